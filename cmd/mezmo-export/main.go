@@ -0,0 +1,102 @@
+// mezmo-export writes the Terraform configuration for an existing pipeline, together with the
+// `import` blocks needed to bring its resources under Terraform management.
+//
+//	mezmo-export -pipeline <pipeline id> -out ./my-pipeline
+package main
+
+import (
+	"context"
+	"errors"
+	"flag"
+	"fmt"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/mezmo/terraform-provider-mezmo/v5/internal/client"
+	"github.com/mezmo/terraform-provider-mezmo/v5/pkg/export"
+)
+
+const defaultEndpoint = "https://api.mezmo.com"
+
+type headerFlags map[string]string
+
+func (h headerFlags) String() string {
+	return fmt.Sprint(map[string]string(h))
+}
+
+func (h headerFlags) Set(value string) error {
+	name, v, ok := strings.Cut(value, "=")
+	if !ok || strings.TrimSpace(name) == "" {
+		return fmt.Errorf("expected a header in the form of name=value, got %q", value)
+	}
+	h[strings.TrimSpace(name)] = v
+	return nil
+}
+
+func main() {
+	var pipelineId, endpoint, authKey, outDir string
+	var force bool
+	headers := headerFlags{}
+
+	flag.StringVar(&pipelineId, "pipeline", "", "the id of the pipeline to export (required)")
+	flag.StringVar(&endpoint, "endpoint", envOrDefault("MEZMO_ENDPOINT", defaultEndpoint), "the Mezmo API endpoint. Defaults to $MEZMO_ENDPOINT")
+	flag.StringVar(&authKey, "auth-key", os.Getenv("MEZMO_AUTH_KEY"), "the Mezmo API auth key. Defaults to $MEZMO_AUTH_KEY")
+	flag.Var(headers, "header", "an additional request header in the form of name=value. Can be repeated")
+	flag.StringVar(&outDir, "out", ".", "the directory to write the generated files to")
+	flag.BoolVar(&force, "force", false, "overwrite existing files in the output directory")
+	flag.Parse()
+
+	if pipelineId == "" || authKey == "" {
+		fmt.Fprintln(os.Stderr, "both -pipeline and -auth-key (or $MEZMO_AUTH_KEY) are required")
+		flag.Usage()
+		os.Exit(2)
+	}
+
+	if err := run(pipelineId, client.NewClient(endpoint, authKey, headers), outDir, force); err != nil {
+		fmt.Fprintln(os.Stderr, "error:", err)
+		os.Exit(1)
+	}
+}
+
+func run(pipelineId string, reader export.Reader, outDir string, force bool) error {
+	result, err := export.Export(context.Background(), reader, pipelineId)
+	if err != nil {
+		return err
+	}
+
+	if err := os.MkdirAll(outDir, 0o755); err != nil {
+		return err
+	}
+	if !force {
+		for _, file := range result.Files {
+			_, err := os.Stat(filepath.Join(outDir, file.Name))
+			if err == nil {
+				return fmt.Errorf("%s already exists, use -force to overwrite it", filepath.Join(outDir, file.Name))
+			}
+			if !errors.Is(err, fs.ErrNotExist) {
+				return err
+			}
+		}
+	}
+	for _, file := range result.Files {
+		path := filepath.Join(outDir, file.Name)
+		if err := os.WriteFile(path, file.Content, 0o644); err != nil {
+			return err
+		}
+		fmt.Println("wrote", path)
+	}
+
+	for _, warning := range result.Warnings {
+		fmt.Fprintln(os.Stderr, "warning:", warning)
+	}
+	return nil
+}
+
+func envOrDefault(name string, defaultValue string) string {
+	if value := os.Getenv(name); value != "" {
+		return value
+	}
+	return defaultValue
+}
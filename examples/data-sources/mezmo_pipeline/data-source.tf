@@ -0,0 +1,21 @@
+terraform {
+  required_providers {
+    mezmo = {
+      source = "registry.terraform.io/mezmo/mezmo"
+    }
+  }
+  required_version = ">= 1.1.0"
+}
+
+provider "mezmo" {
+  auth_key = "my secret"
+}
+
+data "mezmo_pipeline" "by_title" {
+  title = "Platform pipeline"
+}
+
+resource "mezmo_http_source" "team_source" {
+  pipeline_id = data.mezmo_pipeline.by_title.id
+  title       = "Team HTTP source"
+}
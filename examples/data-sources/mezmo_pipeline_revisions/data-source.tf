@@ -0,0 +1,35 @@
+terraform {
+  required_providers {
+    mezmo = {
+      source = "registry.terraform.io/mezmo/mezmo"
+    }
+  }
+  required_version = ">= 1.1.0"
+}
+
+provider "mezmo" {
+  auth_key = "my secret"
+}
+
+variable "pipeline_id" {
+  type = string
+}
+
+variable "rollback_revision" {
+  type        = string
+  default     = null
+  description = "The id of a revision to publish again, e.g. to undo a bad deploy"
+}
+
+data "mezmo_pipeline_revisions" "history" {
+  pipeline_id = var.pipeline_id
+}
+
+output "revisions" {
+  value = data.mezmo_pipeline_revisions.history.revisions
+}
+
+resource "mezmo_publish_pipeline" "publish" {
+  pipeline_id = var.pipeline_id
+  revision    = var.rollback_revision
+}
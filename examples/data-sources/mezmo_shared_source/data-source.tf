@@ -0,0 +1,30 @@
+terraform {
+  required_providers {
+    mezmo = {
+      source = "registry.terraform.io/mezmo/mezmo"
+    }
+  }
+  required_version = ">= 1.1.0"
+}
+
+provider "mezmo" {
+  auth_key = "my secret"
+}
+
+variable "shared_source_id" {
+  type = string
+}
+
+data "mezmo_shared_source" "platform" {
+  id = var.shared_source_id
+}
+
+resource "mezmo_pipeline" "team" {
+  title = "Team pipeline"
+}
+
+resource "mezmo_http_source" "from_shared" {
+  pipeline_id      = mezmo_pipeline.team.id
+  title            = data.mezmo_shared_source.platform.title
+  shared_source_id = data.mezmo_shared_source.platform.id
+}
@@ -0,0 +1,24 @@
+terraform {
+  required_providers {
+    mezmo = {
+      source = "registry.terraform.io/mezmo/mezmo"
+    }
+  }
+  required_version = ">= 1.10.0"
+}
+
+provider "mezmo" {
+  auth_key = "my secret"
+}
+
+resource "mezmo_shared_source" "deploy_logs" {
+  title = "Deployment logs"
+  type  = "http"
+}
+
+# The key is deleted when the run is done. It can be passed to provider
+# configurations and other ephemeral resources, and is never stored in the state.
+ephemeral "mezmo_access_key" "deploy" {
+  title     = "key for this deployment"
+  source_id = mezmo_shared_source.deploy_logs.id
+}
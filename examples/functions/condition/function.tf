@@ -0,0 +1,29 @@
+terraform {
+  required_providers {
+    mezmo = {
+      source = "registry.terraform.io/mezmo/mezmo"
+    }
+  }
+  required_version = ">= 1.8.0"
+}
+
+provider "mezmo" {
+  auth_key = "my secret"
+}
+
+resource "mezmo_pipeline" "my_pipeline" {
+  title = "pipeline"
+}
+
+resource "mezmo_http_source" "my_source" {
+  pipeline_id = mezmo_pipeline.my_pipeline.id
+}
+
+resource "mezmo_filter_processor" "server_errors" {
+  pipeline_id = mezmo_pipeline.my_pipeline.id
+  inputs      = [mezmo_http_source.my_source.id]
+  action      = "allow"
+  conditional = provider::mezmo::condition(
+    "status >= 500 && (service == \"api\" || negate(starts_with(path, \"/health\")))"
+  )
+}
@@ -0,0 +1,3 @@
+# Access keys can be imported by their source id and key id. The cleartext key
+# is only available on creation, so `key` stays empty after an import.
+terraform import mezmo_access_key.for_http <source_id>/<key_id>
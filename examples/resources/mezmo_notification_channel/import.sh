@@ -0,0 +1,2 @@
+# Notification channels can be imported by their id
+terraform import mezmo_notification_channel.on_call <notification_channel_id>
@@ -0,0 +1,43 @@
+terraform {
+  required_providers {
+    mezmo = {
+      source = "registry.terraform.io/mezmo/mezmo"
+    }
+  }
+  required_version = ">= 1.1.0"
+}
+
+provider "mezmo" {
+  auth_key = "my secret"
+}
+
+resource "mezmo_notification_channel" "on_call" {
+  title = "On-call Slack"
+  service = {
+    name         = "slack"
+    uri          = "https://hooks.slack.com/services/T000/B000/XXXX"
+    message_text = "{{.name}} alert fired"
+  }
+}
+
+resource "mezmo_pipeline" "pipeline1" {
+  title = "My pipeline"
+}
+
+resource "mezmo_http_source" "source1" {
+  pipeline_id = mezmo_pipeline.pipeline1.id
+  title       = "My HTTP source"
+}
+
+resource "mezmo_absence_alert" "no_data" {
+  pipeline_id             = mezmo_pipeline.pipeline1.id
+  component_kind          = "source"
+  component_id            = mezmo_http_source.source1.id
+  inputs                  = [mezmo_http_source.source1.id]
+  name                    = "no data received"
+  event_type              = "metric"
+  window_duration_minutes = 15
+  alert_payload = {
+    channel_id = mezmo_notification_channel.on_call.id
+  }
+}
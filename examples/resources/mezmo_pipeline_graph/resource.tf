@@ -0,0 +1,48 @@
+terraform {
+  required_providers {
+    mezmo = {
+      source = "registry.terraform.io/mezmo/mezmo"
+    }
+  }
+  required_version = ">= 1.1.0"
+}
+
+provider "mezmo" {
+  auth_key = "my secret"
+}
+
+resource "mezmo_pipeline" "pipeline1" {
+  title = "My pipeline"
+}
+
+resource "mezmo_pipeline_graph" "graph" {
+  pipeline_id = mezmo_pipeline.pipeline1.id
+  nodes = {
+    source = {
+      http_source = {
+        title    = "My HTTP source"
+        decoding = "json"
+      }
+    }
+    drop_secrets = {
+      inputs = ["source"]
+      drop_fields_processor = {
+        title  = "Drop secrets"
+        fields = [".password", ".token"]
+      }
+    }
+    logs = {
+      inputs = ["drop_secrets"]
+      mezmo_destination = {
+        title         = "Mezmo log analysis"
+        ingestion_key = "my ingestion key"
+      }
+    }
+    archive = {
+      inputs = ["source"]
+      blackhole_destination = {
+        title = "Archive"
+      }
+    }
+  }
+}
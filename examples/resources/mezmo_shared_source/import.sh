@@ -0,0 +1,2 @@
+# Shared sources can be imported by their id
+terraform import mezmo_shared_source.http <shared_source_id>
@@ -0,0 +1,389 @@
+// Package cassette records the requests made to the api and their responses to fixture files,
+// then replays them so that tests can run without the services.
+package cassette
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"os"
+	"path/filepath"
+	"strings"
+	"sync"
+)
+
+type Mode string
+
+const (
+	// Requests are sent to the api, and appended to the cassette with their responses
+	MODE_RECORD Mode = "record"
+	// Requests are answered from the cassette, and fail when no recorded request matches
+	MODE_REPLAY Mode = "replay"
+)
+
+// Environment variables set by the tests that record or replay a cassette, and read by the test
+// provider factories
+const (
+	ENV_PATH = "MEZMO_TEST_CASSETTE"
+	ENV_MODE = "MEZMO_TEST_CASSETTE_MODE"
+)
+
+// Replaces the `Authorization` header and the sensitive fields in the cassettes
+const REDACTED = "REDACTED"
+
+// Sensitive fields are only redacted within these objects of the request and response bodies
+var redactedObjects = map[string]bool{
+	"user_config":  true,
+	"alert_config": true,
+	"service":      true,
+}
+
+// Only these response headers are recorded, the others vary between runs
+var recordedResponseHeaders = []string{"Content-Type", "Retry-After"}
+
+type Request struct {
+	Method  string            `json:"method"`
+	Url     string            `json:"url"`
+	Headers map[string]string `json:"headers,omitempty"`
+	Body    json.RawMessage   `json:"body,omitempty"`
+}
+
+type Response struct {
+	Status  int               `json:"status"`
+	Headers map[string]string `json:"headers,omitempty"`
+	Body    json.RawMessage   `json:"body,omitempty"`
+}
+
+type Interaction struct {
+	Request  Request  `json:"request"`
+	Response Response `json:"response"`
+}
+
+type cassetteFile struct {
+	Interactions []Interaction `json:"interactions"`
+}
+
+// An `http.RoundTripper` that records or replays the interactions of a cassette file
+type Recorder struct {
+	mu           sync.Mutex
+	path         string
+	mode         Mode
+	transport    http.RoundTripper
+	sensitive    map[string]bool
+	interactions []Interaction
+	used         []bool
+	// Values of the sensitive fields sent during the replay, which are put back in the
+	// responses in place of REDACTED. They are keyed by their path in the redacted object.
+	secrets map[string]any
+}
+
+// Creates a recorder for the cassette at `path`. In replay mode, the cassette must exist.
+// `sensitiveKeys` are the names of the fields whose values are never written to the cassette.
+func New(path string, mode Mode, sensitiveKeys []string) (*Recorder, error) {
+	r := &Recorder{
+		path:      path,
+		mode:      mode,
+		transport: http.DefaultTransport,
+		sensitive: map[string]bool{},
+		secrets:   map[string]any{},
+	}
+	r.addSensitiveKeys(sensitiveKeys)
+
+	switch mode {
+	case MODE_RECORD:
+	case MODE_REPLAY:
+		content, err := os.ReadFile(path)
+		if err != nil {
+			return nil, fmt.Errorf("cannot read cassette: %w", err)
+		}
+		var file cassetteFile
+		if err := json.Unmarshal(content, &file); err != nil {
+			return nil, fmt.Errorf("cannot parse cassette %s: %w", path, err)
+		}
+		// Bodies are indented in the file, but are compared with the compact json of the requests
+		for i, interaction := range file.Interactions {
+			var body bytes.Buffer
+			if err := json.Compact(&body, interaction.Request.Body); err == nil {
+				file.Interactions[i].Request.Body = body.Bytes()
+			}
+		}
+		r.interactions = file.Interactions
+		r.used = make([]bool, len(file.Interactions))
+	default:
+		return nil, fmt.Errorf("unknown cassette mode %q, expected %q or %q", mode, MODE_RECORD, MODE_REPLAY)
+	}
+	return r, nil
+}
+
+func (r *Recorder) addSensitiveKeys(keys []string) {
+	for _, key := range keys {
+		r.sensitive[key] = true
+	}
+}
+
+func (r *Recorder) RoundTrip(req *http.Request) (*http.Response, error) {
+	return r.roundTrip(req, r.transport)
+}
+
+// Returns a transport that shares the interactions of the recorder, but sends the recorded
+// requests through `transport`, e.g. the one configured with the certificates and proxy of the
+// provider.
+func (r *Recorder) Through(transport http.RoundTripper) http.RoundTripper {
+	return &recorderTransport{recorder: r, transport: transport}
+}
+
+type recorderTransport struct {
+	recorder  *Recorder
+	transport http.RoundTripper
+}
+
+func (t *recorderTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	return t.recorder.roundTrip(req, t.transport)
+}
+
+func (r *Recorder) roundTrip(req *http.Request, transport http.RoundTripper) (*http.Response, error) {
+	var body []byte
+	if req.Body != nil {
+		var err error
+		body, err = io.ReadAll(req.Body)
+		req.Body.Close()
+		if err != nil {
+			return nil, err
+		}
+		req.Body = io.NopCloser(bytes.NewReader(body))
+	}
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	recorded := Request{
+		Method:  req.Method,
+		Url:     req.URL.RequestURI(),
+		Headers: map[string]string{},
+		Body:    r.redactBody(body, r.secrets),
+	}
+	for name := range req.Header {
+		recorded.Headers[name] = req.Header.Get(name)
+	}
+	if _, ok := recorded.Headers["Authorization"]; ok {
+		recorded.Headers["Authorization"] = REDACTED
+	}
+
+	if r.mode == MODE_REPLAY {
+		return r.replay(req, recorded)
+	}
+	return r.record(req, recorded, transport)
+}
+
+func (r *Recorder) record(req *http.Request, recorded Request, transport http.RoundTripper) (*http.Response, error) {
+	resp, err := transport.RoundTrip(req)
+	if err != nil {
+		return nil, err
+	}
+	body, err := io.ReadAll(resp.Body)
+	resp.Body.Close()
+	if err != nil {
+		return nil, err
+	}
+	resp.Body = io.NopCloser(bytes.NewReader(body))
+
+	response := Response{
+		Status:  resp.StatusCode,
+		Headers: map[string]string{},
+		Body:    r.redactBody(body, nil),
+	}
+	for _, name := range recordedResponseHeaders {
+		if value := resp.Header.Get(name); value != "" {
+			response.Headers[name] = value
+		}
+	}
+	r.interactions = append(r.interactions, Interaction{Request: recorded, Response: response})
+	return resp, nil
+}
+
+// Answers with the first interaction that was not replayed yet and has the same method, url and body
+func (r *Recorder) replay(req *http.Request, recorded Request) (*http.Response, error) {
+	for i, interaction := range r.interactions {
+		if r.used[i] || !sameRequest(interaction.Request, recorded) {
+			continue
+		}
+		r.used[i] = true
+
+		body := decodeBody(r.restoreSecrets(interaction.Response.Body))
+		header := http.Header{}
+		for name, value := range interaction.Response.Headers {
+			header.Set(name, value)
+		}
+		status := interaction.Response.Status
+		return &http.Response{
+			Status:        fmt.Sprintf("%d %s", status, http.StatusText(status)),
+			StatusCode:    status,
+			Proto:         "HTTP/1.1",
+			ProtoMajor:    1,
+			ProtoMinor:    1,
+			Header:        header,
+			Body:          io.NopCloser(bytes.NewReader(body)),
+			ContentLength: int64(len(body)),
+			Request:       req,
+		}, nil
+	}
+	return nil, fmt.Errorf("cassette %s has no recorded response for %s %s", r.path, recorded.Method, recorded.Url)
+}
+
+func sameRequest(a Request, b Request) bool {
+	return a.Method == b.Method && a.Url == b.Url && bytes.Equal(a.Body, b.Body)
+}
+
+// Writes the recorded interactions to the cassette. Nothing is written in replay mode.
+func (r *Recorder) Save() error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	if r.mode != MODE_RECORD {
+		return nil
+	}
+	interactions := r.interactions
+	if interactions == nil {
+		interactions = []Interaction{}
+	}
+	content, err := json.MarshalIndent(cassetteFile{Interactions: interactions}, "", "  ")
+	if err != nil {
+		return err
+	}
+	if err := os.MkdirAll(filepath.Dir(r.path), 0o755); err != nil {
+		return err
+	}
+	return os.WriteFile(r.path, append(content, '\n'), 0o644)
+}
+
+// Returns the body as json with the sensitive fields redacted and sorted keys, so that it can be
+// compared between runs. Bodies that are not json are stored as a string. When `secrets` is given,
+// the redacted values are saved in it.
+func (r *Recorder) redactBody(body []byte, secrets map[string]any) json.RawMessage {
+	if len(body) == 0 {
+		return nil
+	}
+	decoder := json.NewDecoder(bytes.NewReader(body))
+	decoder.UseNumber()
+	var value any
+	if err := decoder.Decode(&value); err != nil || decoder.More() {
+		text, _ := json.Marshal(string(body))
+		return text
+	}
+	value = r.redact(value, "", false, secrets)
+	content, _ := json.Marshal(value)
+	return content
+}
+
+func (r *Recorder) redact(value any, path string, inRedactedObject bool, secrets map[string]any) any {
+	switch value := value.(type) {
+	case map[string]any:
+		for key, child := range value {
+			childPath := path + "/" + key
+			if inRedactedObject && r.sensitive[key] && child != nil {
+				if _, isObject := child.(map[string]any); !isObject {
+					if secrets != nil {
+						secrets[childPath] = child
+					}
+					value[key] = REDACTED
+					continue
+				}
+			}
+			if !inRedactedObject && redactedObjects[key] {
+				// Paths of secrets start at the redacted object, whatever contains it
+				childPath = key
+			}
+			value[key] = r.redact(child, childPath, inRedactedObject || redactedObjects[key], secrets)
+		}
+	case []any:
+		for i, child := range value {
+			value[i] = r.redact(child, fmt.Sprintf("%s/%d", path, i), inRedactedObject, secrets)
+		}
+	}
+	return value
+}
+
+// Puts back the values sent during the replay in place of the redacted fields of a response
+func (r *Recorder) restoreSecrets(body json.RawMessage) json.RawMessage {
+	if len(r.secrets) == 0 || !bytes.Contains(body, []byte(REDACTED)) {
+		return body
+	}
+	decoder := json.NewDecoder(bytes.NewReader(body))
+	decoder.UseNumber()
+	var value any
+	if err := decoder.Decode(&value); err != nil {
+		return body
+	}
+	content, _ := json.Marshal(r.restore(value, "", false))
+	return content
+}
+
+func (r *Recorder) restore(value any, path string, inRedactedObject bool) any {
+	switch value := value.(type) {
+	case map[string]any:
+		for key, child := range value {
+			childPath := path + "/" + key
+			if !inRedactedObject && redactedObjects[key] {
+				childPath = key
+			}
+			if secret, ok := r.secrets[childPath]; ok && inRedactedObject && child == REDACTED {
+				value[key] = secret
+				continue
+			}
+			value[key] = r.restore(child, childPath, inRedactedObject || redactedObjects[key])
+		}
+	case []any:
+		for i, child := range value {
+			value[i] = r.restore(child, fmt.Sprintf("%s/%d", path, i), inRedactedObject)
+		}
+	}
+	return value
+}
+
+func decodeBody(body json.RawMessage) []byte {
+	if strings.HasPrefix(string(body), `"`) {
+		var text string
+		if err := json.Unmarshal(body, &text); err == nil {
+			return []byte(text)
+		}
+	}
+	return body
+}
+
+var registryMutex sync.Mutex
+var registry = map[string]*Recorder{}
+
+// Returns the recorder of the cassette at `path`, creating it on first use. The provider is
+// configured several times during a test, and every client has to share the same recorder.
+func Open(path string, mode Mode, sensitiveKeys []string) (*Recorder, error) {
+	registryMutex.Lock()
+	defer registryMutex.Unlock()
+	if r, ok := registry[path]; ok {
+		if r.mode != mode {
+			return nil, fmt.Errorf("cassette %s is already open in %s mode", path, r.mode)
+		}
+		r.mu.Lock()
+		r.addSensitiveKeys(sensitiveKeys)
+		r.mu.Unlock()
+		return r, nil
+	}
+	r, err := New(path, mode, sensitiveKeys)
+	if err != nil {
+		return nil, err
+	}
+	registry[path] = r
+	return r, nil
+}
+
+// Saves the cassette at `path` if it was opened, and forgets its recorder
+func Close(path string) error {
+	registryMutex.Lock()
+	r, ok := registry[path]
+	delete(registry, path)
+	registryMutex.Unlock()
+	if !ok {
+		return nil
+	}
+	return r.Save()
+}
@@ -6,6 +6,7 @@ import (
 	"fmt"
 	"io"
 	"net/http"
+	"time"
 
 	"context"
 
@@ -14,7 +15,9 @@ import (
 )
 
 type Client interface {
+	ListPipelines(ctx context.Context) ([]Pipeline, error)
 	Pipeline(id string, ctx context.Context) (*Pipeline, error)
+	PipelineGraph(id string, ctx context.Context) (*PipelineGraph, error)
 	CreatePipeline(pipeline *Pipeline, ctx context.Context) (*Pipeline, error)
 	UpdatePipeline(pipeline *Pipeline, ctx context.Context) (*Pipeline, error)
 	DeletePipeline(id string, ctx context.Context) error
@@ -23,46 +26,85 @@ type Client interface {
 	CreateSource(pipelineId string, component *Source, ctx context.Context) (*Source, error)
 	UpdateSource(pipelineId string, component *Source, ctx context.Context) (*Source, error)
 	DeleteSource(pipelineId string, id string, ctx context.Context) error
+	ListSources(pipelineId string, ctx context.Context) ([]Source, error)
 
 	Destination(pipelineId string, id string, ctx context.Context) (*Destination, error)
 	CreateDestination(pipelineId string, component *Destination, ctx context.Context) (*Destination, error)
 	UpdateDestination(pipelineId string, component *Destination, ctx context.Context) (*Destination, error)
 	DeleteDestination(pipelineId string, id string, ctx context.Context) error
+	ListDestinations(pipelineId string, ctx context.Context) ([]Destination, error)
 
 	Processor(pipelineId string, id string, ctx context.Context) (*Processor, error)
 	CreateProcessor(pipelineId string, component *Processor, ctx context.Context) (*Processor, error)
 	UpdateProcessor(pipelineId string, component *Processor, ctx context.Context) (*Processor, error)
 	DeleteProcessor(pipelineId string, id string, ctx context.Context) error
+	ListProcessors(pipelineId string, ctx context.Context) ([]Processor, error)
 
 	Alert(pipelineId string, id string, ctx context.Context) (*Alert, error)
 	CreateAlert(pipelineId string, alert *Alert, ctx context.Context) (*Alert, error) // POST
 	UpdateAlert(pipelineId string, alert *Alert, ctx context.Context) (*Alert, error) // PUT
 	DeleteAlert(pipelineId string, alert *Alert, ctx context.Context) error           // DELETE
+	ListAlerts(pipelineId string, ctx context.Context) ([]Alert, error)
 
+	AccessKey(sourceId string, id string, ctx context.Context) (*AccessKey, error)
 	CreateAccessKey(accessKey *AccessKey, ctx context.Context) (*AccessKey, error)
 	DeleteAccessKey(accessKey *AccessKey, ctx context.Context) error
+	ListAccessKeys(sourceId string, ctx context.Context) ([]AccessKey, error)
 
 	SharedSource(id string, ctx context.Context) (*SharedSource, error)
 	CreateSharedSource(source *SharedSource, ctx context.Context) (*SharedSource, error)
 	UpdateSharedSource(source *SharedSource, ctx context.Context) (*SharedSource, error)
 	DeleteSharedSource(source *SharedSource, ctx context.Context) error
 
+	NotificationChannel(id string, ctx context.Context) (*NotificationChannel, error)
+	CreateNotificationChannel(channel *NotificationChannel, ctx context.Context) (*NotificationChannel, error)
+	UpdateNotificationChannel(channel *NotificationChannel, ctx context.Context) (*NotificationChannel, error)
+	DeleteNotificationChannel(id string, ctx context.Context) error
+
 	PublishPipeline(pipelineId string, ctx context.Context) (*PublishPipeline, error)
+	PublishPipelineRevision(pipelineId string, revisionId string, ctx context.Context) (*PublishPipeline, error)
+	ListPipelineRevisions(pipelineId string, ctx context.Context) ([]PipelineRevision, error)
+	PipelineDeployment(pipelineId string, ctx context.Context) (*PipelineDeployment, error)
+}
+
+// Requests that take longer than this, including reading the response body, are aborted
+const DefaultRequestTimeout = 60 * time.Second
+
+type ClientOption func(*client)
+
+// Sets the timeout of each individual request attempt. Retries get their own timeout.
+func WithRequestTimeout(timeout time.Duration) ClientOption {
+	return func(c *client) {
+		c.httpClient.Timeout = timeout
+	}
+}
+
+// Sends the requests through `transport` instead of `http.DefaultTransport`, e.g. to record
+// and replay them in tests with the `cassette` package.
+func WithTransport(transport http.RoundTripper) ClientOption {
+	return func(c *client) {
+		c.httpClient.Transport = transport
+	}
 }
 
-func NewClient(endpoint string, authKey string, headers map[string]string) Client {
-	return &client{
-		httpClient: &http.Client{},
+func NewClient(endpoint string, authKey string, headers map[string]string, options ...ClientOption) Client {
+	c := &client{
+		httpClient: &http.Client{Timeout: DefaultRequestTimeout},
 		endpoint:   endpoint,
 		authKey:    authKey,
 		headers:    headers,
+		retry:      DefaultRetryOptions(),
 	}
+	for _, option := range options {
+		option(c)
+	}
+	return c
 }
 
-func (c *client) newRequest(method string, url string, body io.Reader) *http.Request {
-	req, err := http.NewRequest(method, url, body)
+func (c *client) newRequest(method string, url string, body io.Reader, ctx context.Context) *http.Request {
+	req, err := http.NewRequestWithContext(ctx, method, url, body)
 	if err != nil {
-		// Creating a request only fails if the method is invalid
+		// Creating a request only fails if the method is invalid or the context is nil
 		panic(err)
 	}
 	if c.authKey != "" {
@@ -80,11 +122,11 @@ func (c *client) newRequest(method string, url string, body io.Reader) *http.Req
 	return req
 }
 
-func readBody(result any, resp *http.Response, ctx context.Context) error {
+func (c *client) readBody(result any, resp *http.Response, ctx context.Context) error {
 	defer resp.Body.Close()
 	bodyBuffer, err := io.ReadAll(resp.Body)
 	if err != nil {
-		return err
+		return c.timeoutError(resp.Request, err)
 	}
 	if resp.StatusCode < http.StatusOK || resp.StatusCode > http.StatusNoContent {
 		return newAPIError(ctx, resp.StatusCode, bodyBuffer, nil)
@@ -102,9 +144,10 @@ func readBody(result any, resp *http.Response, ctx context.Context) error {
 	return err
 }
 
-// Envelope is {meta, data}, but meta is not used
+// Envelope is {meta, data}. Meta only carries pagination details of list responses.
 type apiResponseEnvelope[T any] struct {
-	Data T `json:"data"`
+	Meta apiResponseMeta `json:"meta"`
+	Data T               `json:"data"`
 }
 
 type client struct {
@@ -112,6 +155,7 @@ type client struct {
 	endpoint   string
 	authKey    string
 	headers    map[string]string
+	retry      RetryOptions
 }
 
 // CreatePipeline implements Client.
@@ -123,13 +167,13 @@ func (c *client) CreatePipeline(pipeline *Pipeline, ctx context.Context) (*Pipel
 	if err != nil {
 		return nil, err
 	}
-	req := c.newRequest(http.MethodPost, url, bytes.NewReader(reqBody))
-	resp, err := c.httpClient.Do(req)
+	req := c.newRequest(http.MethodPost, url, bytes.NewReader(reqBody), ctx)
+	resp, err := c.do(req, ctx)
 	if err != nil {
 		return nil, err
 	}
 	var envelope apiResponseEnvelope[Pipeline]
-	if err := readBody(&envelope, resp, ctx); err != nil {
+	if err := c.readBody(&envelope, resp, ctx); err != nil {
 		return nil, err
 	}
 	created := &envelope.Data
@@ -141,12 +185,12 @@ func (c *client) DeletePipeline(id string, ctx context.Context) error {
 	url := fmt.Sprintf("%s/v3/pipeline/%s", c.endpoint, id)
 	msg := fmt.Sprintf("-- Pipeline request to DELETE %s", url)
 	tflog.Trace(ctx, msg)
-	req := c.newRequest(http.MethodDelete, url, nil)
-	resp, err := c.httpClient.Do(req)
+	req := c.newRequest(http.MethodDelete, url, nil, ctx)
+	resp, err := c.do(req, ctx)
 	if err != nil {
 		return err
 	}
-	return readBody(nil, resp, ctx)
+	return c.readBody(nil, resp, ctx)
 }
 
 // Pipeline implements Client.
@@ -154,19 +198,47 @@ func (c *client) Pipeline(id string, ctx context.Context) (*Pipeline, error) {
 	url := fmt.Sprintf("%s/v3/pipeline/%s", c.endpoint, id)
 	msg := fmt.Sprintf("-- Pipeline request to GET %s", url)
 	tflog.Trace(ctx, msg)
-	req := c.newRequest(http.MethodGet, url, nil)
-	resp, err := c.httpClient.Do(req)
+	req := c.newRequest(http.MethodGet, url, nil, ctx)
+	resp, err := c.do(req, ctx)
 	if err != nil {
 		return nil, err
 	}
 	var envelope apiResponseEnvelope[Pipeline]
-	if err := readBody(&envelope, resp, ctx); err != nil {
+	if err := c.readBody(&envelope, resp, ctx); err != nil {
 		return nil, err
 	}
 	pipeline := &envelope.Data
 	return pipeline, nil
 }
 
+// ListPipelines implements Client.
+func (c *client) ListPipelines(ctx context.Context) ([]Pipeline, error) {
+	url := fmt.Sprintf("%s/v3/pipeline", c.endpoint)
+	return listAll[Pipeline](c, url, ctx)
+}
+
+// PipelineGraph implements Client.
+func (c *client) PipelineGraph(id string, ctx context.Context) (*PipelineGraph, error) {
+	pipeline, err := c.Pipeline(id, ctx)
+	if err != nil {
+		return nil, err
+	}
+	graph := &PipelineGraph{Pipeline: *pipeline}
+	if graph.Sources, err = c.ListSources(id, ctx); err != nil {
+		return nil, err
+	}
+	if graph.Processors, err = c.ListProcessors(id, ctx); err != nil {
+		return nil, err
+	}
+	if graph.Destinations, err = c.ListDestinations(id, ctx); err != nil {
+		return nil, err
+	}
+	if graph.Alerts, err = c.ListAlerts(id, ctx); err != nil {
+		return nil, err
+	}
+	return graph, nil
+}
+
 // UpdatePipeline implements Client.
 func (c *client) UpdatePipeline(pipeline *Pipeline, ctx context.Context) (*Pipeline, error) {
 	url := fmt.Sprintf("%s/v3/pipeline/%s", c.endpoint, pipeline.Id)
@@ -176,13 +248,13 @@ func (c *client) UpdatePipeline(pipeline *Pipeline, ctx context.Context) (*Pipel
 	if err != nil {
 		return nil, err
 	}
-	req := c.newRequest(http.MethodPut, url, bytes.NewReader(reqBody))
-	resp, err := c.httpClient.Do(req)
+	req := c.newRequest(http.MethodPut, url, bytes.NewReader(reqBody), ctx)
+	resp, err := c.do(req, ctx)
 	if err != nil {
 		return nil, err
 	}
 	var envelope apiResponseEnvelope[Pipeline]
-	if err := readBody(&envelope, resp, ctx); err != nil {
+	if err := c.readBody(&envelope, resp, ctx); err != nil {
 		return nil, err
 	}
 	updated := &envelope.Data
@@ -198,13 +270,13 @@ func (c *client) CreateSource(pipelineId string, component *Source, ctx context.
 	if err != nil {
 		return nil, err
 	}
-	req := c.newRequest(http.MethodPost, url, bytes.NewReader(reqBody))
-	resp, err := c.httpClient.Do(req)
+	req := c.newRequest(http.MethodPost, url, bytes.NewReader(reqBody), ctx)
+	resp, err := c.do(req, ctx)
 	if err != nil {
 		return nil, err
 	}
 	var envelope apiResponseEnvelope[Source]
-	if err := readBody(&envelope, resp, ctx); err != nil {
+	if err := c.readBody(&envelope, resp, ctx); err != nil {
 		return nil, err
 	}
 
@@ -217,12 +289,12 @@ func (c *client) DeleteSource(pipelineId string, id string, ctx context.Context)
 	url := fmt.Sprintf("%s/v3/pipeline/%s/source/%s", c.endpoint, pipelineId, id)
 	msg := fmt.Sprintf("-- Source request to DELETE %s", url)
 	tflog.Trace(ctx, msg)
-	req := c.newRequest(http.MethodDelete, url, nil)
-	resp, err := c.httpClient.Do(req)
+	req := c.newRequest(http.MethodDelete, url, nil, ctx)
+	resp, err := c.do(req, ctx)
 	if err != nil {
 		return err
 	}
-	return readBody(nil, resp, ctx)
+	return c.readBody(nil, resp, ctx)
 }
 
 // GET Source
@@ -230,13 +302,13 @@ func (c *client) Source(pipelineId string, id string, ctx context.Context) (*Sou
 	url := fmt.Sprintf("%s/v3/pipeline/%s/source/%s", c.endpoint, pipelineId, id)
 	msg := fmt.Sprintf("-- Source request to GET %s", url)
 	tflog.Trace(ctx, msg)
-	req := c.newRequest(http.MethodGet, url, nil)
-	resp, err := c.httpClient.Do(req)
+	req := c.newRequest(http.MethodGet, url, nil, ctx)
+	resp, err := c.do(req, ctx)
 	if err != nil {
 		return nil, err
 	}
 	var envelope apiResponseEnvelope[Source]
-	if err := readBody(&envelope, resp, ctx); err != nil {
+	if err := c.readBody(&envelope, resp, ctx); err != nil {
 		return nil, err
 	}
 	source := &envelope.Data
@@ -252,13 +324,13 @@ func (c *client) UpdateSource(pipelineId string, component *Source, ctx context.
 	if err != nil {
 		return nil, err
 	}
-	req := c.newRequest(http.MethodPut, url, bytes.NewReader(reqBody))
-	resp, err := c.httpClient.Do(req)
+	req := c.newRequest(http.MethodPut, url, bytes.NewReader(reqBody), ctx)
+	resp, err := c.do(req, ctx)
 	if err != nil {
 		return nil, err
 	}
 	var envelope apiResponseEnvelope[Source]
-	if err := readBody(&envelope, resp, ctx); err != nil {
+	if err := c.readBody(&envelope, resp, ctx); err != nil {
 		return nil, err
 	}
 	defer resp.Body.Close()
@@ -267,18 +339,24 @@ func (c *client) UpdateSource(pipelineId string, component *Source, ctx context.
 	return source, nil
 }
 
+// GET all Sources of a pipeline
+func (c *client) ListSources(pipelineId string, ctx context.Context) ([]Source, error) {
+	url := fmt.Sprintf("%s/v3/pipeline/%s/source", c.endpoint, pipelineId)
+	return listAll[Source](c, url, ctx)
+}
+
 // GET Destination (sink)
 func (c *client) Destination(pipelineId string, id string, ctx context.Context) (*Destination, error) {
 	url := fmt.Sprintf("%s/v3/pipeline/%s/sink/%s", c.endpoint, pipelineId, id)
 	msg := fmt.Sprintf("-- Destination request to GET %s", url)
 	tflog.Trace(ctx, msg)
-	req := c.newRequest(http.MethodGet, url, nil)
-	resp, err := c.httpClient.Do(req)
+	req := c.newRequest(http.MethodGet, url, nil, ctx)
+	resp, err := c.do(req, ctx)
 	if err != nil {
 		return nil, err
 	}
 	var envelope apiResponseEnvelope[Destination]
-	if err := readBody(&envelope, resp, ctx); err != nil {
+	if err := c.readBody(&envelope, resp, ctx); err != nil {
 		return nil, err
 	}
 	defer resp.Body.Close()
@@ -296,13 +374,13 @@ func (c *client) CreateDestination(pipelineId string, component *Destination, ct
 	if err != nil {
 		return nil, err
 	}
-	req := c.newRequest(http.MethodPost, url, bytes.NewReader(reqBody))
-	resp, err := c.httpClient.Do(req)
+	req := c.newRequest(http.MethodPost, url, bytes.NewReader(reqBody), ctx)
+	resp, err := c.do(req, ctx)
 	if err != nil {
 		return nil, err
 	}
 	var envelope apiResponseEnvelope[Destination]
-	if err := readBody(&envelope, resp, ctx); err != nil {
+	if err := c.readBody(&envelope, resp, ctx); err != nil {
 		return nil, err
 	}
 	defer resp.Body.Close()
@@ -316,12 +394,12 @@ func (c *client) DeleteDestination(pipelineId string, id string, ctx context.Con
 	url := fmt.Sprintf("%s/v3/pipeline/%s/sink/%s", c.endpoint, pipelineId, id)
 	msg := fmt.Sprintf("-- Destination request to DELETE %s", url)
 	tflog.Trace(ctx, msg)
-	req := c.newRequest(http.MethodDelete, url, nil)
-	resp, err := c.httpClient.Do(req)
+	req := c.newRequest(http.MethodDelete, url, nil, ctx)
+	resp, err := c.do(req, ctx)
 	if err != nil {
 		return err
 	}
-	return readBody(nil, resp, ctx)
+	return c.readBody(nil, resp, ctx)
 }
 
 // PUT Destination (sink)
@@ -333,31 +411,37 @@ func (c *client) UpdateDestination(pipelineId string, component *Destination, ct
 	if err != nil {
 		return nil, err
 	}
-	req := c.newRequest(http.MethodPut, url, bytes.NewReader(reqBody))
-	resp, err := c.httpClient.Do(req)
+	req := c.newRequest(http.MethodPut, url, bytes.NewReader(reqBody), ctx)
+	resp, err := c.do(req, ctx)
 	if err != nil {
 		return nil, err
 	}
 	var envelope apiResponseEnvelope[Destination]
-	if err := readBody(&envelope, resp, ctx); err != nil {
+	if err := c.readBody(&envelope, resp, ctx); err != nil {
 		return nil, err
 	}
 	destination := &envelope.Data
 	return destination, nil
 }
 
+// GET all Destinations (sinks) of a pipeline
+func (c *client) ListDestinations(pipelineId string, ctx context.Context) ([]Destination, error) {
+	url := fmt.Sprintf("%s/v3/pipeline/%s/sink", c.endpoint, pipelineId)
+	return listAll[Destination](c, url, ctx)
+}
+
 // GET Processor (transform)
 func (c *client) Processor(pipelineId string, id string, ctx context.Context) (*Processor, error) {
 	url := fmt.Sprintf("%s/v3/pipeline/%s/transform/%s", c.endpoint, pipelineId, id)
 	msg := fmt.Sprintf("-- Processor request to GET %s", url)
 	tflog.Trace(ctx, msg)
-	req := c.newRequest(http.MethodGet, url, nil)
-	resp, err := c.httpClient.Do(req)
+	req := c.newRequest(http.MethodGet, url, nil, ctx)
+	resp, err := c.do(req, ctx)
 	if err != nil {
 		return nil, err
 	}
 	var envelope apiResponseEnvelope[Processor]
-	if err := readBody(&envelope, resp, ctx); err != nil {
+	if err := c.readBody(&envelope, resp, ctx); err != nil {
 		return nil, err
 	}
 	processor := &envelope.Data
@@ -373,13 +457,13 @@ func (c *client) CreateProcessor(pipelineId string, component *Processor, ctx co
 	if err != nil {
 		return nil, err
 	}
-	req := c.newRequest(http.MethodPost, url, bytes.NewReader(reqBody))
-	resp, err := c.httpClient.Do(req)
+	req := c.newRequest(http.MethodPost, url, bytes.NewReader(reqBody), ctx)
+	resp, err := c.do(req, ctx)
 	if err != nil {
 		return nil, err
 	}
 	var envelope apiResponseEnvelope[Processor]
-	if err := readBody(&envelope, resp, ctx); err != nil {
+	if err := c.readBody(&envelope, resp, ctx); err != nil {
 		return nil, err
 	}
 	processor := &envelope.Data
@@ -391,12 +475,12 @@ func (c *client) DeleteProcessor(pipelineId string, id string, ctx context.Conte
 	url := fmt.Sprintf("%s/v3/pipeline/%s/transform/%s", c.endpoint, pipelineId, id)
 	msg := fmt.Sprintf("-- Processor request to DELETE %s", url)
 	tflog.Trace(ctx, msg)
-	req := c.newRequest(http.MethodDelete, url, nil)
-	resp, err := c.httpClient.Do(req)
+	req := c.newRequest(http.MethodDelete, url, nil, ctx)
+	resp, err := c.do(req, ctx)
 	if err != nil {
 		return err
 	}
-	return readBody(nil, resp, ctx)
+	return c.readBody(nil, resp, ctx)
 }
 
 // PUT Processor (transform)
@@ -408,31 +492,37 @@ func (c *client) UpdateProcessor(pipelineId string, component *Processor, ctx co
 	if err != nil {
 		return nil, err
 	}
-	req := c.newRequest(http.MethodPut, url, bytes.NewReader(reqBody))
-	resp, err := c.httpClient.Do(req)
+	req := c.newRequest(http.MethodPut, url, bytes.NewReader(reqBody), ctx)
+	resp, err := c.do(req, ctx)
 	if err != nil {
 		return nil, err
 	}
 	var envelope apiResponseEnvelope[Processor]
-	if err := readBody(&envelope, resp, ctx); err != nil {
+	if err := c.readBody(&envelope, resp, ctx); err != nil {
 		return nil, err
 	}
 	processor := &envelope.Data
 	return processor, nil
 }
 
+// GET all Processors (transforms) of a pipeline
+func (c *client) ListProcessors(pipelineId string, ctx context.Context) ([]Processor, error) {
+	url := fmt.Sprintf("%s/v3/pipeline/%s/transform", c.endpoint, pipelineId)
+	return listAll[Processor](c, url, ctx)
+}
+
 // GET Alert (not used by the UI)
 func (c *client) Alert(pipelineId string, id string, ctx context.Context) (*Alert, error) {
 	url := fmt.Sprintf("%s/v3/pipeline/%s/alert/%s", c.endpoint, pipelineId, id)
 	msg := fmt.Sprintf("-- Alert request to GET %s", url)
 	tflog.Trace(ctx, msg)
-	req := c.newRequest(http.MethodGet, url, nil)
-	resp, err := c.httpClient.Do(req)
+	req := c.newRequest(http.MethodGet, url, nil, ctx)
+	resp, err := c.do(req, ctx)
 	if err != nil {
 		return nil, err
 	}
 	var envelope apiResponseEnvelope[Alert]
-	if err := readBody(&envelope, resp, ctx); err != nil {
+	if err := c.readBody(&envelope, resp, ctx); err != nil {
 		return nil, err
 	}
 	alert := &envelope.Data
@@ -448,13 +538,13 @@ func (c *client) CreateAlert(pipelineId string, alert *Alert, ctx context.Contex
 	if err != nil {
 		return nil, err
 	}
-	req := c.newRequest(http.MethodPost, url, bytes.NewReader(reqBody))
-	resp, err := c.httpClient.Do(req)
+	req := c.newRequest(http.MethodPost, url, bytes.NewReader(reqBody), ctx)
+	resp, err := c.do(req, ctx)
 	if err != nil {
 		return nil, err
 	}
 	var envelope apiResponseEnvelope[Alert]
-	if err := readBody(&envelope, resp, ctx); err != nil {
+	if err := c.readBody(&envelope, resp, ctx); err != nil {
 		return nil, err
 	}
 	createdAlert := &envelope.Data
@@ -470,13 +560,13 @@ func (c *client) UpdateAlert(pipelineId string, alert *Alert, ctx context.Contex
 	if err != nil {
 		return nil, err
 	}
-	req := c.newRequest(http.MethodPut, url, bytes.NewReader(reqBody))
-	resp, err := c.httpClient.Do(req)
+	req := c.newRequest(http.MethodPut, url, bytes.NewReader(reqBody), ctx)
+	resp, err := c.do(req, ctx)
 	if err != nil {
 		return nil, err
 	}
 	var envelope apiResponseEnvelope[Alert]
-	if err := readBody(&envelope, resp, ctx); err != nil {
+	if err := c.readBody(&envelope, resp, ctx); err != nil {
 		return nil, err
 	}
 	updatedAlert := &envelope.Data
@@ -488,12 +578,18 @@ func (c *client) DeleteAlert(pipelineId string, alert *Alert, ctx context.Contex
 	url := fmt.Sprintf("%s/v3/pipeline/%s/%s/%s/alert/%s", c.endpoint, pipelineId, alert.ComponentKind, alert.ComponentId, alert.Id)
 	msg := fmt.Sprintf("-- Alert request to DELETE %s", url)
 	tflog.Trace(ctx, msg)
-	req := c.newRequest(http.MethodDelete, url, nil)
-	resp, err := c.httpClient.Do(req)
+	req := c.newRequest(http.MethodDelete, url, nil, ctx)
+	resp, err := c.do(req, ctx)
 	if err != nil {
 		return err
 	}
-	return readBody(nil, resp, ctx)
+	return c.readBody(nil, resp, ctx)
+}
+
+// GET all Alerts of a pipeline
+func (c *client) ListAlerts(pipelineId string, ctx context.Context) ([]Alert, error) {
+	url := fmt.Sprintf("%s/v3/pipeline/%s/alert", c.endpoint, pipelineId)
+	return listAll[Alert](c, url, ctx)
 }
 
 // POST Access Key
@@ -505,30 +601,54 @@ func (c *client) CreateAccessKey(accessKey *AccessKey, ctx context.Context) (*Ac
 	if err != nil {
 		return nil, err
 	}
-	req := c.newRequest(http.MethodPost, url, bytes.NewReader(reqBody))
-	resp, err := c.httpClient.Do(req)
+	req := c.newRequest(http.MethodPost, url, bytes.NewReader(reqBody), ctx)
+	resp, err := c.do(req, ctx)
 	if err != nil {
 		return nil, err
 	}
 	var envelope apiResponseEnvelope[AccessKey]
-	if err := readBody(&envelope, resp, ctx); err != nil {
+	if err := c.readBody(&envelope, resp, ctx); err != nil {
 		return nil, err
 	}
 	createdAccessKey := &envelope.Data
 	return createdAccessKey, nil
 }
 
+// GET access key. The cleartext key is only returned on creation.
+func (c *client) AccessKey(sourceId string, id string, ctx context.Context) (*AccessKey, error) {
+	url := fmt.Sprintf("%s/v3/pipeline/gateway-route/%s/access-key/%s", c.endpoint, sourceId, id)
+	msg := fmt.Sprintf("-- Access Key request to GET %s", url)
+	tflog.Trace(ctx, msg)
+	req := c.newRequest(http.MethodGet, url, nil, ctx)
+	resp, err := c.do(req, ctx)
+	if err != nil {
+		return nil, err
+	}
+	var envelope apiResponseEnvelope[AccessKey]
+	if err := c.readBody(&envelope, resp, ctx); err != nil {
+		return nil, err
+	}
+	accessKey := &envelope.Data
+	return accessKey, nil
+}
+
+// GET access keys of a source
+func (c *client) ListAccessKeys(sourceId string, ctx context.Context) ([]AccessKey, error) {
+	url := fmt.Sprintf("%s/v3/pipeline/gateway-route/%s/access-key", c.endpoint, sourceId)
+	return listAll[AccessKey](c, url, ctx)
+}
+
 // DELETE access key
 func (c *client) DeleteAccessKey(accessKey *AccessKey, ctx context.Context) error {
 	url := fmt.Sprintf("%s/v3/pipeline/gateway-route/%s/access-key/%s", c.endpoint, accessKey.SharedSourceId, accessKey.Id)
 	msg := fmt.Sprintf("-- Access Key request to DELETE %s", url)
 	tflog.Trace(ctx, msg)
-	req := c.newRequest(http.MethodDelete, url, nil)
-	resp, err := c.httpClient.Do(req)
+	req := c.newRequest(http.MethodDelete, url, nil, ctx)
+	resp, err := c.do(req, ctx)
 	if err != nil {
 		return err
 	}
-	return readBody(nil, resp, ctx)
+	return c.readBody(nil, resp, ctx)
 }
 
 // POST Shared Source
@@ -540,13 +660,13 @@ func (c *client) CreateSharedSource(source *SharedSource, ctx context.Context) (
 	if err != nil {
 		return nil, err
 	}
-	req := c.newRequest(http.MethodPost, url, bytes.NewReader(reqBody))
-	resp, err := c.httpClient.Do(req)
+	req := c.newRequest(http.MethodPost, url, bytes.NewReader(reqBody), ctx)
+	resp, err := c.do(req, ctx)
 	if err != nil {
 		return nil, err
 	}
 	var envelope apiResponseEnvelope[SharedSource]
-	if err := readBody(&envelope, resp, ctx); err != nil {
+	if err := c.readBody(&envelope, resp, ctx); err != nil {
 		return nil, err
 	}
 	createdSharedSource := &envelope.Data
@@ -558,13 +678,13 @@ func (c *client) SharedSource(id string, ctx context.Context) (*SharedSource, er
 	url := fmt.Sprintf("%s/v3/pipeline/gateway-route/%s", c.endpoint, id)
 	msg := fmt.Sprintf("-- Shared Source request to GET %s", url)
 	tflog.Trace(ctx, msg)
-	req := c.newRequest(http.MethodGet, url, nil)
-	resp, err := c.httpClient.Do(req)
+	req := c.newRequest(http.MethodGet, url, nil, ctx)
+	resp, err := c.do(req, ctx)
 	if err != nil {
 		return nil, err
 	}
 	var envelope apiResponseEnvelope[SharedSource]
-	if err := readBody(&envelope, resp, ctx); err != nil {
+	if err := c.readBody(&envelope, resp, ctx); err != nil {
 		return nil, err
 	}
 	source := &envelope.Data
@@ -580,13 +700,13 @@ func (c *client) UpdateSharedSource(source *SharedSource, ctx context.Context) (
 	if err != nil {
 		return nil, err
 	}
-	req := c.newRequest(http.MethodPut, url, bytes.NewReader(reqBody))
-	resp, err := c.httpClient.Do(req)
+	req := c.newRequest(http.MethodPut, url, bytes.NewReader(reqBody), ctx)
+	resp, err := c.do(req, ctx)
 	if err != nil {
 		return nil, err
 	}
 	var envelope apiResponseEnvelope[SharedSource]
-	if err := readBody(&envelope, resp, ctx); err != nil {
+	if err := c.readBody(&envelope, resp, ctx); err != nil {
 		return nil, err
 	}
 	updatedSharedSource := &envelope.Data
@@ -598,33 +718,141 @@ func (c *client) DeleteSharedSource(source *SharedSource, ctx context.Context) e
 	url := fmt.Sprintf("%s/v3/pipeline/gateway-route/%s", c.endpoint, source.Id)
 	msg := fmt.Sprintf("-- Shared Source request to DELETE %s", url)
 	tflog.Trace(ctx, msg)
-	req := c.newRequest(http.MethodDelete, url, nil)
-	resp, err := c.httpClient.Do(req)
+	req := c.newRequest(http.MethodDelete, url, nil, ctx)
+	resp, err := c.do(req, ctx)
 	if err != nil {
 		return err
 	}
-	return readBody(nil, resp, ctx)
+	return c.readBody(nil, resp, ctx)
+}
+
+// POST notification channel
+func (c *client) CreateNotificationChannel(channel *NotificationChannel, ctx context.Context) (*NotificationChannel, error) {
+	url := fmt.Sprintf("%s/v3/pipeline/notification-channel", c.endpoint)
+	msg := Json(fmt.Sprintf("-- Notification Channel request to POST %s", url), channel)
+	tflog.Trace(ctx, msg)
+	reqBody, err := json.Marshal(channel)
+	if err != nil {
+		return nil, err
+	}
+	req := c.newRequest(http.MethodPost, url, bytes.NewReader(reqBody), ctx)
+	resp, err := c.do(req, ctx)
+	if err != nil {
+		return nil, err
+	}
+	var envelope apiResponseEnvelope[NotificationChannel]
+	if err := c.readBody(&envelope, resp, ctx); err != nil {
+		return nil, err
+	}
+	created := &envelope.Data
+	return created, nil
+}
+
+// GET notification channel
+func (c *client) NotificationChannel(id string, ctx context.Context) (*NotificationChannel, error) {
+	url := fmt.Sprintf("%s/v3/pipeline/notification-channel/%s", c.endpoint, id)
+	msg := fmt.Sprintf("-- Notification Channel request to GET %s", url)
+	tflog.Trace(ctx, msg)
+	req := c.newRequest(http.MethodGet, url, nil, ctx)
+	resp, err := c.do(req, ctx)
+	if err != nil {
+		return nil, err
+	}
+	var envelope apiResponseEnvelope[NotificationChannel]
+	if err := c.readBody(&envelope, resp, ctx); err != nil {
+		return nil, err
+	}
+	channel := &envelope.Data
+	return channel, nil
+}
+
+// PUT notification channel
+func (c *client) UpdateNotificationChannel(channel *NotificationChannel, ctx context.Context) (*NotificationChannel, error) {
+	url := fmt.Sprintf("%s/v3/pipeline/notification-channel/%s", c.endpoint, channel.Id)
+	msg := Json(fmt.Sprintf("-- Notification Channel request to PUT %s", url), channel)
+	tflog.Trace(ctx, msg)
+	reqBody, err := json.Marshal(channel)
+	if err != nil {
+		return nil, err
+	}
+	req := c.newRequest(http.MethodPut, url, bytes.NewReader(reqBody), ctx)
+	resp, err := c.do(req, ctx)
+	if err != nil {
+		return nil, err
+	}
+	var envelope apiResponseEnvelope[NotificationChannel]
+	if err := c.readBody(&envelope, resp, ctx); err != nil {
+		return nil, err
+	}
+	updated := &envelope.Data
+	return updated, nil
+}
+
+// DELETE notification channel
+func (c *client) DeleteNotificationChannel(id string, ctx context.Context) error {
+	url := fmt.Sprintf("%s/v3/pipeline/notification-channel/%s", c.endpoint, id)
+	msg := fmt.Sprintf("-- Notification Channel request to DELETE %s", url)
+	tflog.Trace(ctx, msg)
+	req := c.newRequest(http.MethodDelete, url, nil, ctx)
+	resp, err := c.do(req, ctx)
+	if err != nil {
+		return err
+	}
+	return c.readBody(nil, resp, ctx)
 }
 
 // POST publish pipeline
 func (c *client) PublishPipeline(pipelineId string, ctx context.Context) (*PublishPipeline, error) {
+	// Because it's a POST, an empty body is required
+	return c.publishPipeline(pipelineId, struct{}{}, ctx)
+}
+
+// POST publish a previous revision of a pipeline again
+func (c *client) PublishPipelineRevision(pipelineId string, revisionId string, ctx context.Context) (*PublishPipeline, error) {
+	return c.publishPipeline(pipelineId, map[string]string{"revision_id": revisionId}, ctx)
+}
+
+func (c *client) publishPipeline(pipelineId string, body any, ctx context.Context) (*PublishPipeline, error) {
 	url := fmt.Sprintf("%s/v3/pipeline/%s/publish?allow_unconnected_edges=true", c.endpoint, pipelineId)
 	msg := fmt.Sprintf("-- Pipeline Publish request to POST %s", url)
 	tflog.Trace(ctx, msg)
-	// Because it's a POST, an empty body is required
-	reqBody, err := json.Marshal(struct{}{})
+	reqBody, err := json.Marshal(body)
 	if err != nil {
 		return nil, err
 	}
-	req := c.newRequest(http.MethodPost, url, bytes.NewReader(reqBody))
-	resp, err := c.httpClient.Do(req)
+	req := c.newRequest(http.MethodPost, url, bytes.NewReader(reqBody), ctx)
+	resp, err := c.do(req, ctx)
 	if err != nil {
 		return nil, err
 	}
 	var envelope apiResponseEnvelope[PublishPipeline]
-	if err := readBody(&envelope, resp, ctx); err != nil {
+	if err := c.readBody(&envelope, resp, ctx); err != nil {
 		return nil, err
 	}
 	created := &envelope.Data
 	return created, nil
 }
+
+// GET published revisions of a pipeline
+func (c *client) ListPipelineRevisions(pipelineId string, ctx context.Context) ([]PipelineRevision, error) {
+	url := fmt.Sprintf("%s/v3/pipeline/%s/revision", c.endpoint, pipelineId)
+	return listAll[PipelineRevision](c, url, ctx)
+}
+
+// GET the deployment status of a pipeline
+func (c *client) PipelineDeployment(pipelineId string, ctx context.Context) (*PipelineDeployment, error) {
+	url := fmt.Sprintf("%s/v3/pipeline/%s/deployment", c.endpoint, pipelineId)
+	msg := fmt.Sprintf("-- Pipeline Deployment request to GET %s", url)
+	tflog.Trace(ctx, msg)
+	req := c.newRequest(http.MethodGet, url, nil, ctx)
+	resp, err := c.do(req, ctx)
+	if err != nil {
+		return nil, err
+	}
+	var envelope apiResponseEnvelope[PipelineDeployment]
+	if err := c.readBody(&envelope, resp, ctx); err != nil {
+		return nil, err
+	}
+	deployment := &envelope.Data
+	return deployment, nil
+}
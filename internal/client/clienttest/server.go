@@ -0,0 +1,660 @@
+// Package clienttest provides an in-memory fake of the pipeline service for tests that cannot
+// reach a real one. It serves the `/v3/pipeline` routes used by the client over HTTP, so the
+// real client, including its error handling, is exercised. Point the provider's `endpoint` at
+// `Server.URL` to use it with `resource.UnitTest`.
+package clienttest
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/mezmo/terraform-provider-mezmo/v5/internal/client"
+)
+
+// The kinds of pipeline nodes, as used in the api routes
+const (
+	KIND_SOURCE      = "source"
+	KIND_TRANSFORM   = "transform"
+	KIND_SINK        = "sink"
+	KIND_PIPELINE    = "pipeline"
+	KIND_ALERT       = "alert"
+	KIND_SHARED      = "gateway-route"
+	KIND_CHANNEL     = "notification-channel"
+	KIND_ACCESS_KEYS = "access-key"
+)
+
+type Server struct {
+	*httptest.Server
+
+	mu            sync.Mutex
+	lastId        int
+	pipelines     map[string]*pipelineState
+	sharedSources map[string]map[string]any
+	accessKeys    map[string]map[string]any
+	channels      map[string]map[string]any
+	validators    []Validator
+}
+
+type pipelineState struct {
+	pipeline  map[string]any
+	nodes     map[string]map[string]map[string]any // kind -> id -> node
+	alerts    map[string]map[string]any
+	revisions []map[string]any
+}
+
+type response struct {
+	status int
+	body   any
+}
+
+// Starts a fake pipeline service that is closed when the test finishes
+func NewServer(t testing.TB) *Server {
+	s := NewUnstartedServer()
+	s.Start()
+	t.Cleanup(s.Close)
+	return s
+}
+
+// Creates a fake pipeline service without starting it, e.g. to share it between tests
+func NewUnstartedServer() *Server {
+	s := &Server{
+		pipelines:     map[string]*pipelineState{},
+		sharedSources: map[string]map[string]any{},
+		accessKeys:    map[string]map[string]any{},
+		channels:      map[string]map[string]any{},
+	}
+	s.Server = httptest.NewUnstartedServer(s)
+	return s
+}
+
+// A client of the fake service
+func (s *Server) Client() client.Client {
+	return client.NewClient(s.URL, "", nil)
+}
+
+// A provider block that uses the fake service
+func (s *Server) ProviderConfig() string {
+	return fmt.Sprintf(`
+		provider "mezmo" {
+			endpoint = %q
+			auth_key = "fake"
+		}
+		`, s.URL)
+}
+
+// Changes a source, processor or destination as if it was modified outside of terraform.
+// Its generation id is incremented, so that updates based on the previous one conflict.
+func (s *Server) ModifyComponent(pipelineId string, id string, modify func(component map[string]any)) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	p, ok := s.pipelines[pipelineId]
+	if !ok {
+		return fmt.Errorf("pipeline %s not found", pipelineId)
+	}
+	for _, nodes := range p.nodes {
+		if node, ok := nodes[id]; ok {
+			modify(node)
+			node["generation_id"] = node["generation_id"].(int64) + 1
+			p.changed()
+			return nil
+		}
+	}
+	return fmt.Errorf("component %s not found in pipeline %s", id, pipelineId)
+}
+
+func (s *Server) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	var body map[string]any
+	if r.Body != nil && r.ContentLength != 0 {
+		if err := json.NewDecoder(r.Body).Decode(&body); err != nil {
+			writeResponse(w, apiError(http.StatusBadRequest, "EINVALIDJSON", "The request body is not valid JSON"))
+			return
+		}
+	}
+
+	parts := strings.Split(strings.Trim(r.URL.Path, "/"), "/")
+	var res response
+	switch {
+	case r.URL.Path == "/internal/account":
+		// Used by the acceptance tests to set up an account
+		res = data(http.StatusOK, map[string]any{})
+	case len(parts) < 2 || parts[0] != "v3" || parts[1] != "pipeline":
+		res = notFound()
+	case len(parts) > 2 && parts[2] == KIND_SHARED:
+		res = s.sharedSourceRoutes(r, body, parts[3:])
+	case len(parts) > 2 && parts[2] == KIND_CHANNEL:
+		res = s.channelRoutes(r, body, parts[3:])
+	default:
+		res = s.pipelineRoutes(r, body, parts[2:])
+	}
+	writeResponse(w, res)
+}
+
+func writeResponse(w http.ResponseWriter, res response) {
+	if res.body == nil {
+		w.WriteHeader(res.status)
+		return
+	}
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(res.status)
+	_ = json.NewEncoder(w).Encode(res.body)
+}
+
+func data(status int, v any) response {
+	return response{status, map[string]any{"data": v}}
+}
+
+func apiError(status int, code string, message string, errors ...ValidationError) response {
+	body := map[string]any{"status": status, "code": code, "message": message}
+	if len(errors) > 0 {
+		body["errors"] = errors
+	}
+	return response{status, body}
+}
+
+func notFound() response {
+	return apiError(http.StatusNotFound, "ENOTFOUND", "Not Found")
+}
+
+func methodNotAllowed() response {
+	return apiError(http.StatusMethodNotAllowed, "EMETHOD", "Method Not Allowed")
+}
+
+// Returns one page of a list, honoring the `limit` and `offset` query parameters
+func list[T any](r *http.Request, items []T) response {
+	offset, _ := strconv.Atoi(r.URL.Query().Get("offset"))
+	limit, err := strconv.Atoi(r.URL.Query().Get("limit"))
+	if err != nil || limit <= 0 {
+		limit = len(items)
+	}
+	total := len(items)
+	start := min(offset, total)
+	end := min(start+limit, total)
+	return response{http.StatusOK, map[string]any{
+		"meta": map[string]any{"limit": limit, "offset": offset, "total": total},
+		"data": items[start:end],
+	}}
+}
+
+// Ids look like the uuids of the service, and sort in creation order
+func (s *Server) newId() string {
+	s.lastId++
+	return fmt.Sprintf("00000000-0000-4000-8000-%012d", s.lastId)
+}
+
+func now() string {
+	return time.Now().UTC().Format(time.RFC3339)
+}
+
+func sortedValues(items map[string]map[string]any) []map[string]any {
+	ids := make([]string, 0, len(items))
+	for id := range items {
+		ids = append(ids, id)
+	}
+	sort.Strings(ids)
+	values := make([]map[string]any, 0, len(items))
+	for _, id := range ids {
+		values = append(values, items[id])
+	}
+	return values
+}
+
+// Copies the given fields of a request body into a stored object
+func assign(target map[string]any, body map[string]any, fields ...string) {
+	for _, field := range fields {
+		if value, ok := body[field]; ok && value != nil {
+			target[field] = value
+		} else {
+			delete(target, field)
+		}
+	}
+}
+
+func (p *pipelineState) changed() {
+	p.pipeline["has_changes"] = true
+	p.pipeline["updated_at"] = now()
+}
+
+func (s *Server) pipelineRoutes(r *http.Request, body map[string]any, parts []string) response {
+	if len(parts) == 0 {
+		switch r.Method {
+		case http.MethodGet:
+			pipelines := make([]map[string]any, 0, len(s.pipelines))
+			for _, p := range s.pipelines {
+				pipelines = append(pipelines, p.pipeline)
+			}
+			sort.Slice(pipelines, func(i, j int) bool {
+				return pipelines[i]["id"].(string) < pipelines[j]["id"].(string)
+			})
+			return list(r, pipelines)
+		case http.MethodPost:
+			if errors := s.validate(KIND_PIPELINE, body); len(errors) > 0 {
+				return validationFailed(errors)
+			}
+			id := s.newId()
+			pipeline := map[string]any{"id": id, "created_at": now(), "updated_at": now(), "has_changes": false}
+			assign(pipeline, body, "title", "origin")
+			s.pipelines[id] = &pipelineState{
+				pipeline: pipeline,
+				nodes:    map[string]map[string]map[string]any{KIND_SOURCE: {}, KIND_TRANSFORM: {}, KIND_SINK: {}},
+				alerts:   map[string]map[string]any{},
+			}
+			return data(http.StatusCreated, pipeline)
+		}
+		return methodNotAllowed()
+	}
+
+	p, ok := s.pipelines[parts[0]]
+	if !ok {
+		return notFound()
+	}
+	if len(parts) == 1 {
+		switch r.Method {
+		case http.MethodGet:
+			return data(http.StatusOK, p.pipeline)
+		case http.MethodPut:
+			if errors := s.validate(KIND_PIPELINE, body); len(errors) > 0 {
+				return validationFailed(errors)
+			}
+			assign(p.pipeline, body, "title")
+			p.pipeline["updated_at"] = now()
+			return data(http.StatusOK, p.pipeline)
+		case http.MethodDelete:
+			delete(s.pipelines, parts[0])
+			return response{status: http.StatusNoContent}
+		}
+		return methodNotAllowed()
+	}
+
+	switch parts[1] {
+	case KIND_ALERT:
+		return s.listOrGetAlert(r, p, parts[2:])
+	case "publish":
+		if r.Method != http.MethodPost || len(parts) != 2 {
+			return methodNotAllowed()
+		}
+		return s.publish(p, body)
+	case "revision":
+		if r.Method != http.MethodGet || len(parts) != 2 {
+			return methodNotAllowed()
+		}
+		return list(r, p.revisions)
+	case "deployment":
+		if r.Method != http.MethodGet || len(parts) != 2 {
+			return methodNotAllowed()
+		}
+		revision, _ := p.pipeline["published_revision_id"].(string)
+		if revision == "" {
+			return notFound()
+		}
+		return data(http.StatusOK, map[string]any{"status": client.DEPLOYMENT_STATUS_RUNNING, "revision_id": revision})
+	}
+
+	kind := parts[1]
+	if len(parts) >= 4 && parts[3] == KIND_ALERT {
+		return s.alertRoutes(r, body, p, kind, parts[2], parts[4:])
+	}
+	nodes, ok := p.nodes[kind]
+	if !ok {
+		return notFound()
+	}
+	switch len(parts) {
+	case 2:
+		switch r.Method {
+		case http.MethodGet:
+			return list(r, sortedValues(nodes))
+		case http.MethodPost:
+			return s.createNode(p, kind, body)
+		}
+		return methodNotAllowed()
+	case 3:
+		node, ok := nodes[parts[2]]
+		if !ok {
+			return notFound()
+		}
+		switch r.Method {
+		case http.MethodGet:
+			return data(http.StatusOK, node)
+		case http.MethodPut:
+			return s.updateNode(p, kind, node, body)
+		case http.MethodDelete:
+			delete(nodes, parts[2])
+			p.changed()
+			return response{status: http.StatusNoContent}
+		}
+		return methodNotAllowed()
+	}
+	return notFound()
+}
+
+func (s *Server) createNode(p *pipelineState, kind string, body map[string]any) response {
+	if errors := s.validateNode(p, kind, body); len(errors) > 0 {
+		return validationFailed(errors)
+	}
+	id := s.newId()
+	node := map[string]any{"id": id, "type": body["type"], "generation_id": int64(1)}
+	assign(node, body, "title", "description", "inputs", "user_config", "gateway_route_id")
+	setOutputs(kind, node)
+	p.nodes[kind][id] = node
+	p.changed()
+	return data(http.StatusCreated, node)
+}
+
+func (s *Server) updateNode(p *pipelineState, kind string, node map[string]any, body map[string]any) response {
+	generation := node["generation_id"].(int64)
+	if requested, ok := body["generation_id"].(float64); ok && int64(requested) != generation {
+		return apiError(http.StatusConflict, "ECONFLICT", fmt.Sprintf(
+			"The component was modified since generation %d was read. The current generation is %d.",
+			int64(requested), generation,
+		))
+	}
+	body["type"] = node["type"]
+	if errors := s.validateNode(p, kind, body); len(errors) > 0 {
+		return validationFailed(errors)
+	}
+	assign(node, body, "title", "description", "inputs", "user_config", "gateway_route_id")
+	node["generation_id"] = generation + 1
+	setOutputs(kind, node)
+	p.changed()
+	return data(http.StatusOK, node)
+}
+
+// Processors that route events have one output per route, plus one for unmatched events.
+// The service names the outputs, and records each name in the route's `_output_name`.
+func setOutputs(kind string, node map[string]any) {
+	if kind != KIND_TRANSFORM {
+		return
+	}
+	userConfig, _ := node["user_config"].(map[string]any)
+	var routes []any
+	switch node["type"] {
+	case "route":
+		routes, _ = userConfig["conditionals"].([]any)
+	case "parse-sequentially":
+		routes, _ = userConfig["parsers"].([]any)
+	default:
+		return
+	}
+	id := node["id"].(string)
+	outputs := make([]map[string]any, 0, len(routes)+1)
+	for i, route := range routes {
+		route, ok := route.(map[string]any)
+		if !ok {
+			continue
+		}
+		name, _ := route["_output_name"].(string)
+		if name == "" {
+			name = fmt.Sprintf("output_%d", i)
+			route["_output_name"] = name
+		}
+		label, _ := route["label"].(string)
+		outputs = append(outputs, map[string]any{"id": id + "." + name, "label": label})
+	}
+	outputs = append(outputs, map[string]any{"id": id + "._unmatched", "label": "Unmatched"})
+	node["outputs"] = outputs
+}
+
+func (s *Server) listOrGetAlert(r *http.Request, p *pipelineState, parts []string) response {
+	if r.Method != http.MethodGet {
+		return methodNotAllowed()
+	}
+	switch len(parts) {
+	case 0:
+		return list(r, sortedValues(p.alerts))
+	case 1:
+		if alert, ok := p.alerts[parts[0]]; ok {
+			return data(http.StatusOK, alert)
+		}
+	}
+	return notFound()
+}
+
+func (s *Server) alertRoutes(
+	r *http.Request, body map[string]any, p *pipelineState, kind string, componentId string, parts []string,
+) response {
+	if kind == KIND_PIPELINE {
+		if componentId != p.pipeline["id"] {
+			return notFound()
+		}
+	} else if nodes, ok := p.nodes[kind]; !ok {
+		return notFound()
+	} else if _, ok := nodes[componentId]; !ok {
+		return notFound()
+	}
+
+	switch len(parts) {
+	case 0:
+		if r.Method != http.MethodPost {
+			return methodNotAllowed()
+		}
+		if errors := s.validate(KIND_ALERT, body); len(errors) > 0 {
+			return validationFailed(errors)
+		}
+		id := s.newId()
+		alert := map[string]any{
+			"id":             id,
+			"pipeline_id":    p.pipeline["id"],
+			"component_kind": kind,
+			"component_id":   componentId,
+			"active":         body["active"] == true,
+		}
+		assign(alert, body, "inputs", "alert_config")
+		setAlertDefaults(alert)
+		p.alerts[id] = alert
+		p.changed()
+		return data(http.StatusCreated, alert)
+	case 1:
+		alert, ok := p.alerts[parts[0]]
+		if !ok || alert["component_kind"] != kind || alert["component_id"] != componentId {
+			return notFound()
+		}
+		switch r.Method {
+		case http.MethodPut:
+			if errors := s.validate(KIND_ALERT, body); len(errors) > 0 {
+				return validationFailed(errors)
+			}
+			assign(alert, body, "inputs", "alert_config")
+			alert["active"] = body["active"] == true
+			setAlertDefaults(alert)
+			p.changed()
+			return data(http.StatusOK, alert)
+		case http.MethodDelete:
+			delete(p.alerts, parts[0])
+			p.changed()
+			return response{status: http.StatusNoContent}
+		}
+		return methodNotAllowed()
+	}
+	return notFound()
+}
+
+// The service fills in the throttling options that were not sent
+func setAlertDefaults(alert map[string]any) {
+	config, ok := alert["alert_config"].(map[string]any)
+	if !ok {
+		return
+	}
+	payload, ok := config["alert_payload"].(map[string]any)
+	if !ok {
+		return
+	}
+	throttling, _ := payload["throttling"].(map[string]any)
+	if throttling == nil {
+		throttling = map[string]any{}
+	}
+	if _, ok := throttling["window_secs"]; !ok {
+		throttling["window_secs"] = 60
+	}
+	if _, ok := throttling["threshold"]; !ok {
+		throttling["threshold"] = 1
+	}
+	payload["throttling"] = throttling
+}
+
+func (s *Server) publish(p *pipelineState, body map[string]any) response {
+	if revisionId, ok := body["revision_id"].(string); ok {
+		for _, revision := range p.revisions {
+			if revision["id"] == revisionId {
+				p.pipeline["published_revision_id"] = revisionId
+				return data(http.StatusOK, map[string]any{"id": p.pipeline["id"]})
+			}
+		}
+		return apiError(http.StatusNotFound, "ENOTFOUND", fmt.Sprintf("Revision %s was not found", revisionId))
+	}
+	if p.pipeline["published_revision_id"] != nil && p.pipeline["has_changes"] == false {
+		return apiError(http.StatusBadRequest, "ENOCHANGES", "There are no changes to publish")
+	}
+	revision := map[string]any{"id": s.newId(), "published_at": now()}
+	// Newest first, like the service
+	p.revisions = append([]map[string]any{revision}, p.revisions...)
+	p.pipeline["published_revision_id"] = revision["id"]
+	p.pipeline["has_changes"] = false
+	return data(http.StatusOK, map[string]any{"id": p.pipeline["id"]})
+}
+
+func (s *Server) sharedSourceRoutes(r *http.Request, body map[string]any, parts []string) response {
+	if len(parts) == 0 {
+		if r.Method != http.MethodPost {
+			return methodNotAllowed()
+		}
+		if errors := s.validate(KIND_SHARED, body); len(errors) > 0 {
+			return validationFailed(errors)
+		}
+		id := s.newId()
+		source := map[string]any{"id": id, "consumer_id": s.newId()}
+		assign(source, body, "title", "description", "type")
+		s.sharedSources[id] = source
+		return data(http.StatusCreated, s.withAttachments(source))
+	}
+
+	source, ok := s.sharedSources[parts[0]]
+	if !ok {
+		return notFound()
+	}
+	if len(parts) > 1 {
+		if parts[1] != KIND_ACCESS_KEYS {
+			return notFound()
+		}
+		return s.accessKeyRoutes(r, body, parts[0], parts[2:])
+	}
+	switch r.Method {
+	case http.MethodGet:
+		return data(http.StatusOK, s.withAttachments(source))
+	case http.MethodPut:
+		if errors := s.validate(KIND_SHARED, body); len(errors) > 0 {
+			return validationFailed(errors)
+		}
+		assign(source, body, "title", "description")
+		return data(http.StatusOK, s.withAttachments(source))
+	case http.MethodDelete:
+		delete(s.sharedSources, parts[0])
+		return response{status: http.StatusNoContent}
+	}
+	return methodNotAllowed()
+}
+
+// Adds the pipeline sources that consume a shared source
+func (s *Server) withAttachments(source map[string]any) map[string]any {
+	attachments := []map[string]any{}
+	for pipelineId, p := range s.pipelines {
+		for id, node := range p.nodes[KIND_SOURCE] {
+			if node["gateway_route_id"] == source["id"] {
+				attachments = append(attachments, map[string]any{"pipeline_id": pipelineId, "source_id": id})
+			}
+		}
+	}
+	sort.Slice(attachments, func(i, j int) bool {
+		return attachments[i]["source_id"].(string) < attachments[j]["source_id"].(string)
+	})
+	result := make(map[string]any, len(source)+1)
+	for k, v := range source {
+		result[k] = v
+	}
+	result["attached_pipelines"] = attachments
+	return result
+}
+
+func (s *Server) accessKeyRoutes(r *http.Request, body map[string]any, sourceId string, parts []string) response {
+	if len(parts) == 0 {
+		switch r.Method {
+		case http.MethodGet:
+			keys := []map[string]any{}
+			for _, key := range sortedValues(s.accessKeys) {
+				if key["gateway_route_id"] == sourceId {
+					keys = append(keys, key)
+				}
+			}
+			return list(r, keys)
+		case http.MethodPost:
+			id := s.newId()
+			key := map[string]any{"id": id, "gateway_route_id": sourceId}
+			assign(key, body, "title", "type")
+			s.accessKeys[id] = key
+			// The cleartext key is only returned on creation
+			created := map[string]any{"key": "fake-key-" + id}
+			for k, v := range key {
+				created[k] = v
+			}
+			return data(http.StatusCreated, created)
+		}
+		return methodNotAllowed()
+	}
+
+	key, ok := s.accessKeys[parts[0]]
+	if !ok || key["gateway_route_id"] != sourceId {
+		return notFound()
+	}
+	switch r.Method {
+	case http.MethodGet:
+		return data(http.StatusOK, key)
+	case http.MethodDelete:
+		delete(s.accessKeys, parts[0])
+		return response{status: http.StatusNoContent}
+	}
+	return methodNotAllowed()
+}
+
+func (s *Server) channelRoutes(r *http.Request, body map[string]any, parts []string) response {
+	if len(parts) == 0 {
+		if r.Method != http.MethodPost {
+			return methodNotAllowed()
+		}
+		if errors := s.validate(KIND_CHANNEL, body); len(errors) > 0 {
+			return validationFailed(errors)
+		}
+		id := s.newId()
+		channel := map[string]any{"id": id}
+		assign(channel, body, "title", "service")
+		s.channels[id] = channel
+		return data(http.StatusCreated, channel)
+	}
+
+	channel, ok := s.channels[parts[0]]
+	if !ok || len(parts) > 1 {
+		return notFound()
+	}
+	switch r.Method {
+	case http.MethodGet:
+		return data(http.StatusOK, channel)
+	case http.MethodPut:
+		if errors := s.validate(KIND_CHANNEL, body); len(errors) > 0 {
+			return validationFailed(errors)
+		}
+		assign(channel, body, "title", "service")
+		return data(http.StatusOK, channel)
+	case http.MethodDelete:
+		delete(s.channels, parts[0])
+		return response{status: http.StatusNoContent}
+	}
+	return methodNotAllowed()
+}
@@ -0,0 +1,129 @@
+package clienttest
+
+import (
+	"fmt"
+	"net/http"
+	"strings"
+)
+
+// A validation error, as reported in the `errors` of an api response
+type ValidationError struct {
+	InstancePath string `json:"instancePath"`
+	Message      string `json:"message"`
+}
+
+// Checks the request body of an object being created or updated. `kind` is one of the
+// KIND_* constants. Validators let tests reproduce the errors of the service's schemas.
+type Validator func(kind string, body map[string]any) []ValidationError
+
+// Adds a check to every create and update request
+func (s *Server) AddValidator(validator Validator) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.validators = append(s.validators, validator)
+}
+
+// Rejects a `user_config` value, e.g. `RejectUserConfig("sink", "/user_config/uri", "must match format \"uri\"")`
+func RejectUserConfig(kind string, instancePath string, message string) Validator {
+	return func(k string, body map[string]any) []ValidationError {
+		if k != kind {
+			return nil
+		}
+		if _, ok := lookup(body, instancePath); !ok {
+			return nil
+		}
+		return []ValidationError{{InstancePath: instancePath, Message: message}}
+	}
+}
+
+func validationFailed(errors []ValidationError) response {
+	return apiError(http.StatusBadRequest, "EVALIDATION", "Validation failed", errors...)
+}
+
+func (s *Server) validate(kind string, body map[string]any) []ValidationError {
+	errors := []ValidationError{}
+	switch kind {
+	case KIND_PIPELINE, KIND_SHARED, KIND_CHANNEL:
+		if title, _ := body["title"].(string); title == "" {
+			errors = append(errors, ValidationError{"/title", "must NOT have fewer than 1 characters"})
+		}
+	case KIND_ALERT:
+		if _, ok := body["alert_config"].(map[string]any); !ok {
+			errors = append(errors, ValidationError{"", "must have required property 'alert_config'"})
+		}
+	}
+	for _, validator := range s.validators {
+		errors = append(errors, validator(kind, body)...)
+	}
+	return errors
+}
+
+func (s *Server) validateNode(p *pipelineState, kind string, body map[string]any) []ValidationError {
+	errors := []ValidationError{}
+	if nodeType, _ := body["type"].(string); nodeType == "" {
+		errors = append(errors, ValidationError{"/type", "must NOT have fewer than 1 characters"})
+	}
+	if _, ok := body["user_config"].(map[string]any); !ok {
+		errors = append(errors, ValidationError{"/user_config", "must be object"})
+	}
+	inputs, _ := body["inputs"].([]any)
+	if kind == KIND_SOURCE && len(inputs) > 0 {
+		errors = append(errors, ValidationError{"/inputs", "must NOT have more than 0 items"})
+		inputs = nil
+	}
+	for i, input := range inputs {
+		if id, _ := input.(string); !p.hasOutput(id) {
+			errors = append(errors, ValidationError{
+				fmt.Sprintf("/inputs/%d", i),
+				fmt.Sprintf("must be the id of a source or processor output in the pipeline, got %q", input),
+			})
+		}
+	}
+	return append(errors, s.validate(kind, body)...)
+}
+
+// Whether an input id refers to a source, a processor or one of the outputs of a processor
+func (p *pipelineState) hasOutput(id string) bool {
+	if _, ok := p.nodes[KIND_SOURCE][id]; ok {
+		return true
+	}
+	processorId, _, _ := strings.Cut(id, ".")
+	processor, ok := p.nodes[KIND_TRANSFORM][processorId]
+	if !ok {
+		return false
+	}
+	outputs, _ := processor["outputs"].([]map[string]any)
+	if len(outputs) == 0 {
+		return processorId == id
+	}
+	for _, output := range outputs {
+		if output["id"] == id {
+			return true
+		}
+	}
+	return false
+}
+
+// Looks up a json pointer such as `/user_config/brokers/0`
+func lookup(body map[string]any, pointer string) (any, bool) {
+	var current any = body
+	for _, key := range strings.Split(strings.TrimPrefix(pointer, "/"), "/") {
+		switch value := current.(type) {
+		case map[string]any:
+			next, ok := value[key]
+			if !ok {
+				return nil, false
+			}
+			current = next
+		case []any:
+			var i int
+			if _, err := fmt.Sscanf(key, "%d", &i); err != nil || i < 0 || i >= len(value) {
+				return nil, false
+			}
+			current = value[i]
+		default:
+			return nil, false
+		}
+	}
+	return current, true
+}
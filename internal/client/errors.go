@@ -4,8 +4,11 @@ import (
 	"bytes"
 	"context"
 	"encoding/json"
+	"errors"
 	"fmt"
+	"net"
 	"net/http"
+	"time"
 
 	"github.com/hashicorp/terraform-plugin-log/tflog"
 	"github.com/mezmo/terraform-provider-mezmo/v5/internal/provider/models/modelutils"
@@ -103,11 +106,60 @@ func JSONMarshal(t any) ([]byte, error) {
 	return buffer.Bytes(), err
 }
 
+// Returned when a request to the API does not complete in time, either because of the
+// client's request timeout or because the caller's context deadline passed.
+type TimeoutError struct {
+	Method  string
+	URL     string
+	Timeout time.Duration
+	Err     error
+}
+
+func (e TimeoutError) Error() string {
+	if e.Timeout > 0 {
+		return fmt.Sprintf("%s %s did not complete within %s: %s", e.Method, e.URL, e.Timeout, e.Err)
+	}
+	return fmt.Sprintf("%s %s did not complete in time: %s", e.Method, e.URL, e.Err)
+}
+
+func (e TimeoutError) Unwrap() error {
+	return e.Err
+}
+
+func IsTimeoutError(target error) bool {
+	var err TimeoutError
+	return errors.As(target, &err)
+}
+
+// Converts transport errors that are caused by a timeout into a `TimeoutError`. Other errors,
+// including a context cancelled by the user, are returned as-is.
+func (c *client) timeoutError(req *http.Request, err error) error {
+	if err == nil {
+		return nil
+	}
+	var netErr net.Error
+	if errors.Is(err, context.DeadlineExceeded) || (errors.As(err, &netErr) && netErr.Timeout()) {
+		return TimeoutError{
+			Method:  req.Method,
+			URL:     req.URL.String(),
+			Timeout: c.httpClient.Timeout,
+			Err:     err,
+		}
+	}
+	return err
+}
+
 func IsNotFoundError(target error) bool {
 	err, ok := target.(ApiResponseError)
 	return ok && err.Status == http.StatusNotFound
 }
 
+// Returned when the API rejects a change because the resource was modified since it was read
+func IsConflictError(target error) bool {
+	err, ok := target.(ApiResponseError)
+	return ok && err.Status == http.StatusConflict
+}
+
 // Validation errors can be wordy and redundant. Skip ones that don't make sense
 // ourside of the schema's context.
 func SkipThisError(msg string) bool {
@@ -0,0 +1,50 @@
+package client
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"net/url"
+	"strconv"
+
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+)
+
+// The number of items requested per page by list calls
+const DefaultPageSize = 100
+
+// Pagination details returned in the `meta` of list responses. Endpoints that do not
+// paginate omit them, in which case the first response holds the full result.
+type apiResponseMeta struct {
+	Limit  int  `json:"limit"`
+	Offset int  `json:"offset"`
+	Total  *int `json:"total,omitempty"`
+}
+
+// Fetches every page of a list endpoint. `listUrl` must not contain a query string.
+func listAll[T any](c *client, listUrl string, ctx context.Context) ([]T, error) {
+	all := []T{}
+	for {
+		query := url.Values{}
+		query.Set("limit", strconv.Itoa(DefaultPageSize))
+		query.Set("offset", strconv.Itoa(len(all)))
+		pageUrl := listUrl + "?" + query.Encode()
+		tflog.Trace(ctx, fmt.Sprintf("-- List request to GET %s", pageUrl))
+
+		req := c.newRequest(http.MethodGet, pageUrl, nil, ctx)
+		resp, err := c.do(req, ctx)
+		if err != nil {
+			return nil, err
+		}
+		var envelope apiResponseEnvelope[[]T]
+		if err := c.readBody(&envelope, resp, ctx); err != nil {
+			return nil, err
+		}
+		all = append(all, envelope.Data...)
+
+		total := envelope.Meta.Total
+		if total == nil || len(envelope.Data) == 0 || len(all) >= *total {
+			return all, nil
+		}
+	}
+}
@@ -0,0 +1,153 @@
+package client
+
+import (
+	"context"
+	"io"
+	"math/rand"
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+)
+
+const (
+	DefaultMaxRetries   = 3
+	DefaultRetryWaitMin = 1 * time.Second
+	DefaultRetryWaitMax = 30 * time.Second
+)
+
+// Controls how transient failures (429, 502, 503, 504 and connection errors) are retried.
+type RetryOptions struct {
+	MaxRetries int
+	WaitMin    time.Duration
+	WaitMax    time.Duration
+	// By default, only idempotent requests (GET, PUT, DELETE) are retried. POST requests
+	// may have been processed before the failure, so retrying them could create duplicates.
+	RetryNonIdempotent bool
+}
+
+func DefaultRetryOptions() RetryOptions {
+	return RetryOptions{
+		MaxRetries: DefaultMaxRetries,
+		WaitMin:    DefaultRetryWaitMin,
+		WaitMax:    DefaultRetryWaitMax,
+	}
+}
+
+func WithRetryOptions(options RetryOptions) ClientOption {
+	return func(c *client) {
+		c.retry = options
+	}
+}
+
+// Sends the request, retrying transient failures with exponential backoff. The response
+// of the last attempt is always returned so that `readBody` can report its error.
+func (c *client) do(req *http.Request, ctx context.Context) (*http.Response, error) {
+	for attempt := 0; ; attempt++ {
+		if attempt > 0 && req.GetBody != nil {
+			body, err := req.GetBody()
+			if err != nil {
+				return nil, err
+			}
+			req.Body = body
+		}
+
+		resp, err := c.httpClient.Do(req)
+		if attempt >= c.retry.MaxRetries || !c.shouldRetry(req, resp, err) {
+			if err != nil {
+				return nil, c.timeoutError(req, err)
+			}
+			return resp, nil
+		}
+
+		wait := c.retryWait(attempt, resp)
+		fields := map[string]any{
+			"method":  req.Method,
+			"url":     req.URL.String(),
+			"attempt": attempt + 1,
+			"wait":    wait.String(),
+		}
+		if err != nil {
+			fields["error"] = err.Error()
+		} else {
+			fields["status"] = resp.StatusCode
+			// Drain the body so that the connection can be reused
+			_, _ = io.Copy(io.Discard, resp.Body)
+			resp.Body.Close()
+		}
+		tflog.Warn(ctx, "Retrying request after a transient failure", fields)
+
+		timer := time.NewTimer(wait)
+		select {
+		case <-ctx.Done():
+			timer.Stop()
+			return nil, c.timeoutError(req, ctx.Err())
+		case <-timer.C:
+		}
+	}
+}
+
+func (c *client) shouldRetry(req *http.Request, resp *http.Response, err error) bool {
+	if !c.retry.RetryNonIdempotent && !isIdempotent(req.Method) {
+		return false
+	}
+	if err != nil {
+		// Don't retry when the caller has given up
+		return req.Context().Err() == nil
+	}
+	switch resp.StatusCode {
+	case http.StatusTooManyRequests,
+		http.StatusBadGateway,
+		http.StatusServiceUnavailable,
+		http.StatusGatewayTimeout:
+		return true
+	default:
+		return false
+	}
+}
+
+func isIdempotent(method string) bool {
+	switch method {
+	case http.MethodGet, http.MethodHead, http.MethodOptions, http.MethodPut, http.MethodDelete:
+		return true
+	default:
+		return false
+	}
+}
+
+// Honors `Retry-After` if the server sent one, otherwise backs off exponentially with jitter.
+// Either way, the wait is capped at `WaitMax`.
+func (c *client) retryWait(attempt int, resp *http.Response) time.Duration {
+	if resp != nil {
+		if wait, ok := parseRetryAfter(resp.Header.Get("Retry-After")); ok {
+			return min(wait, c.retry.WaitMax)
+		}
+	}
+	wait := c.retry.WaitMin << attempt
+	if wait <= 0 || wait > c.retry.WaitMax {
+		wait = c.retry.WaitMax
+	}
+	if jitter := int64(wait / 4); jitter > 0 {
+		wait += time.Duration(rand.Int63n(jitter))
+	}
+	return min(wait, c.retry.WaitMax)
+}
+
+// `Retry-After` is either a number of seconds or an HTTP date
+func parseRetryAfter(value string) (time.Duration, bool) {
+	if value == "" {
+		return 0, false
+	}
+	if seconds, err := strconv.Atoi(value); err == nil && seconds >= 0 {
+		return time.Duration(seconds) * time.Second, true
+	}
+	if date, err := http.ParseTime(value); err == nil {
+		wait := time.Until(date)
+		if wait < 0 {
+			wait = 0
+		}
+		return wait, true
+	}
+	return 0, false
+}
@@ -0,0 +1,41 @@
+package client_test
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/mezmo/terraform-provider-mezmo/v5/internal/client"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestAccessKeyGetAndList(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		switch r.URL.Path {
+		case "/v3/pipeline/gateway-route/sid/access-key/kid":
+			w.Write([]byte(`{"data": {"id": "kid", "title": "ingestion", "gateway_route_id": "sid", "type": "generated"}}`))
+		case "/v3/pipeline/gateway-route/sid/access-key":
+			w.Write([]byte(`{"data": [{"id": "kid", "gateway_route_id": "sid"}, {"id": "other", "gateway_route_id": "sid"}]}`))
+		default:
+			w.WriteHeader(http.StatusNotFound)
+			w.Write([]byte(`{"message": "not found", "code": "ENOTFOUND"}`))
+		}
+	}))
+	t.Cleanup(server.Close)
+	c := client.NewClient(server.URL, "", nil)
+
+	key, err := c.AccessKey("sid", "kid", context.Background())
+	assert.NoError(t, err)
+	assert.Equal(t, "ingestion", key.Title)
+	assert.Equal(t, "sid", key.SharedSourceId)
+	assert.Empty(t, key.Key)
+
+	keys, err := c.ListAccessKeys("sid", context.Background())
+	assert.NoError(t, err)
+	assert.Len(t, keys, 2)
+
+	_, err = c.AccessKey("sid", "revoked", context.Background())
+	assert.True(t, client.IsNotFoundError(err), err)
+}
@@ -0,0 +1,151 @@
+package client_test
+
+import (
+	"context"
+	"net/http"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/mezmo/terraform-provider-mezmo/v5/internal/client"
+	"github.com/mezmo/terraform-provider-mezmo/v5/internal/client/cassette"
+	"github.com/mezmo/terraform-provider-mezmo/v5/internal/client/clienttest"
+	"github.com/stretchr/testify/assert"
+)
+
+func createSinkWithSecret(c client.Client, ctx context.Context) (string, *client.Destination, error) {
+	pipeline, err := c.CreatePipeline(&client.Pipeline{Title: "recorded"}, ctx)
+	if err != nil {
+		return "", nil, err
+	}
+	destination, err := c.CreateDestination(pipeline.Id, &client.Destination{BaseNode: client.BaseNode{
+		Type: "http",
+		UserConfig: map[string]any{
+			"uri":  "https://example.org",
+			"auth": map[string]any{"strategy": "basic", "user": "me", "password": "hunter2"},
+		},
+	}}, ctx)
+	if err != nil {
+		return "", nil, err
+	}
+	destination, err = c.Destination(pipeline.Id, destination.Id, ctx)
+	return pipeline.Id, destination, err
+}
+
+func TestCassetteRecordAndReplay(t *testing.T) {
+	ctx := context.Background()
+	cassettePath := filepath.Join(t.TempDir(), "cassettes", "test.json")
+	server := clienttest.NewServer(t)
+
+	recorder, err := cassette.New(cassettePath, cassette.MODE_RECORD, []string{"password"})
+	assert.NoError(t, err)
+	c := client.NewClient(server.URL, "my-auth-key", nil, client.WithTransport(recorder))
+	pipelineId, recorded, err := createSinkWithSecret(c, ctx)
+	assert.NoError(t, err)
+	assert.NoError(t, recorder.Save())
+
+	content, err := os.ReadFile(cassettePath)
+	assert.NoError(t, err)
+	assert.NotContains(t, string(content), "my-auth-key")
+	assert.NotContains(t, string(content), "hunter2")
+	assert.Contains(t, string(content), `"Authorization": "REDACTED"`)
+	assert.Contains(t, string(content), `"password": "REDACTED"`)
+
+	// The service is not needed anymore, and the secrets are restored from the requests
+	server.Close()
+	recorder, err = cassette.New(cassettePath, cassette.MODE_REPLAY, []string{"password"})
+	assert.NoError(t, err)
+	// Requests that were not recorded fail without retries
+	noRetries := client.WithRetryOptions(client.RetryOptions{})
+	c = client.NewClient(server.URL, "another-auth-key", nil, client.WithTransport(recorder), noRetries)
+	_, replayed, err := createSinkWithSecret(c, ctx)
+	assert.NoError(t, err)
+	assert.Equal(t, recorded, replayed)
+	assert.Equal(t, "hunter2", replayed.UserConfig["auth"].(map[string]any)["password"])
+
+	// Every interaction is replayed once
+	_, err = c.Destination(pipelineId, recorded.Id, ctx)
+	assert.ErrorContains(t, err, "has no recorded response for GET")
+}
+
+func TestCassetteReplayMatchesBody(t *testing.T) {
+	ctx := context.Background()
+	cassettePath := filepath.Join(t.TempDir(), "test.json")
+	server := clienttest.NewServer(t)
+
+	recorder, _ := cassette.New(cassettePath, cassette.MODE_RECORD, nil)
+	c := client.NewClient(server.URL, "", nil, client.WithTransport(recorder))
+	_, err := c.CreatePipeline(&client.Pipeline{Title: "first"}, ctx)
+	assert.NoError(t, err)
+	_, err = c.CreatePipeline(&client.Pipeline{}, ctx)
+	assert.Error(t, err)
+	assert.NoError(t, recorder.Save())
+
+	recorder, _ = cassette.New(cassettePath, cassette.MODE_REPLAY, nil)
+	c = client.NewClient(server.URL, "", nil, client.WithTransport(recorder), client.WithRetryOptions(client.RetryOptions{}))
+
+	// Errors are replayed too, whatever the order of the requests
+	_, err = c.CreatePipeline(&client.Pipeline{}, ctx)
+	assert.Equal(t, "EVALIDATION", err.(client.ApiResponseError).Code)
+	pipeline, err := c.CreatePipeline(&client.Pipeline{Title: "first"}, ctx)
+	assert.NoError(t, err)
+	assert.Equal(t, "first", pipeline.Title)
+	_, err = c.CreatePipeline(&client.Pipeline{Title: "second"}, ctx)
+	assert.ErrorContains(t, err, "has no recorded response for POST /v3/pipeline")
+}
+
+type countingTransport struct {
+	requests int
+}
+
+func (t *countingTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	t.requests++
+	return http.DefaultTransport.RoundTrip(req)
+}
+
+func TestCassetteRecordsThroughTransport(t *testing.T) {
+	ctx := context.Background()
+	cassettePath := filepath.Join(t.TempDir(), "test.json")
+	server := clienttest.NewServer(t)
+
+	// e.g. the transport with the certificates and proxy of the provider
+	transport := &countingTransport{}
+	recorder, _ := cassette.New(cassettePath, cassette.MODE_RECORD, nil)
+	c := client.NewClient(server.URL, "", nil, client.WithTransport(recorder.Through(transport)))
+	_, err := c.CreatePipeline(&client.Pipeline{Title: "first"}, ctx)
+	assert.NoError(t, err)
+	assert.Equal(t, 1, transport.requests)
+
+	// Requests sent with the recorder itself are added to the same cassette
+	c = client.NewClient(server.URL, "", nil, client.WithTransport(recorder))
+	_, err = c.CreatePipeline(&client.Pipeline{Title: "second"}, ctx)
+	assert.NoError(t, err)
+	assert.Equal(t, 1, transport.requests)
+	assert.NoError(t, recorder.Save())
+
+	content, err := os.ReadFile(cassettePath)
+	assert.NoError(t, err)
+	assert.Contains(t, string(content), `"title": "first"`)
+	assert.Contains(t, string(content), `"title": "second"`)
+}
+
+func TestCassetteModes(t *testing.T) {
+	_, err := cassette.New(filepath.Join(t.TempDir(), "missing.json"), cassette.MODE_REPLAY, nil)
+	assert.ErrorContains(t, err, "cannot read cassette")
+
+	_, err = cassette.New("test.json", "rewind", nil)
+	assert.ErrorContains(t, err, `unknown cassette mode "rewind"`)
+
+	cassettePath := filepath.Join(t.TempDir(), "shared.json")
+	first, err := cassette.Open(cassettePath, cassette.MODE_RECORD, nil)
+	assert.NoError(t, err)
+	second, err := cassette.Open(cassettePath, cassette.MODE_RECORD, []string{"password"})
+	assert.NoError(t, err)
+	assert.Same(t, first, second)
+	_, err = cassette.Open(cassettePath, cassette.MODE_REPLAY, nil)
+	assert.ErrorContains(t, err, "already open in record mode")
+
+	assert.NoError(t, cassette.Close(cassettePath))
+	_, err = os.Stat(cassettePath)
+	assert.NoError(t, err)
+}
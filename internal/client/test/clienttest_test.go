@@ -0,0 +1,157 @@
+package client_test
+
+import (
+	"context"
+	"testing"
+
+	"github.com/mezmo/terraform-provider-mezmo/v5/internal/client"
+	"github.com/mezmo/terraform-provider-mezmo/v5/internal/client/clienttest"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestFakeServerComponents(t *testing.T) {
+	ctx := context.Background()
+	server := clienttest.NewServer(t)
+	c := server.Client()
+
+	pipeline, err := c.CreatePipeline(&client.Pipeline{Title: "fake"}, ctx)
+	assert.NoError(t, err)
+	assert.Regexp(t, `^[\w-]{36}$`, pipeline.Id)
+
+	source, err := c.CreateSource(pipeline.Id, &client.Source{BaseNode: client.BaseNode{
+		Type:       "http",
+		UserConfig: map[string]any{"decoding": "json"},
+	}}, ctx)
+	assert.NoError(t, err)
+	assert.Equal(t, int64(1), source.GenerationId)
+
+	route, err := c.CreateProcessor(pipeline.Id, &client.Processor{BaseNode: client.BaseNode{
+		Type:       "route",
+		Inputs:     []string{source.Id},
+		UserConfig: map[string]any{"conditionals": []any{map[string]any{"label": "errors"}}},
+	}}, ctx)
+	assert.NoError(t, err)
+	assert.Len(t, route.Outputs, 2)
+	assert.Equal(t, route.Id+".output_0", route.Outputs[0].Id)
+	assert.Equal(t, route.Id+"._unmatched", route.Outputs[1].Id)
+
+	// Inputs have to exist, and processors with several outputs are referenced through one
+	_, err = c.CreateDestination(pipeline.Id, &client.Destination{BaseNode: client.BaseNode{
+		Type:       "blackhole",
+		Inputs:     []string{route.Id, "missing"},
+		UserConfig: map[string]any{},
+	}}, ctx)
+	apiErr, ok := err.(client.ApiResponseError)
+	assert.True(t, ok)
+	assert.Equal(t, uint16(400), apiErr.Status)
+	assert.Equal(t, "EVALIDATION", apiErr.Code)
+	assert.Len(t, apiErr.Errors, 2)
+	assert.Equal(t, "/inputs/0", apiErr.Errors[0].Path)
+	assert.Equal(t, "/inputs/1", apiErr.Errors[1].Path)
+
+	destination, err := c.CreateDestination(pipeline.Id, &client.Destination{BaseNode: client.BaseNode{
+		Type:       "blackhole",
+		Inputs:     []string{route.Outputs[0].Id},
+		UserConfig: map[string]any{},
+	}}, ctx)
+	assert.NoError(t, err)
+
+	graph, err := c.PipelineGraph(pipeline.Id, ctx)
+	assert.NoError(t, err)
+	assert.Len(t, graph.Sources, 1)
+	assert.Len(t, graph.Processors, 1)
+	assert.Len(t, graph.Destinations, 1)
+	assert.True(t, *graph.Pipeline.HasChanges)
+
+	err = c.DeleteDestination(pipeline.Id, destination.Id, ctx)
+	assert.NoError(t, err)
+	_, err = c.Destination(pipeline.Id, destination.Id, ctx)
+	assert.True(t, client.IsNotFoundError(err))
+}
+
+func TestFakeServerGenerations(t *testing.T) {
+	ctx := context.Background()
+	server := clienttest.NewServer(t)
+	c := server.Client()
+
+	pipeline, _ := c.CreatePipeline(&client.Pipeline{Title: "fake"}, ctx)
+	source, err := c.CreateSource(pipeline.Id, &client.Source{BaseNode: client.BaseNode{
+		Type:       "http",
+		UserConfig: map[string]any{},
+	}}, ctx)
+	assert.NoError(t, err)
+
+	source.Title = "updated"
+	updated, err := c.UpdateSource(pipeline.Id, source, ctx)
+	assert.NoError(t, err)
+	assert.Equal(t, int64(2), updated.GenerationId)
+	assert.Equal(t, "updated", updated.Title)
+
+	// Updates based on an old generation conflict
+	err = server.ModifyComponent(pipeline.Id, source.Id, func(component map[string]any) {
+		component["title"] = "changed elsewhere"
+	})
+	assert.NoError(t, err)
+	_, err = c.UpdateSource(pipeline.Id, updated, ctx)
+	assert.True(t, client.IsConflictError(err))
+
+	current, err := c.Source(pipeline.Id, source.Id, ctx)
+	assert.NoError(t, err)
+	assert.Equal(t, int64(3), current.GenerationId)
+	assert.Equal(t, "changed elsewhere", current.Title)
+}
+
+func TestFakeServerValidators(t *testing.T) {
+	ctx := context.Background()
+	server := clienttest.NewServer(t)
+	server.AddValidator(clienttest.RejectUserConfig(clienttest.KIND_SINK, "/user_config/uri", "must match format \"uri\""))
+	c := server.Client()
+
+	pipeline, _ := c.CreatePipeline(&client.Pipeline{Title: "fake"}, ctx)
+	_, err := c.CreateDestination(pipeline.Id, &client.Destination{BaseNode: client.BaseNode{
+		Type:       "http",
+		UserConfig: map[string]any{"uri": "not a uri"},
+	}}, ctx)
+	apiErr, ok := err.(client.ApiResponseError)
+	assert.True(t, ok)
+	assert.Equal(t, "/user_config/uri", apiErr.Errors[0].Path)
+	assert.Equal(t, "must match format \"uri\"", apiErr.Errors[0].Message)
+
+	_, err = c.CreatePipeline(&client.Pipeline{}, ctx)
+	apiErr, ok = err.(client.ApiResponseError)
+	assert.True(t, ok)
+	assert.Equal(t, "/title", apiErr.Errors[0].Path)
+}
+
+func TestFakeServerPublish(t *testing.T) {
+	ctx := context.Background()
+	server := clienttest.NewServer(t)
+	c := server.Client()
+
+	pipeline, _ := c.CreatePipeline(&client.Pipeline{Title: "fake"}, ctx)
+	_, err := c.PipelineDeployment(pipeline.Id, ctx)
+	assert.True(t, client.IsNotFoundError(err))
+
+	_, err = c.PublishPipeline(pipeline.Id, ctx)
+	assert.NoError(t, err)
+	_, err = c.PublishPipeline(pipeline.Id, ctx)
+	assert.Equal(t, "ENOCHANGES", err.(client.ApiResponseError).Code)
+
+	_, err = c.CreateSource(pipeline.Id, &client.Source{BaseNode: client.BaseNode{Type: "http", UserConfig: map[string]any{}}}, ctx)
+	assert.NoError(t, err)
+	_, err = c.PublishPipeline(pipeline.Id, ctx)
+	assert.NoError(t, err)
+
+	revisions, err := c.ListPipelineRevisions(pipeline.Id, ctx)
+	assert.NoError(t, err)
+	assert.Len(t, revisions, 2)
+
+	_, err = c.PublishPipelineRevision(pipeline.Id, revisions[1].Id, ctx)
+	assert.NoError(t, err)
+	published, _ := c.Pipeline(pipeline.Id, ctx)
+	assert.Equal(t, revisions[1].Id, published.PublishedRevisionId)
+
+	deployment, err := c.PipelineDeployment(pipeline.Id, ctx)
+	assert.NoError(t, err)
+	assert.Equal(t, client.DEPLOYMENT_STATUS_RUNNING, deployment.Status)
+}
@@ -0,0 +1,109 @@
+package client_test
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strconv"
+	"strings"
+	"testing"
+
+	"github.com/mezmo/terraform-provider-mezmo/v5/internal/client"
+	"github.com/stretchr/testify/assert"
+)
+
+// Serves `total` sources, honoring the limit and offset query parameters. Without
+// `paginate`, all of them are returned at once and the meta is left out.
+func sourcesServer(t *testing.T, total int, paginate bool) (*httptest.Server, *[]string) {
+	t.Helper()
+	var queries []string
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		queries = append(queries, r.URL.RawQuery)
+		limit, offset := total, 0
+		if paginate {
+			limit, _ = strconv.Atoi(r.URL.Query().Get("limit"))
+			offset, _ = strconv.Atoi(r.URL.Query().Get("offset"))
+		}
+		data := []map[string]any{}
+		for i := offset; i < total && i < offset+limit; i++ {
+			data = append(data, map[string]any{"id": fmt.Sprintf("source-%d", i), "type": "http"})
+		}
+		body := map[string]any{"data": data}
+		if paginate {
+			body["meta"] = map[string]any{"limit": limit, "offset": offset, "total": total}
+		}
+		w.Header().Set("Content-Type", "application/json")
+		json.NewEncoder(w).Encode(body)
+	}))
+	t.Cleanup(server.Close)
+	return server, &queries
+}
+
+func TestListFetchesAllPages(t *testing.T) {
+	total := 2*client.DefaultPageSize + 1
+	server, queries := sourcesServer(t, total, true)
+	c := client.NewClient(server.URL, "", nil)
+
+	sources, err := c.ListSources("pipeline-id", context.Background())
+	assert.NoError(t, err)
+	assert.Len(t, sources, total)
+	assert.Equal(t, "source-0", sources[0].Id)
+	assert.Equal(t, fmt.Sprintf("source-%d", total-1), sources[total-1].Id)
+	assert.Len(t, *queries, 3)
+	assert.True(t, strings.Contains((*queries)[2], fmt.Sprintf("offset=%d", 2*client.DefaultPageSize)))
+}
+
+func TestListWithoutPagination(t *testing.T) {
+	server, queries := sourcesServer(t, client.DefaultPageSize+5, false)
+	c := client.NewClient(server.URL, "", nil)
+
+	sources, err := c.ListSources("pipeline-id", context.Background())
+	assert.NoError(t, err)
+	assert.Len(t, sources, client.DefaultPageSize+5)
+	assert.Len(t, *queries, 1)
+}
+
+func TestListEmpty(t *testing.T) {
+	server, _ := sourcesServer(t, 0, true)
+	c := client.NewClient(server.URL, "", nil)
+
+	sources, err := c.ListSources("pipeline-id", context.Background())
+	assert.NoError(t, err)
+	assert.NotNil(t, sources)
+	assert.Empty(t, sources)
+}
+
+func TestPipelineGraph(t *testing.T) {
+	var paths []string
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		paths = append(paths, r.URL.Path)
+		w.Header().Set("Content-Type", "application/json")
+		switch {
+		case r.URL.Path == "/v3/pipeline/pid":
+			w.Write([]byte(`{"data": {"id": "pid", "title": "graph"}}`))
+		case strings.HasSuffix(r.URL.Path, "/alert"):
+			w.Write([]byte(`{"data": [{"id": "alert", "component_kind": "source", "component_id": "node"}]}`))
+		default:
+			w.Write([]byte(`{"data": [{"id": "node", "type": "http"}]}`))
+		}
+	}))
+	t.Cleanup(server.Close)
+	c := client.NewClient(server.URL, "", nil)
+
+	graph, err := c.PipelineGraph("pid", context.Background())
+	assert.NoError(t, err)
+	assert.Equal(t, "graph", graph.Pipeline.Title)
+	assert.Len(t, graph.Sources, 1)
+	assert.Len(t, graph.Processors, 1)
+	assert.Len(t, graph.Destinations, 1)
+	assert.Len(t, graph.Alerts, 1)
+	assert.Equal(t, []string{
+		"/v3/pipeline/pid",
+		"/v3/pipeline/pid/source",
+		"/v3/pipeline/pid/transform",
+		"/v3/pipeline/pid/sink",
+		"/v3/pipeline/pid/alert",
+	}, paths)
+}
@@ -0,0 +1,72 @@
+package client_test
+
+import (
+	"context"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/mezmo/terraform-provider-mezmo/v5/internal/client"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestPipelineRevisions(t *testing.T) {
+	var publishBody string
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		switch r.URL.Path {
+		case "/v3/pipeline/pid/revision":
+			w.Write([]byte(`{"data": [
+				{"id": "rev-1", "published_at": "2024-01-02T03:04:05Z", "published_by": "someone@example.org"},
+				{"id": "rev-2", "published_at": "2024-02-02T03:04:05Z"}
+			]}`))
+		case "/v3/pipeline/pid/publish":
+			body, _ := io.ReadAll(r.Body)
+			publishBody = string(body)
+			w.Write([]byte(`{"data": {"id": "pid"}}`))
+		default:
+			w.WriteHeader(http.StatusNotFound)
+			w.Write([]byte(`{"message": "not found", "code": "ENOTFOUND"}`))
+		}
+	}))
+	t.Cleanup(server.Close)
+	c := client.NewClient(server.URL, "", nil)
+
+	revisions, err := c.ListPipelineRevisions("pid", context.Background())
+	assert.NoError(t, err)
+	assert.Len(t, revisions, 2)
+	assert.Equal(t, "someone@example.org", revisions[0].PublishedBy)
+	assert.Equal(t, 2024, revisions[0].PublishedAt.Year())
+
+	_, err = c.PublishPipelineRevision("pid", "rev-1", context.Background())
+	assert.NoError(t, err)
+	assert.Equal(t, `{"revision_id":"rev-1"}`, publishBody)
+
+	_, err = c.PublishPipeline("pid", context.Background())
+	assert.NoError(t, err)
+	assert.Equal(t, `{}`, publishBody)
+}
+
+func TestPipelinePublishedState(t *testing.T) {
+	body := `{"data": {"id": "pid", "title": "p", "published_revision_id": "rev-1", "has_changes": false}}`
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		w.Write([]byte(body))
+	}))
+	t.Cleanup(server.Close)
+	c := client.NewClient(server.URL, "", nil)
+
+	pipeline, err := c.Pipeline("pid", context.Background())
+	assert.NoError(t, err)
+	assert.Equal(t, "rev-1", pipeline.PublishedRevisionId)
+	assert.NotNil(t, pipeline.HasChanges)
+	assert.False(t, *pipeline.HasChanges)
+
+	// Servers that do not report the published state
+	body = `{"data": {"id": "pid", "title": "p"}}`
+	pipeline, err = c.Pipeline("pid", context.Background())
+	assert.NoError(t, err)
+	assert.Equal(t, "", pipeline.PublishedRevisionId)
+	assert.Nil(t, pipeline.HasChanges)
+}
@@ -0,0 +1,131 @@
+package client_test
+
+import (
+	"context"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/mezmo/terraform-provider-mezmo/v5/internal/client"
+	"github.com/stretchr/testify/assert"
+)
+
+var fastRetries = client.RetryOptions{
+	MaxRetries: 3,
+	WaitMin:    time.Millisecond,
+	WaitMax:    10 * time.Millisecond,
+}
+
+// Responds with `failures` copies of `status` before succeeding
+func flakyServer(t *testing.T, status int, failures int32, header http.Header) (*httptest.Server, *atomic.Int32) {
+	t.Helper()
+	var calls atomic.Int32
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if calls.Add(1) <= failures {
+			for k, v := range header {
+				w.Header()[k] = v
+			}
+			w.WriteHeader(status)
+			return
+		}
+		w.Header().Set("Content-Type", "application/json")
+		w.Write([]byte(`{"data": {"id": "abc", "title": "retried"}}`))
+	}))
+	t.Cleanup(server.Close)
+	return server, &calls
+}
+
+func TestRetryIdempotentRequests(t *testing.T) {
+	for _, status := range []int{
+		http.StatusTooManyRequests,
+		http.StatusBadGateway,
+		http.StatusServiceUnavailable,
+		http.StatusGatewayTimeout,
+	} {
+		t.Run(http.StatusText(status), func(t *testing.T) {
+			server, calls := flakyServer(t, status, 2, nil)
+			c := client.NewClient(server.URL, "", nil, client.WithRetryOptions(fastRetries))
+
+			pipeline, err := c.Pipeline("abc", context.Background())
+			assert.NoError(t, err)
+			assert.Equal(t, "retried", pipeline.Title)
+			assert.EqualValues(t, 3, calls.Load())
+		})
+	}
+}
+
+func TestRetryBodyIsResent(t *testing.T) {
+	var bodies []string
+	var calls atomic.Int32
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		body, _ := io.ReadAll(r.Body)
+		bodies = append(bodies, string(body))
+		if calls.Add(1) == 1 {
+			w.WriteHeader(http.StatusServiceUnavailable)
+			return
+		}
+		w.Write([]byte(`{"data": {"id": "abc", "title": "updated"}}`))
+	}))
+	t.Cleanup(server.Close)
+	c := client.NewClient(server.URL, "", nil, client.WithRetryOptions(fastRetries))
+
+	_, err := c.UpdatePipeline(&client.Pipeline{Id: "abc", Title: "updated"}, context.Background())
+	assert.NoError(t, err)
+	assert.Len(t, bodies, 2)
+	assert.Equal(t, bodies[0], bodies[1])
+}
+
+func TestNoRetryForPost(t *testing.T) {
+	server, calls := flakyServer(t, http.StatusServiceUnavailable, 1, nil)
+	c := client.NewClient(server.URL, "", nil, client.WithRetryOptions(fastRetries))
+
+	_, err := c.CreatePipeline(&client.Pipeline{Title: "not retried"}, context.Background())
+	assert.Error(t, err)
+	assert.EqualValues(t, 1, calls.Load())
+}
+
+func TestRetryNonIdempotent(t *testing.T) {
+	server, calls := flakyServer(t, http.StatusServiceUnavailable, 1, nil)
+	options := fastRetries
+	options.RetryNonIdempotent = true
+	c := client.NewClient(server.URL, "", nil, client.WithRetryOptions(options))
+
+	_, err := c.CreatePipeline(&client.Pipeline{Title: "retried"}, context.Background())
+	assert.NoError(t, err)
+	assert.EqualValues(t, 2, calls.Load())
+}
+
+func TestNoRetryForClientErrors(t *testing.T) {
+	server, calls := flakyServer(t, http.StatusBadRequest, 1, nil)
+	c := client.NewClient(server.URL, "", nil, client.WithRetryOptions(fastRetries))
+
+	_, err := c.Pipeline("abc", context.Background())
+	assert.Error(t, err)
+	assert.EqualValues(t, 1, calls.Load())
+}
+
+func TestRetryBudgetExhausted(t *testing.T) {
+	server, calls := flakyServer(t, http.StatusServiceUnavailable, 100, nil)
+	c := client.NewClient(server.URL, "", nil, client.WithRetryOptions(fastRetries))
+
+	_, err := c.Pipeline("abc", context.Background())
+	apiErr, ok := err.(client.ApiResponseError)
+	assert.True(t, ok, "expected an ApiResponseError, got %T", err)
+	assert.EqualValues(t, http.StatusServiceUnavailable, apiErr.Status)
+	assert.EqualValues(t, fastRetries.MaxRetries+1, calls.Load())
+}
+
+func TestRetryAfterIsCappedByWaitMax(t *testing.T) {
+	header := http.Header{"Retry-After": []string{"3600"}}
+	server, calls := flakyServer(t, http.StatusTooManyRequests, 1, header)
+	c := client.NewClient(server.URL, "", nil, client.WithRetryOptions(fastRetries))
+
+	start := time.Now()
+	_, err := c.Pipeline("abc", context.Background())
+	assert.NoError(t, err)
+	assert.EqualValues(t, 2, calls.Load())
+	assert.Less(t, time.Since(start), time.Second)
+}
@@ -0,0 +1,71 @@
+package client_test
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/mezmo/terraform-provider-mezmo/v5/internal/client"
+	"github.com/stretchr/testify/assert"
+)
+
+var noRetries = client.RetryOptions{MaxRetries: 0}
+
+func hangingServer(t *testing.T) *httptest.Server {
+	t.Helper()
+	done := make(chan struct{})
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		select {
+		case <-done:
+		case <-r.Context().Done():
+		}
+	}))
+	t.Cleanup(func() {
+		close(done)
+		server.Close()
+	})
+	return server
+}
+
+func TestRequestTimeout(t *testing.T) {
+	server := hangingServer(t)
+	c := client.NewClient(server.URL, "", nil,
+		client.WithRetryOptions(noRetries),
+		client.WithRequestTimeout(50*time.Millisecond),
+	)
+
+	_, err := c.Pipeline("abc", context.Background())
+	assert.True(t, client.IsTimeoutError(err), "expected a TimeoutError, got %T: %v", err, err)
+
+	var timeoutErr client.TimeoutError
+	assert.True(t, errors.As(err, &timeoutErr))
+	assert.Equal(t, http.MethodGet, timeoutErr.Method)
+	assert.Equal(t, server.URL+"/v3/pipeline/abc", timeoutErr.URL)
+	assert.Equal(t, 50*time.Millisecond, timeoutErr.Timeout)
+}
+
+func TestContextDeadline(t *testing.T) {
+	server := hangingServer(t)
+	c := client.NewClient(server.URL, "", nil, client.WithRetryOptions(noRetries))
+
+	ctx, cancel := context.WithTimeout(context.Background(), 50*time.Millisecond)
+	defer cancel()
+	_, err := c.Pipeline("abc", ctx)
+	assert.True(t, client.IsTimeoutError(err), "expected a TimeoutError, got %T: %v", err, err)
+}
+
+func TestContextCancellation(t *testing.T) {
+	server := hangingServer(t)
+	c := client.NewClient(server.URL, "", nil, client.WithRetryOptions(fastRetries))
+
+	ctx, cancel := context.WithCancel(context.Background())
+	time.AfterFunc(50*time.Millisecond, cancel)
+	start := time.Now()
+	err := c.DeletePipeline("abc", ctx)
+	assert.ErrorIs(t, err, context.Canceled)
+	assert.False(t, client.IsTimeoutError(err))
+	assert.Less(t, time.Since(start), time.Second)
+}
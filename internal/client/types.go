@@ -17,6 +17,19 @@ type Pipeline struct {
 	CreatedAt *time.Time `json:"created_at,omitempty"`
 	UpdatedAt *time.Time `json:"updated_at,omitempty"`
 	Origin    Origin     `json:"origin"`
+	// Read-only fields describing the published state of the pipeline. They are empty when the
+	// API does not report them, and callers fall back to publishing unconditionally.
+	PublishedRevisionId string `json:"published_revision_id,omitempty"`
+	HasChanges          *bool  `json:"has_changes,omitempty"`
+}
+
+// A pipeline together with all of its nodes and alerts
+type PipelineGraph struct {
+	Pipeline     Pipeline
+	Sources      []Source
+	Processors   []Processor
+	Destinations []Destination
+	Alerts       []Alert
 }
 
 // Represents a source, processor or destination.
@@ -67,13 +80,55 @@ type AccessKey struct {
 }
 
 type SharedSource struct {
-	Id          string `json:"id"`
-	ConsumerId  string `json:"consumer_id"`
-	Title       string `json:"title"`
-	Description string `json:"description,omitempty"`
-	Type        string `json:"type"`
+	Id                string                   `json:"id"`
+	ConsumerId        string                   `json:"consumer_id"`
+	Title             string                   `json:"title"`
+	Description       string                   `json:"description,omitempty"`
+	Type              string                   `json:"type"`
+	AttachedPipelines []SharedSourceAttachment `json:"attached_pipelines,omitempty"`
+}
+
+// A pipeline source that consumes a shared source. Only returned by the API.
+type SharedSourceAttachment struct {
+	PipelineId string `json:"pipeline_id"`
+	SourceId   string `json:"source_id"`
+}
+
+// A reusable target for alert notifications. Alerts reference it by id.
+type NotificationChannel struct {
+	Id      string         `json:"id,omitempty"`
+	Title   string         `json:"title"`
+	Service map[string]any `json:"service"`
 }
 
 type PublishPipeline struct {
 	PipelineId string `json:"id"`
 }
+
+// A published revision of a pipeline. Only returned by the API.
+type PipelineRevision struct {
+	Id          string     `json:"id"`
+	PublishedAt *time.Time `json:"published_at,omitempty"`
+	PublishedBy string     `json:"published_by,omitempty"`
+}
+
+type DeploymentStatus string
+
+const (
+	DEPLOYMENT_STATUS_PENDING   DeploymentStatus = "pending"
+	DEPLOYMENT_STATUS_DEPLOYING DeploymentStatus = "deploying"
+	DEPLOYMENT_STATUS_RUNNING   DeploymentStatus = "running"
+	DEPLOYMENT_STATUS_FAILED    DeploymentStatus = "failed"
+)
+
+// The deployment state of a pipeline's published revision
+type PipelineDeployment struct {
+	Status     DeploymentStatus `json:"status"`
+	RevisionId string           `json:"revision_id,omitempty"`
+	Error      *DeploymentError `json:"error,omitempty"`
+}
+
+type DeploymentError struct {
+	Message string   `json:"message"`
+	Details []string `json:"details,omitempty"`
+}
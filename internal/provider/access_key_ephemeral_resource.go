@@ -0,0 +1,111 @@
+package provider
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+
+	"github.com/hashicorp/terraform-plugin-framework/ephemeral"
+	"github.com/hashicorp/terraform-plugin-framework/types/basetypes"
+	"github.com/mezmo/terraform-provider-mezmo/v5/internal/client"
+	. "github.com/mezmo/terraform-provider-mezmo/v5/internal/provider/models"
+)
+
+var (
+	_ ephemeral.EphemeralResource              = &AccessKeyEphemeralResource{}
+	_ ephemeral.EphemeralResourceWithConfigure = &AccessKeyEphemeralResource{}
+	_ ephemeral.EphemeralResourceWithClose     = &AccessKeyEphemeralResource{}
+)
+
+// Private data key of the key that `Open` created, which `Close` deletes
+const privateKeyAccessKey = "access_key"
+
+type accessKeyPrivateData struct {
+	Id       string `json:"id"`
+	SourceId string `json:"source_id"`
+}
+
+func NewAccessKeyEphemeralResource() ephemeral.EphemeralResource {
+	return &AccessKeyEphemeralResource{}
+}
+
+type AccessKeyEphemeralResource struct {
+	client client.Client
+}
+
+func (r *AccessKeyEphemeralResource) Metadata(_ context.Context, req ephemeral.MetadataRequest, resp *ephemeral.MetadataResponse) {
+	resp.TypeName = PROVIDER_TYPE_NAME + "_access_key"
+}
+
+func (*AccessKeyEphemeralResource) Schema(_ context.Context, _ ephemeral.SchemaRequest, resp *ephemeral.SchemaResponse) {
+	resp.Schema = AccessKeyEphemeralResourceSchema()
+}
+
+// Configure implements ephemeral.EphemeralResourceWithConfigure.
+func (r *AccessKeyEphemeralResource) Configure(_ context.Context, req ephemeral.ConfigureRequest, resp *ephemeral.ConfigureResponse) {
+	if req.ProviderData == nil {
+		return
+	}
+
+	client, ok := req.ProviderData.(client.Client)
+
+	if !ok {
+		resp.Diagnostics.AddError(
+			"Unexpected Ephemeral Resource Configure Type",
+			fmt.Sprintf("Expected client.Client, got: %T. Please report this issue to Mezmo.", req.ProviderData),
+		)
+
+		return
+	}
+
+	r.client = client
+}
+
+// POST access key
+func (r *AccessKeyEphemeralResource) Open(ctx context.Context, req ephemeral.OpenRequest, resp *ephemeral.OpenResponse) {
+	var config AccessKeyEphemeralResourceModel
+	if diags := req.Config.Get(ctx, &config); setDiagnosticsHasError(diags, &resp.Diagnostics) {
+		return
+	}
+
+	stored, err := r.client.CreateAccessKey(AccessKeyFromEphemeralModel(&config), ctx)
+	if err != nil {
+		addClientErrorDiagnostic(&resp.Diagnostics, err,
+			"Error creating access key",
+			"Could not create access key, unexpected error: "+err.Error(),
+		)
+		return
+	}
+
+	private, err := json.Marshal(accessKeyPrivateData{Id: stored.Id, SourceId: config.SourceId.ValueString()})
+	if err != nil {
+		resp.Diagnostics.AddError("Error creating access key", "Could not record the access key: "+err.Error())
+		return
+	}
+	resp.Diagnostics.Append(resp.Private.SetKey(ctx, privateKeyAccessKey, private)...)
+
+	config.Id = basetypes.NewStringValue(stored.Id)
+	config.Key = basetypes.NewStringValue(stored.Key)
+	resp.Diagnostics.Append(resp.Result.Set(ctx, config)...)
+}
+
+// DELETE access key, once terraform no longer needs it
+func (r *AccessKeyEphemeralResource) Close(ctx context.Context, req ephemeral.CloseRequest, resp *ephemeral.CloseResponse) {
+	private, diags := req.Private.GetKey(ctx, privateKeyAccessKey)
+	if setDiagnosticsHasError(diags, &resp.Diagnostics) || private == nil {
+		return
+	}
+	var key accessKeyPrivateData
+	if err := json.Unmarshal(private, &key); err != nil {
+		resp.Diagnostics.AddError("Error Deleting Access Key", "Could not read the access key to delete: "+err.Error())
+		return
+	}
+
+	err := r.client.DeleteAccessKey(&client.AccessKey{Id: key.Id, SharedSourceId: key.SourceId}, ctx)
+	if err != nil && !client.IsNotFoundError(err) {
+		addClientErrorDiagnostic(&resp.Diagnostics, err,
+			"Error Deleting Access Key",
+			fmt.Sprintf("Could not delete access key %s, unexpected error: %s", key.Id, err.Error()),
+		)
+	}
+}
@@ -0,0 +1,64 @@
+package provider
+
+import (
+	"context"
+	"fmt"
+	"regexp"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-framework/providerserver"
+	"github.com/hashicorp/terraform-plugin-go/tfprotov6"
+	"github.com/hashicorp/terraform-plugin-testing/echoprovider"
+	"github.com/hashicorp/terraform-plugin-testing/helper/resource"
+	"github.com/hashicorp/terraform-plugin-testing/terraform"
+	"github.com/hashicorp/terraform-plugin-testing/tfversion"
+	. "github.com/mezmo/terraform-provider-mezmo/v5/internal/provider/providertest"
+)
+
+func TestAccessKeyEphemeralResource(t *testing.T) {
+	resource.Test(t, resource.TestCase{
+		// The echo provider exposes the ephemeral values, which are never written to the state
+		ProtoV6ProviderFactories: map[string]func() (tfprotov6.ProviderServer, error){
+			"mezmo": providerserver.NewProtocol6WithError(New("test")()),
+			"echo":  echoprovider.NewProviderServer(),
+		},
+		TerraformVersionChecks: []tfversion.TerraformVersionCheck{
+			tfversion.SkipBelow(tfversion.Version1_10_0),
+		},
+		PreCheck: func() { TestPreCheck(t) },
+		Steps: []resource.TestStep{
+			{
+				Config: GetProviderConfig() + `
+					resource "mezmo_shared_source" "shared" {
+						title = "shared source"
+						type  = "http"
+					}
+					ephemeral "mezmo_access_key" "temporary" {
+						title     = "temporary key"
+						source_id = mezmo_shared_source.shared.id
+					}
+					provider "echo" {
+						data = ephemeral.mezmo_access_key.temporary
+					}
+					resource "echo" "key" {}`,
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr("echo.key", "data.title", "temporary key"),
+					resource.TestCheckResourceAttrPair("echo.key", "data.source_id", "mezmo_shared_source.shared", "id"),
+					resource.TestMatchResourceAttr("echo.key", "data.key", regexp.MustCompile(`\w+`)),
+					// Every run deletes the keys that it created once it is done
+					func(s *terraform.State) error {
+						sourceId := s.RootModule().Resources["mezmo_shared_source.shared"].Primary.ID
+						keys, err := NewTestClient().ListAccessKeys(sourceId, context.Background())
+						if err != nil {
+							return err
+						}
+						if len(keys) != 0 {
+							return fmt.Errorf("expected the ephemeral access keys to be deleted, found %d", len(keys))
+						}
+						return nil
+					},
+				),
+			},
+		},
+	})
+}
@@ -3,17 +3,25 @@ package provider
 import (
 	"context"
 	"fmt"
+	"strings"
+	"time"
 
 	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
 
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/path"
 	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/types/basetypes"
 	"github.com/mezmo/terraform-provider-mezmo/v5/internal/client"
 	. "github.com/mezmo/terraform-provider-mezmo/v5/internal/provider/models"
 )
 
 var (
-	_ resource.Resource              = &AccessKeyResource{}
-	_ resource.ResourceWithConfigure = &AccessKeyResource{}
+	_ resource.Resource                   = &AccessKeyResource{}
+	_ resource.ResourceWithConfigure      = &AccessKeyResource{}
+	_ resource.ResourceWithValidateConfig = &AccessKeyResource{}
+	_ resource.ResourceWithModifyPlan     = &AccessKeyResource{}
+	_ resource.ResourceWithImportState    = &AccessKeyResource{}
 )
 
 func NewAccessKeyResource() resource.Resource {
@@ -37,7 +45,7 @@ func (r *AccessKeyResource) TerraformSchema() schema.Schema {
 }
 
 func (r *AccessKeyResource) NotConvertible() bool {
-	// There is no import for access keys, so implement the "not convertible" interface
+	// The cleartext key cannot be read back, so implement the "not convertible" interface
 	return true
 }
 
@@ -72,7 +80,7 @@ func (r *AccessKeyResource) Create(ctx context.Context, req resource.CreateReque
 	stored, err := r.client.CreateAccessKey(accessKey, ctx)
 
 	if err != nil {
-		resp.Diagnostics.AddError(
+		addClientErrorDiagnostic(&resp.Diagnostics, err,
 			"Error creating access key",
 			"Could not create access key, unexpected error: "+err.Error(),
 		)
@@ -80,10 +88,25 @@ func (r *AccessKeyResource) Create(ctx context.Context, req resource.CreateReque
 	}
 
 	AccessKeyToModel(&plan, stored)
+	plan.CreatedAt = basetypes.NewStringValue(time.Now().UTC().Format(time.RFC3339))
+	ClearPreviousAccessKey(&plan)
 	diags := resp.State.Set(ctx, plan)
 	setDiagnosticsHasError(diags, &resp.Diagnostics)
 }
 
+// Deletes a key by id, treating a key that is already gone as deleted
+func (r *AccessKeyResource) deleteKey(id string, sourceId string, ctx context.Context, diags *diag.Diagnostics) bool {
+	err := r.client.DeleteAccessKey(&client.AccessKey{Id: id, SharedSourceId: sourceId}, ctx)
+	if err != nil && !client.IsNotFoundError(err) {
+		addClientErrorDiagnostic(diags, err,
+			"Error Deleting Access Key",
+			fmt.Sprintf("Could not delete access key %s, unexpected error: %s", id, err.Error()),
+		)
+		return false
+	}
+	return true
+}
+
 // Delete implements resource.Resource.
 func (r *AccessKeyResource) Delete(ctx context.Context, req resource.DeleteRequest, resp *resource.DeleteResponse) {
 	var state AccessKeyResourceModel
@@ -91,38 +114,240 @@ func (r *AccessKeyResource) Delete(ctx context.Context, req resource.DeleteReque
 		return
 	}
 
-	accessKey := AccessKeyFromModel(&state)
+	// Keys that weren't found are ignored, and TF cleans up state on its own
+	if !state.PreviousId.IsNull() {
+		r.deleteKey(state.PreviousId.ValueString(), state.SourceId.ValueString(), ctx, &resp.Diagnostics)
+	}
+	r.deleteKey(state.Id.ValueString(), state.SourceId.ValueString(), ctx, &resp.Diagnostics)
+}
+
+func (r *AccessKeyResource) Metadata(_ context.Context, req resource.MetadataRequest, resp *resource.MetadataResponse) {
+	resp.TypeName = r.TypeName()
+}
+
+func (r *AccessKeyResource) Read(ctx context.Context, req resource.ReadRequest, resp *resource.ReadResponse) {
+	var state AccessKeyResourceModel
+	if diags := req.State.Get(ctx, &state); setDiagnosticsHasError(diags, &resp.Diagnostics) {
+		return
+	}
+	sourceId := state.SourceId.ValueString()
 
-	err := r.client.DeleteAccessKey(accessKey, ctx)
+	stored, err := r.client.AccessKey(sourceId, state.Id.ValueString(), ctx)
 	if client.IsNotFoundError(err) {
-		// If the key wasn't found, just ignore and let TF clean up state on its own
+		// The key was revoked outside of terraform
+		resp.State.RemoveResource(ctx)
 		return
 	}
 	if err != nil {
-		resp.Diagnostics.AddError(
-			"Error Deleting Access Key",
-			"Could not delete access key, unexpected error: "+err.Error(),
+		addClientErrorDiagnostic(&resp.Diagnostics, err,
+			"Error Reading Access Key",
+			"Could not read access key, unexpected error: "+err.Error(),
 		)
 		return
 	}
-}
+	// The cleartext key is only returned on creation, so the one in state is kept
+	state.Title = basetypes.NewStringValue(stored.Title)
 
-func (r *AccessKeyResource) Metadata(_ context.Context, req resource.MetadataRequest, resp *resource.MetadataResponse) {
-	resp.TypeName = r.TypeName()
+	if !state.PreviousId.IsNull() {
+		_, err := r.client.AccessKey(sourceId, state.PreviousId.ValueString(), ctx)
+		if client.IsNotFoundError(err) {
+			ClearPreviousAccessKey(&state)
+		} else if err != nil {
+			addClientErrorDiagnostic(&resp.Diagnostics, err,
+				"Error Reading Access Key",
+				"Could not read previous access key, unexpected error: "+err.Error(),
+			)
+			return
+		}
+	}
+	if state.Overlap.IsNull() {
+		state.Overlap = basetypes.NewStringValue("0s")
+	}
+	// State written by older versions has no creation time, so `rotation_days` counts from the first refresh
+	if state.CreatedAt.IsNull() {
+		state.CreatedAt = basetypes.NewStringValue(time.Now().UTC().Format(time.RFC3339))
+	}
+
+	diags := resp.State.Set(ctx, state)
+	setDiagnosticsHasError(diags, &resp.Diagnostics)
 }
 
-func (r *AccessKeyResource) Read(ctx context.Context, req resource.ReadRequest, resp *resource.ReadResponse) {
-	// There is no getter for access keys. Implement a noop to satisfy the interface.
+// Imports the metadata of a key by `<source_id>/<key_id>`. The cleartext key stays unknown.
+func (r *AccessKeyResource) ImportState(ctx context.Context, req resource.ImportStateRequest, resp *resource.ImportStateResponse) {
+	parts := strings.Split(req.ID, "/")
+	if len(parts) != 2 {
+		resp.Diagnostics.AddError(
+			"Malformed Resource Import Id",
+			fmt.Sprintf(
+				"The access key resource import id needs to be in the form of <source id>/<key id>. The "+
+					"input \"%s\" did not contain two parts after parsing the id.",
+				req.ID,
+			),
+		)
+		return
+	}
+
+	sourceId := strings.TrimSpace(parts[0])
+	if sourceId == "" {
+		resp.Diagnostics.AddError(
+			"Resource Import Id Missing Source Id Part",
+			"The source id specified only contained whitespace.",
+		)
+	}
+
+	id := strings.TrimSpace(parts[1])
+	if id == "" {
+		resp.Diagnostics.AddError(
+			"Resource Import Id Missing Access Key Id Part",
+			"The access key id specified only contained whitespace.",
+		)
+	}
+
+	if !resp.Diagnostics.HasError() {
+		resp.Diagnostics.Append(resp.State.SetAttribute(ctx, path.Root("id"), id)...)
+		resp.Diagnostics.Append(resp.State.SetAttribute(ctx, path.Root("source_id"), sourceId)...)
+		// The age of the key is unknown, so `rotation_days` counts from the import
+		createdAt := time.Now().UTC().Format(time.RFC3339)
+		resp.Diagnostics.Append(resp.State.SetAttribute(ctx, path.Root("created_at"), createdAt)...)
+	}
 }
 
 func (*AccessKeyResource) Schema(_ context.Context, _ resource.SchemaRequest, resp *resource.SchemaResponse) {
 	resp.Schema = AccessKeyResourceSchema()
 }
 
+// Rotations need an overlap, so it cannot be left at zero once a rotation trigger is set
+func (r *AccessKeyResource) ValidateConfig(ctx context.Context, req resource.ValidateConfigRequest, resp *resource.ValidateConfigResponse) {
+	var config AccessKeyResourceModel
+	if diags := req.Config.Get(ctx, &config); setDiagnosticsHasError(diags, &resp.Diagnostics) {
+		return
+	}
+	if config.RotationDays.IsNull() && config.RotateWhenChanged.IsNull() {
+		return
+	}
+	if config.Overlap.IsNull() || accessKeyOverlapIsZero(config.Overlap) {
+		resp.Diagnostics.AddAttributeError(
+			path.Root("overlap"),
+			"Access Key Rotation Requires Overlap",
+			"`rotation_days` and `rotate_when_changed` replace the key, which requires a nonzero `overlap` "+
+				"to keep the current key working while shippers switch to the new one.",
+		)
+	}
+}
+
+// Unknown and invalid durations are left to the plan and the attribute validators
+func accessKeyOverlapIsZero(overlap basetypes.StringValue) bool {
+	if overlap.IsNull() || overlap.IsUnknown() {
+		return false
+	}
+	duration, err := time.ParseDuration(overlap.ValueString())
+	return err == nil && duration == 0
+}
+
+// Plans a rotation when it is due, and the removal of a previous key whose overlap is over
+func (r *AccessKeyResource) ModifyPlan(ctx context.Context, req resource.ModifyPlanRequest, resp *resource.ModifyPlanResponse) {
+	if req.State.Raw.IsNull() || req.Plan.Raw.IsNull() {
+		return
+	}
+	var state, plan AccessKeyResourceModel
+	if diags := req.State.Get(ctx, &state); setDiagnosticsHasError(diags, &resp.Diagnostics) {
+		return
+	}
+	if diags := req.Plan.Get(ctx, &plan); setDiagnosticsHasError(diags, &resp.Diagnostics) {
+		return
+	}
+
+	now := time.Now()
+	if AccessKeyRotationDue(&state, &plan, now) {
+		// Without an overlap, the shippers that use the current key would lose access as soon
+		// as it is replaced. Keys cannot be renamed, so this includes a new title.
+		if accessKeyOverlapIsZero(plan.Overlap) {
+			resp.Diagnostics.AddAttributeError(
+				path.Root("overlap"),
+				"Access Key Rotation Requires Overlap",
+				"Rotating the access key replaces it, and so does changing its title. Set `overlap` "+
+					"to keep the current key working while shippers switch to the new one.",
+			)
+			return
+		}
+		plan.Id = basetypes.NewStringUnknown()
+		plan.Key = basetypes.NewStringUnknown()
+		plan.CreatedAt = basetypes.NewStringUnknown()
+		plan.PreviousId = basetypes.NewStringUnknown()
+		plan.PreviousKey = basetypes.NewStringUnknown()
+		plan.PreviousExpiresAt = basetypes.NewStringUnknown()
+	} else {
+		plan.Id = state.Id
+		plan.Key = state.Key
+		plan.CreatedAt = state.CreatedAt
+		plan.PreviousId = state.PreviousId
+		plan.PreviousKey = state.PreviousKey
+		plan.PreviousExpiresAt = state.PreviousExpiresAt
+		if AccessKeyPreviousExpired(&state, now) {
+			ClearPreviousAccessKey(&plan)
+		}
+	}
+	diags := resp.Plan.Set(ctx, plan)
+	setDiagnosticsHasError(diags, &resp.Diagnostics)
+}
+
+// Rotates the key when planned. The new key is created before the current one becomes the
+// previous key, which is kept until the overlap is over.
 func (r *AccessKeyResource) Update(ctx context.Context, req resource.UpdateRequest, resp *resource.UpdateResponse) {
-	// Updating keys is not supported. Throw an error until such time (if ever) we allow patching.
-	resp.Diagnostics.AddError(
-		fmt.Sprintf("Cannot update %s after creation", r.TypeName()),
-		"Access keys are currently imutable and cannot be updated.",
-	)
+	var state, plan AccessKeyResourceModel
+	if diags := req.State.Get(ctx, &state); setDiagnosticsHasError(diags, &resp.Diagnostics) {
+		return
+	}
+	if diags := req.Plan.Get(ctx, &plan); setDiagnosticsHasError(diags, &resp.Diagnostics) {
+		return
+	}
+	overlap, err := time.ParseDuration(plan.Overlap.ValueString())
+	if err != nil {
+		resp.Diagnostics.AddAttributeError(
+			path.Root("overlap"), "Invalid Overlap", "Could not parse overlap: "+err.Error(),
+		)
+		return
+	}
+	sourceId := state.SourceId.ValueString()
+
+	if !plan.Id.IsUnknown() {
+		if plan.PreviousId.IsNull() && !state.PreviousId.IsNull() {
+			if !r.deleteKey(state.PreviousId.ValueString(), sourceId, ctx, &resp.Diagnostics) {
+				return
+			}
+		}
+		diags := resp.State.Set(ctx, plan)
+		setDiagnosticsHasError(diags, &resp.Diagnostics)
+		return
+	}
+
+	stored, err := r.client.CreateAccessKey(AccessKeyFromModel(&plan), ctx)
+	if err != nil {
+		addClientErrorDiagnostic(&resp.Diagnostics, err,
+			"Error rotating access key",
+			"Could not create the replacement access key, unexpected error: "+err.Error(),
+		)
+		resp.State.Set(ctx, state)
+		return
+	}
+
+	// Only one previous key is kept, so an earlier one is dropped once the new key exists.
+	// If that fails, the new key is deleted again and the rotation is retried on the next apply.
+	if !state.PreviousId.IsNull() {
+		if !r.deleteKey(state.PreviousId.ValueString(), sourceId, ctx, &resp.Diagnostics) {
+			r.deleteKey(stored.Id, sourceId, ctx, &resp.Diagnostics)
+			resp.State.Set(ctx, state)
+			return
+		}
+	}
+
+	now := time.Now().UTC()
+	AccessKeyToModel(&plan, stored)
+	plan.CreatedAt = basetypes.NewStringValue(now.Format(time.RFC3339))
+	plan.PreviousId = state.Id
+	plan.PreviousKey = state.Key
+	plan.PreviousExpiresAt = basetypes.NewStringValue(now.Add(overlap).Format(time.RFC3339))
+
+	diags := resp.State.Set(ctx, plan)
+	setDiagnosticsHasError(diags, &resp.Diagnostics)
 }
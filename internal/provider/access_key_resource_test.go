@@ -1,15 +1,19 @@
 package provider
 
 import (
+	"fmt"
 	"regexp"
 	"testing"
 
 	"github.com/hashicorp/terraform-plugin-testing/helper/resource"
+	"github.com/hashicorp/terraform-plugin-testing/terraform"
 	. "github.com/mezmo/terraform-provider-mezmo/v5/internal/provider/providertest"
 )
 
 func TestAccessKeyResource(t *testing.T) {
 	cacheKey := "access_key_resource_tests"
+	// The id of the key after the first rotation, which the second rotation keeps as the previous key
+	var rotatedId string
 	resource.Test(t, resource.TestCase{
 		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
 		PreCheck:                 func() { TestPreCheck(t) },
@@ -48,16 +52,118 @@ func TestAccessKeyResource(t *testing.T) {
 					}),
 				),
 			},
-			// Access keys are immutable, so no updates are allowed
+			// Import by <source_id>/<key_id>. The cleartext key cannot be imported.
 			{
 				Config: GetCachedConfig(cacheKey) + `
 					resource "mezmo_access_key" "for_http" {
-						title = "change not allowed"
+						title = "http ingestion key"
+						source_id = mezmo_http_source.my_source.id
+					}`,
+				ImportState:  true,
+				ResourceName: "mezmo_access_key.for_http",
+				ImportStateIdFunc: func(s *terraform.State) (string, error) {
+					key := s.RootModule().Resources["mezmo_access_key.for_http"]
+					if key == nil {
+						return "", fmt.Errorf("resource \"mezmo_access_key.for_http\" not found")
+					}
+					return fmt.Sprintf("%s/%s", key.Primary.Attributes["source_id"], key.Primary.ID), nil
+				},
+				ImportStateVerify:       true,
+				ImportStateVerifyIgnore: []string{"key", "created_at"},
+			},
+			// Malformed import id
+			{
+				Config: GetCachedConfig(cacheKey) + `
+					resource "mezmo_access_key" "for_http" {
+						title = "http ingestion key"
+						source_id = mezmo_http_source.my_source.id
+					}`,
+				ImportState:   true,
+				ResourceName:  "mezmo_access_key.for_http",
+				ImportStateId: "no-slash",
+				ExpectError:   regexp.MustCompile(`(?s)Malformed Resource Import Id`),
+			},
+			// Invalid overlap
+			{
+				Config: GetCachedConfig(cacheKey) + `
+					resource "mezmo_access_key" "for_http" {
+						title = "http ingestion key"
+						source_id = mezmo_http_source.my_source.id
+						overlap = "one day"
+					}`,
+				ExpectError: regexp.MustCompile(`(?s)Attribute overlap must be a duration`),
+			},
+			// Changing the title without an overlap would cut off the current key
+			{
+				Config: GetCachedConfig(cacheKey) + `
+					resource "mezmo_access_key" "for_http" {
+						title = "renamed ingestion key"
+						source_id = mezmo_http_source.my_source.id
+					}`,
+				ExpectError: regexp.MustCompile(`(?s)Access Key Rotation Requires Overlap`),
+			},
+			// The same goes for the other rotation triggers
+			{
+				Config: GetCachedConfig(cacheKey) + `
+					resource "mezmo_access_key" "for_http" {
+						title = "http ingestion key"
 						source_id = mezmo_http_source.my_source.id
+						rotation_days = 30
 					}`,
-				ExpectError: regexp.MustCompile(
-					`(?s).*Cannot update mezmo_access_key after creation.*` +
-						`Access keys are currently imutable and cannot be updated`,
+				ExpectError: regexp.MustCompile(`(?s)Access Key Rotation Requires Overlap`),
+			},
+			// Changing the title rotates the key, and the replaced key keeps working during the overlap
+			{
+				Config: GetCachedConfig(cacheKey) + `
+					resource "mezmo_access_key" "for_http" {
+						title = "renamed ingestion key"
+						source_id = mezmo_http_source.my_source.id
+						overlap = "24h"
+						rotate_when_changed = {
+							version = "1"
+						}
+					}`,
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestMatchResourceAttr(
+						"mezmo_access_key.for_http", "previous_id", regexp.MustCompile(`[\w-]{36}`),
+					),
+					func(s *terraform.State) error {
+						rotatedId = s.RootModule().Resources["mezmo_access_key.for_http"].Primary.ID
+						return nil
+					},
+					StateHasExpectedValues("mezmo_access_key.for_http", map[string]any{
+						"title":   "renamed ingestion key",
+						"overlap": "24h",
+					}),
+				),
+			},
+			// Changing a value of `rotate_when_changed` rotates the key again
+			{
+				Config: GetCachedConfig(cacheKey) + `
+					resource "mezmo_access_key" "for_http" {
+						title = "renamed ingestion key"
+						source_id = mezmo_http_source.my_source.id
+						overlap = "24h"
+						rotation_days = 30
+						rotate_when_changed = {
+							version = "2"
+						}
+					}`,
+				Check: resource.ComposeTestCheckFunc(
+					func(s *terraform.State) error {
+						key := s.RootModule().Resources["mezmo_access_key.for_http"].Primary
+						if key.Attributes["previous_id"] != rotatedId {
+							return fmt.Errorf("expected previous_id %q, got %q", rotatedId, key.Attributes["previous_id"])
+						}
+						return nil
+					},
+					resource.TestCheckResourceAttrSet("mezmo_access_key.for_http", "previous_key"),
+					resource.TestCheckResourceAttrSet("mezmo_access_key.for_http", "previous_expires_at"),
+					StateHasExpectedValues("mezmo_access_key.for_http", map[string]any{
+						"overlap":                     "24h",
+						"rotation_days":               "30",
+						"rotate_when_changed.version": "2",
+					}),
 				),
 			},
 			// Delete testing automatically occurs in TestCase
@@ -0,0 +1,69 @@
+package provider
+
+import (
+	"testing"
+	"time"
+
+	"github.com/hashicorp/terraform-plugin-framework/attr"
+	"github.com/hashicorp/terraform-plugin-framework/types/basetypes"
+	. "github.com/mezmo/terraform-provider-mezmo/v5/internal/provider/models"
+	"github.com/stretchr/testify/assert"
+)
+
+func accessKeyModel(createdAt time.Time, rotationDays int64, keepers map[string]attr.Value) AccessKeyResourceModel {
+	model := AccessKeyResourceModel{
+		Title:             basetypes.NewStringValue("key"),
+		CreatedAt:         basetypes.NewStringValue(createdAt.Format(time.RFC3339)),
+		RotationDays:      basetypes.NewInt64Null(),
+		RotateWhenChanged: basetypes.NewMapNull(basetypes.StringType{}),
+		PreviousExpiresAt: basetypes.NewStringNull(),
+	}
+	if rotationDays > 0 {
+		model.RotationDays = basetypes.NewInt64Value(rotationDays)
+	}
+	if keepers != nil {
+		model.RotateWhenChanged = basetypes.NewMapValueMust(basetypes.StringType{}, keepers)
+	}
+	return model
+}
+
+func TestAccessKeyRotationDue(t *testing.T) {
+	now := time.Now()
+	v1 := map[string]attr.Value{"version": basetypes.NewStringValue("1")}
+	v2 := map[string]attr.Value{"version": basetypes.NewStringValue("2")}
+
+	state := accessKeyModel(now.AddDate(0, 0, -10), 0, v1)
+	plan := accessKeyModel(now, 0, v1)
+	assert.False(t, AccessKeyRotationDue(&state, &plan, now), "nothing changed")
+
+	plan = accessKeyModel(now, 0, v2)
+	assert.True(t, AccessKeyRotationDue(&state, &plan, now), "keepers changed")
+
+	unset := accessKeyModel(now, 0, nil)
+	assert.False(t, AccessKeyRotationDue(&unset, &plan, now), "keepers set for the first time")
+
+	plan = accessKeyModel(now, 0, v1)
+	plan.Title = basetypes.NewStringValue("renamed")
+	assert.True(t, AccessKeyRotationDue(&state, &plan, now), "title changed")
+
+	plan = accessKeyModel(now, 30, v1)
+	assert.False(t, AccessKeyRotationDue(&state, &plan, now), "key is not old enough")
+
+	plan = accessKeyModel(now, 10, v1)
+	assert.True(t, AccessKeyRotationDue(&state, &plan, now), "key is old enough")
+
+	state.CreatedAt = basetypes.NewStringNull()
+	assert.False(t, AccessKeyRotationDue(&state, &plan, now), "unknown age")
+}
+
+func TestAccessKeyPreviousExpired(t *testing.T) {
+	now := time.Now()
+	state := accessKeyModel(now, 0, nil)
+	assert.False(t, AccessKeyPreviousExpired(&state, now), "no previous key")
+
+	state.PreviousExpiresAt = basetypes.NewStringValue(now.Add(time.Hour).Format(time.RFC3339))
+	assert.False(t, AccessKeyPreviousExpired(&state, now), "overlap is not over")
+
+	state.PreviousExpiresAt = basetypes.NewStringValue(now.Add(-time.Hour).Format(time.RFC3339))
+	assert.True(t, AccessKeyPreviousExpired(&state, now), "overlap is over")
+}
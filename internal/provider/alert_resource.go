@@ -7,13 +7,16 @@ import (
 	"reflect"
 	"strings"
 
+	"github.com/hashicorp/terraform-plugin-framework/diag"
 	"github.com/hashicorp/terraform-plugin-framework/path"
 
 	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
 
 	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/types/basetypes"
 	"github.com/mezmo/terraform-provider-mezmo/v5/internal/client"
 	. "github.com/mezmo/terraform-provider-mezmo/v5/internal/client"
+	"github.com/mezmo/terraform-provider-mezmo/v5/internal/provider/models"
 	. "github.com/mezmo/terraform-provider-mezmo/v5/internal/provider/models/alerts"
 )
 
@@ -98,7 +101,7 @@ func (r *AlertResource[T]) Create(ctx context.Context, req resource.CreateReques
 
 	stored, err := r.client.CreateAlert(r.getPipelineIdFunc(&plan).ValueString(), component, ctx)
 	if err != nil {
-		resp.Diagnostics.AddError(
+		addApiErrorDiagnostics[T](&resp.Diagnostics, err, r.schema, component,
 			"Error creating alert",
 			"Could not create alert, unexpected error: "+err.Error(),
 		)
@@ -131,7 +134,7 @@ func (r *AlertResource[T]) Delete(ctx context.Context, req resource.DeleteReques
 
 	err := r.client.DeleteAlert(r.getPipelineIdFunc(&state).ValueString(), alert, ctx)
 	if err != nil {
-		resp.Diagnostics.AddError(
+		addClientErrorDiagnostic(&resp.Diagnostics, err,
 			"Error Deleting Alert",
 			"Could not delete alert, unexpected error: "+err.Error(),
 		)
@@ -159,7 +162,7 @@ func (r *AlertResource[T]) Read(ctx context.Context, req resource.ReadRequest, r
 		return
 	}
 	if err != nil {
-		resp.Diagnostics.AddError(
+		addClientErrorDiagnostic(&resp.Diagnostics, err,
 			"Error Reading Alert",
 			fmt.Sprintf("Could not read alert with id %s and pipeline_id %s: %s",
 				r.getIdFunc(&state), r.getPipelineIdFunc(&state), err.Error()),
@@ -191,9 +194,14 @@ func (r *AlertResource[T]) Update(ctx context.Context, req resource.UpdateReques
 		return
 	}
 
-	stored, err := r.client.UpdateAlert(r.getPipelineIdFunc(&state).ValueString(), component, ctx)
+	pipelineId := r.getPipelineIdFunc(&state).ValueString()
+	stored, err := r.client.UpdateAlert(pipelineId, component, ctx)
 	if err != nil {
-		resp.Diagnostics.AddError(
+		// Alerts have no generation to compare, so only conflicts reported by the API are detected
+		if client.IsConflictError(err) && r.reportConflict(ctx, pipelineId, &state, &resp.Diagnostics) {
+			return
+		}
+		addApiErrorDiagnostics[T](&resp.Diagnostics, err, r.schema, component,
 			"Error Updating Alert",
 			"Could not updated alert, unexpected error: "+err.Error(),
 		)
@@ -207,6 +215,53 @@ func (r *AlertResource[T]) Update(ctx context.Context, req resource.UpdateReques
 	resp.Diagnostics.Append(diags...)
 }
 
+// Reports an alert that the API could not update because it was modified outside of terraform
+func (r *AlertResource[T]) reportConflict(ctx context.Context, pipelineId string, state *T, diags *diag.Diagnostics) bool {
+	known, dd := r.fromModelFunc(state, state)
+	if dd.HasError() {
+		return false
+	}
+	remote, err := r.client.Alert(pipelineId, r.getIdFunc(state).ValueString(), ctx)
+	if err != nil {
+		return false
+	}
+	remoteConfig := remote.AlertConfig
+	remoteModel := *state
+	r.toModelFunc(&remoteModel, remote)
+	if normalized, dd := r.fromModelFunc(&remoteModel, state); !dd.HasError() {
+		remoteConfig = normalized.AlertConfig
+	}
+	addModifiedOutsideTerraformDiagnostic(diags, "alert", known.Id, known.AlertConfig, remoteConfig)
+	return true
+}
+
+// ValidateConfig implements resource.ResourceWithValidateConfig.
+func (r *AlertResource[T]) ValidateConfig(ctx context.Context, req resource.ValidateConfigRequest, resp *resource.ValidateConfigResponse) {
+	var fields ComponentKindFields
+	resp.Diagnostics.Append(req.Config.GetAttribute(ctx, path.Root("pipeline_id"), &fields.PipelineId)...)
+	resp.Diagnostics.Append(req.Config.GetAttribute(ctx, path.Root("component_kind"), &fields.ComponentKind)...)
+	resp.Diagnostics.Append(req.Config.GetAttribute(ctx, path.Root("component_id"), &fields.ComponentId)...)
+	resp.Diagnostics.Append(req.Config.GetAttribute(ctx, path.Root("event_type"), &fields.EventType)...)
+	resp.Diagnostics.Append(req.Config.GetAttribute(ctx, path.Root("inputs"), &fields.Inputs)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	ComponentKindErrorChecks(&fields, &resp.Diagnostics)
+}
+
+// ModifyPlan implements resource.ResourceWithModifyPlan.
+func (r *AlertResource[T]) ModifyPlan(ctx context.Context, req resource.ModifyPlanRequest, resp *resource.ModifyPlanResponse) {
+	kind := models.INPUT_CONSUMER_ALERT
+	if !req.Plan.Raw.IsNull() {
+		var componentKind basetypes.StringValue
+		resp.Diagnostics.Append(req.Plan.GetAttribute(ctx, path.Root("component_kind"), &componentKind)...)
+		if componentKind.ValueString() == COMPONENT_KIND_SINK {
+			kind = models.INPUT_CONSUMER_SINK_ALERT
+		}
+	}
+	validatePlannedInputs(ctx, r.client, kind, req, resp)
+}
+
 // Schema implements resource.Resource.
 func (r *AlertResource[T]) Schema(_ context.Context, _ resource.SchemaRequest, resp *resource.SchemaResponse) {
 	resp.Schema = r.schema
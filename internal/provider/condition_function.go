@@ -0,0 +1,60 @@
+package provider
+
+import (
+	"context"
+
+	"github.com/hashicorp/terraform-plugin-framework/function"
+	. "github.com/mezmo/terraform-provider-mezmo/v5/internal/provider/models/modelutils"
+)
+
+var _ function.Function = &ConditionFunction{}
+
+func NewConditionFunction() function.Function {
+	return &ConditionFunction{}
+}
+
+// Compiles a condition expression into the value of a `conditional` attribute, such as the one
+// of `mezmo_filter_processor`
+type ConditionFunction struct{}
+
+func (f *ConditionFunction) Metadata(_ context.Context, req function.MetadataRequest, resp *function.MetadataResponse) {
+	resp.Name = "condition"
+}
+
+func (f *ConditionFunction) Definition(_ context.Context, req function.DefinitionRequest, resp *function.DefinitionResponse) {
+	resp.Definition = function.Definition{
+		Summary: "Compiles a condition expression into a `conditional` value",
+		MarkdownDescription: "Parses a condition such as " +
+			"`status >= 500 && (service == \"api\" || negate(starts_with(path, \"/health\")))` into the " +
+			"nested `expressions` and `expressions_group` of a `conditional` attribute.\n\n" +
+			"Comparisons use `==`, `!=`, `>`, `>=`, `<`, `<=` and `=~` (regex match). Any other operator " +
+			"is called as a function with the field and, unless it only tests the field, a value. A " +
+			"comparison or function can be wrapped in `negate()`. Parentheses group expressions, and " +
+			"`&&` binds tighter than `||`.",
+		Parameters: []function.Parameter{
+			function.StringParameter{
+				Name:        "expression",
+				Description: "The condition to compile",
+			},
+		},
+		Return: function.ObjectReturn{
+			AttributeTypes: ToAttrTypes(ParentConditionalAttribute(Non_Change_Operator_Labels).Attributes),
+		},
+	}
+}
+
+func (f *ConditionFunction) Run(ctx context.Context, req function.RunRequest, resp *function.RunResponse) {
+	var expression string
+	resp.Error = function.ConcatFuncErrors(req.Arguments.Get(ctx, &expression))
+	if resp.Error != nil {
+		return
+	}
+
+	conditional, err := ParseConditionExpression(expression, Non_Change_Operator_Labels)
+	if err != nil {
+		resp.Error = function.NewArgumentFuncError(0, "Invalid condition expression: "+err.Error())
+		return
+	}
+	value := UnwindConditionalToModel(conditional, Non_Change_Operator_Labels)
+	resp.Error = function.ConcatFuncErrors(resp.Result.Set(ctx, value))
+}
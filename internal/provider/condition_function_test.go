@@ -0,0 +1,64 @@
+package provider
+
+import (
+	"regexp"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-testing/helper/resource"
+	"github.com/hashicorp/terraform-plugin-testing/tfversion"
+	. "github.com/mezmo/terraform-provider-mezmo/v5/internal/provider/providertest"
+)
+
+func TestConditionFunction(t *testing.T) {
+	config := func(expression string) string {
+		return GetProviderConfig() + `
+			resource "mezmo_pipeline" "conditions" {
+				title = "conditions"
+			}
+			resource "mezmo_http_source" "source" {
+				pipeline_id = mezmo_pipeline.conditions.id
+			}
+			resource "mezmo_filter_processor" "filter" {
+				pipeline_id = mezmo_pipeline.conditions.id
+				inputs      = [mezmo_http_source.source.id]
+				action      = "drop"
+				conditional = provider::mezmo::condition(` + expression + `)
+			}`
+	}
+
+	resource.Test(t, resource.TestCase{
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		TerraformVersionChecks: []tfversion.TerraformVersionCheck{
+			tfversion.SkipBelow(tfversion.Version1_8_0),
+		},
+		PreCheck: func() { TestPreCheck(t) },
+		Steps: []resource.TestStep{
+			// Operators of change alerts are not available to conditionals
+			{
+				Config:      config(`"value percent_change_greater 10"`),
+				ExpectError: regexp.MustCompile(`(?s)Invalid condition expression`),
+			},
+			{
+				Config:      config(`"status >= 500 &&"`),
+				ExpectError: regexp.MustCompile(`(?s)Invalid condition expression`),
+			},
+			// The groups follow the precedence of && and ||
+			{
+				Config: config(`"status >= 500 && (service == \"api\" || negate(starts_with(path, \"/health\")))"`),
+				Check: resource.ComposeTestCheckFunc(
+					StateHasExpectedValues("mezmo_filter_processor.filter", map[string]any{
+						"conditional.logical_operation":                              "AND",
+						"conditional.expressions_group.0.expressions.0.field":        "status",
+						"conditional.expressions_group.0.expressions.0.operator":     "greater_or_equal",
+						"conditional.expressions_group.0.expressions.0.value_number": "500",
+						"conditional.expressions_group.1.logical_operation":          "OR",
+						"conditional.expressions_group.1.expressions.0.field":        "service",
+						"conditional.expressions_group.1.expressions.0.value_string": "api",
+						"conditional.expressions_group.1.expressions.1.operator":     "starts_with",
+						"conditional.expressions_group.1.expressions.1.negate":       "true",
+					}),
+				),
+			},
+		},
+	})
+}
@@ -7,6 +7,7 @@ import (
 	"reflect"
 	"strings"
 
+	"github.com/hashicorp/terraform-plugin-framework/diag"
 	"github.com/hashicorp/terraform-plugin-framework/path"
 
 	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
@@ -14,6 +15,7 @@ import (
 	"github.com/hashicorp/terraform-plugin-framework/resource"
 	"github.com/mezmo/terraform-provider-mezmo/v5/internal/client"
 	. "github.com/mezmo/terraform-provider-mezmo/v5/internal/client"
+	"github.com/mezmo/terraform-provider-mezmo/v5/internal/provider/models"
 	. "github.com/mezmo/terraform-provider-mezmo/v5/internal/provider/models/destinations"
 )
 
@@ -76,7 +78,9 @@ func (r *DestinationResource[T]) ConvertToTerraformModel(component *reflect.Valu
 	}
 
 	var model T
-	r.toModelFunc(&model, &dest)
+	if diags := r.toModelFunc(&model, &dest); diags.HasError() {
+		return nil, errors.New(diagnosticsDetail(diags))
+	}
 	res := reflect.ValueOf(model)
 	return &res, nil
 }
@@ -116,7 +120,7 @@ func (r *DestinationResource[T]) Create(ctx context.Context, req resource.Create
 
 	stored, err := r.client.CreateDestination(r.getPipelineIdFunc(&plan).ValueString(), component, ctx)
 	if err != nil {
-		resp.Diagnostics.AddError(
+		addApiErrorDiagnostics[T](&resp.Diagnostics, err, r.schema, component,
 			"Error creating destination",
 			"Could not create destination, unexpected error: "+err.Error(),
 		)
@@ -125,7 +129,9 @@ func (r *DestinationResource[T]) Create(ctx context.Context, req resource.Create
 
 	NullifyPlanFields(&plan, r.schema)
 
-	r.toModelFunc(&plan, stored)
+	if diags := r.toModelFunc(&plan, stored); setDiagnosticsHasError(diags, &resp.Diagnostics) {
+		return
+	}
 	diags = resp.State.Set(ctx, plan)
 	resp.Diagnostics.Append(diags...)
 }
@@ -142,7 +148,7 @@ func (r *DestinationResource[T]) Delete(ctx context.Context, req resource.Delete
 	// Delete existing order
 	err := r.client.DeleteDestination(r.getPipelineIdFunc(&state).ValueString(), r.getIdFunc(&state).ValueString(), ctx)
 	if err != nil {
-		resp.Diagnostics.AddError(
+		addClientErrorDiagnostic(&resp.Diagnostics, err,
 			"Error deleting destination",
 			"Could not delete destination, unexpected error: "+err.Error(),
 		)
@@ -170,7 +176,7 @@ func (r *DestinationResource[T]) Read(ctx context.Context, req resource.ReadRequ
 		return
 	}
 	if err != nil {
-		resp.Diagnostics.AddError(
+		addClientErrorDiagnostic(&resp.Diagnostics, err,
 			"Error reading destination",
 			fmt.Sprintf("Could not read destination with id %s and pipeline_id %s: %s",
 				r.getIdFunc(&state), r.getPipelineIdFunc(&state), err.Error()),
@@ -180,7 +186,9 @@ func (r *DestinationResource[T]) Read(ctx context.Context, req resource.ReadRequ
 
 	NullifyPlanFields(&state, r.schema)
 
-	r.toModelFunc(&state, component)
+	if diags := r.toModelFunc(&state, component); setDiagnosticsHasError(diags, &resp.Diagnostics) {
+		return
+	}
 	diags := resp.State.Set(ctx, state)
 	resp.Diagnostics.Append(diags...)
 }
@@ -203,9 +211,13 @@ func (r *DestinationResource[T]) Update(ctx context.Context, req resource.Update
 	}
 
 	// Set id from the current state (not in plan)
-	stored, err := r.client.UpdateDestination(r.getPipelineIdFunc(&state).ValueString(), component, ctx)
+	pipelineId := r.getPipelineIdFunc(&state).ValueString()
+	stored, err := r.client.UpdateDestination(pipelineId, component, ctx)
 	if err != nil {
-		resp.Diagnostics.AddError(
+		if client.IsConflictError(err) && r.reportConflict(ctx, pipelineId, &state, &resp.Diagnostics) {
+			return
+		}
+		addApiErrorDiagnostics[T](&resp.Diagnostics, err, r.schema, component,
 			"Error updating destination",
 			"Could not update destination, unexpected error: "+err.Error(),
 		)
@@ -214,11 +226,28 @@ func (r *DestinationResource[T]) Update(ctx context.Context, req resource.Update
 
 	NullifyPlanFields(&plan, r.schema)
 
-	r.toModelFunc(&plan, stored)
+	if diags := r.toModelFunc(&plan, stored); setDiagnosticsHasError(diags, &resp.Diagnostics) {
+		return
+	}
 	diags := resp.State.Set(ctx, plan)
 	resp.Diagnostics.Append(diags...)
 }
 
+// Reports a destination that the API could not update because it was modified outside of terraform.
+// Errors reading it are left to the update to report.
+func (r *DestinationResource[T]) reportConflict(ctx context.Context, pipelineId string, state *T, diags *diag.Diagnostics) bool {
+	remote, err := r.client.Destination(pipelineId, r.getIdFunc(state).ValueString(), ctx)
+	if err != nil {
+		return false
+	}
+	return reportNodeConflict("destination", state, remote, r.fromModelFunc, r.toModelFunc, destinationNode, diags)
+}
+
+// ModifyPlan implements resource.ResourceWithModifyPlan.
+func (r *DestinationResource[T]) ModifyPlan(ctx context.Context, req resource.ModifyPlanRequest, resp *resource.ModifyPlanResponse) {
+	validatePlannedInputs(ctx, r.client, models.INPUT_CONSUMER_DESTINATION, req, resp)
+}
+
 // Schema implements resource.Resource.
 func (r *DestinationResource[T]) Schema(_ context.Context, _ resource.SchemaRequest, resp *resource.SchemaResponse) {
 	resp.Schema = r.schema
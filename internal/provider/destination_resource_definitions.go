@@ -12,7 +12,7 @@ func NewAzureBlobStorageDestinationResource() resource.Resource {
 		typeName:          AZURE_BLOB_STORAGE_DESTINATION_TYPE_NAME,
 		nodeName:          AZURE_BLOB_STORAGE_DESTINATION_NODE_NAME,
 		fromModelFunc:     AzureBlobStorageFromModel,
-		toModelFunc:       AzureBlobStorageToModel,
+		toModelFunc:       infallibleToModel(AzureBlobStorageToModel),
 		getIdFunc:         func(m *AzureBlobStorageDestinationModel) basetypes.StringValue { return m.Id },
 		getPipelineIdFunc: func(m *AzureBlobStorageDestinationModel) basetypes.StringValue { return m.PipelineId },
 		schema:            AzureBlobStorageResourceSchema,
@@ -60,7 +60,7 @@ func NewElasticSearchDestinationResource() resource.Resource {
 		typeName:          ELASTICSEARCH_DESTINATION_TYPE_NAME,
 		nodeName:          ELASTICSEARCH_DESTINATION_NODE_NAME,
 		fromModelFunc:     ElasticSearchDestinationFromModel,
-		toModelFunc:       ElasticSearchDestinationToModel,
+		toModelFunc:       infallibleToModel(ElasticSearchDestinationToModel),
 		getIdFunc:         func(m *ElasticSearchDestinationModel) basetypes.StringValue { return m.Id },
 		getPipelineIdFunc: func(m *ElasticSearchDestinationModel) basetypes.StringValue { return m.PipelineId },
 		schema:            ElasticSearchDestinationResourceSchema,
@@ -84,7 +84,7 @@ func NewHttpDestinationResource() resource.Resource {
 		typeName:          HTTP_DESTINATION_TYPE_NAME,
 		nodeName:          HTTP_DESTINATION_NODE_NAME,
 		fromModelFunc:     HttpDestinationFromModel,
-		toModelFunc:       HttpDestinationToModel,
+		toModelFunc:       infallibleToModel(HttpDestinationToModel),
 		getIdFunc:         func(m *HttpDestinationModel) basetypes.StringValue { return m.Id },
 		getPipelineIdFunc: func(m *HttpDestinationModel) basetypes.StringValue { return m.PipelineId },
 		schema:            HttpDestinationResourceSchema,
@@ -96,7 +96,7 @@ func NewKafkaDestinationResource() resource.Resource {
 		typeName:          KAFKA_DESTINATION_TYPE_NAME,
 		nodeName:          KAFKA_DESTINATION_NODE_NAME,
 		fromModelFunc:     KafkaDestinationFromModel,
-		toModelFunc:       KafkaDestinationToModel,
+		toModelFunc:       infallibleToModel(KafkaDestinationToModel),
 		getIdFunc:         func(m *KafkaDestinationModel) basetypes.StringValue { return m.Id },
 		getPipelineIdFunc: func(m *KafkaDestinationModel) basetypes.StringValue { return m.PipelineId },
 		schema:            KafkaDestinationResourceSchema,
@@ -108,7 +108,7 @@ func NewLokiDestinationResource() resource.Resource {
 		typeName:          LOKI_DESTINATION_TYPE_NAME,
 		nodeName:          LOKI_DESTINATION_NODE_NAME,
 		fromModelFunc:     LokiFromModel,
-		toModelFunc:       LokiDestinationToModel,
+		toModelFunc:       infallibleToModel(LokiDestinationToModel),
 		getIdFunc:         func(m *LokiDestinationModel) basetypes.StringValue { return m.Id },
 		getPipelineIdFunc: func(m *LokiDestinationModel) basetypes.StringValue { return m.PipelineId },
 		schema:            LokiDestinationResourceSchema,
@@ -120,7 +120,7 @@ func NewMezmoDestinationResource() resource.Resource {
 		typeName:          MEZMO_DESTINATION_TYPE_NAME,
 		nodeName:          MEZMO_DESTINATION_NODE_NAME,
 		fromModelFunc:     MezmoDestinationFromModel,
-		toModelFunc:       MezmoDestinationToModel,
+		toModelFunc:       infallibleToModel(MezmoDestinationToModel),
 		getIdFunc:         func(m *MezmoDestinationModel) basetypes.StringValue { return m.Id },
 		getPipelineIdFunc: func(m *MezmoDestinationModel) basetypes.StringValue { return m.PipelineId },
 		schema:            MezmoDestinationResourceSchema,
@@ -144,7 +144,7 @@ func NewPrometheusRemoteWriteDestinationResource() resource.Resource {
 		typeName:          PROMETHEUS_REMOTE_WRITE_DESTINATION_TYPE_NAME,
 		nodeName:          PROMETHEUS_REMOTE_WRITE_DESTINATION_NODE_NAME,
 		fromModelFunc:     PrometheusRemoteWriteDestinationFromModel,
-		toModelFunc:       PrometheusRemoteWriteDestinationToModel,
+		toModelFunc:       infallibleToModel(PrometheusRemoteWriteDestinationToModel),
 		getIdFunc:         func(m *PrometheusRemoteWriteDestinationModel) basetypes.StringValue { return m.Id },
 		getPipelineIdFunc: func(m *PrometheusRemoteWriteDestinationModel) basetypes.StringValue { return m.PipelineId },
 		schema:            PrometheusRemoteWriteDestinationResourceSchema,
@@ -156,7 +156,7 @@ func NewS3DestinationResource() resource.Resource {
 		typeName:          S3_DESTINATION_TYPE_NAME,
 		nodeName:          S3_DESTINATION_NODE_NAME,
 		fromModelFunc:     S3DestinationFromModel,
-		toModelFunc:       S3DestinationToModel,
+		toModelFunc:       infallibleToModel(S3DestinationToModel),
 		getIdFunc:         func(m *S3DestinationModel) basetypes.StringValue { return m.Id },
 		getPipelineIdFunc: func(m *S3DestinationModel) basetypes.StringValue { return m.PipelineId },
 		schema:            S3DestinationResourceSchema,
@@ -168,7 +168,7 @@ func NewSplunkHecLogsDestinationResource() resource.Resource {
 		typeName:          SPLUNK_HEC_LOGS_DESTINATION_TYPE_NAME,
 		nodeName:          SPLUNK_HEC_LOGS_DESTINATION_NODE_NAME,
 		fromModelFunc:     SplunkHecLogsDestinationFromModel,
-		toModelFunc:       SplunkHecLogsDestinationToModel,
+		toModelFunc:       infallibleToModel(SplunkHecLogsDestinationToModel),
 		getIdFunc:         func(m *SplunkHecLogsDestinationModel) basetypes.StringValue { return m.Id },
 		getPipelineIdFunc: func(m *SplunkHecLogsDestinationModel) basetypes.StringValue { return m.PipelineId },
 		schema:            SplunkHecLogsDestinationResourceSchema,
@@ -180,7 +180,7 @@ func NewGcpCloudStorageDestinationResource() resource.Resource {
 		typeName:          GCP_CLOUD_STORAGE_DESTINATION_TYPE_NAME,
 		nodeName:          GCP_CLOUD_STORAGE_DESTINATION_NODE_NAME,
 		fromModelFunc:     GcpCloudStorageDestinationFromModel,
-		toModelFunc:       GcpCloudStorageDestinationToModel,
+		toModelFunc:       infallibleToModel(GcpCloudStorageDestinationToModel),
 		getIdFunc:         func(m *GcpCloudStorageDestinationModel) basetypes.StringValue { return m.Id },
 		getPipelineIdFunc: func(m *GcpCloudStorageDestinationModel) basetypes.StringValue { return m.PipelineId },
 		schema:            GcpCloudStorageResourceSchema,
@@ -192,7 +192,7 @@ func NewGcpCloudMonitoringDestinationResource() resource.Resource {
 		typeName:          GCP_CLOUD_MONITORING_DESTINATION_TYPE_NAME,
 		nodeName:          GCP_CLOUD_MONITORING_DESTINATION_NODE_NAME,
 		fromModelFunc:     GcpCloudMonitoringDestinationFromModel,
-		toModelFunc:       GcpCloudMonitoringDestinationToModel,
+		toModelFunc:       infallibleToModel(GcpCloudMonitoringDestinationToModel),
 		getIdFunc:         func(m *GcpCloudMonitoringDestinationModel) basetypes.StringValue { return m.Id },
 		getPipelineIdFunc: func(m *GcpCloudMonitoringDestinationModel) basetypes.StringValue { return m.PipelineId },
 		schema:            GcpCloudMonitoringResourceSchema,
@@ -204,7 +204,7 @@ func NewGcpCloudOperationsDestinationResource() resource.Resource {
 		typeName:          GCP_CLOUD_OPERATIONS_DESTINATION_TYPE_NAME,
 		nodeName:          GCP_CLOUD_OPERATIONS_DESTINATION_NODE_NAME,
 		fromModelFunc:     GcpCloudOperationsDestinationFromModel,
-		toModelFunc:       GcpCloudOperationsDestinationToModel,
+		toModelFunc:       infallibleToModel(GcpCloudOperationsDestinationToModel),
 		getIdFunc:         func(m *GcpCloudOperationsDestinationModel) basetypes.StringValue { return m.Id },
 		getPipelineIdFunc: func(m *GcpCloudOperationsDestinationModel) basetypes.StringValue { return m.PipelineId },
 		schema:            GcpCloudOperationsResourceSchema,
@@ -216,7 +216,7 @@ func NewGcpCloudPubSubDestinationResource() resource.Resource {
 		typeName:          GCP_CLOUD_PUBSUB_DESTINATION_TYPE_NAME,
 		nodeName:          GCP_CLOUD_PUBSUB_DESTINATION_NODE_NAME,
 		fromModelFunc:     GcpCloudPubSubDestinationFromModel,
-		toModelFunc:       GcpCloudPubSubDestinationToModel,
+		toModelFunc:       infallibleToModel(GcpCloudPubSubDestinationToModel),
 		getIdFunc:         func(m *GcpCloudPubSubDestinationModel) basetypes.StringValue { return m.Id },
 		getPipelineIdFunc: func(m *GcpCloudPubSubDestinationModel) basetypes.StringValue { return m.PipelineId },
 		schema:            GcpCloudPubSubResourceSchema,
@@ -0,0 +1,209 @@
+package provider
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"reflect"
+	"strconv"
+	"strings"
+
+	"github.com/hashicorp/terraform-plugin-framework/attr"
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-go/tftypes"
+	"github.com/mezmo/terraform-provider-mezmo/v5/internal/client"
+)
+
+// Adds an error diagnostic for a failed API call. Most errors use the given summary and detail,
+// but timeouts are reported on their own so that users know the API did not answer, as opposed
+// to rejecting the request.
+func addClientErrorDiagnostic(diags *diag.Diagnostics, err error, summary string, detail string) {
+	var timeoutErr client.TimeoutError
+	if errors.As(err, &timeoutErr) {
+		diags.AddError(
+			"Mezmo API Request Timed Out",
+			fmt.Sprintf(
+				"%s: the request %s %s did not receive a response in time. The API may be "+
+					"temporarily unavailable; try again later, or increase `request_timeout` in the "+
+					"provider configuration.\n\nCause: %s",
+				summary, timeoutErr.Method, timeoutErr.URL, timeoutErr.Err,
+			),
+		)
+		return
+	}
+	diags.AddError(summary, detail)
+}
+
+// Adds the diagnostics of a create or update that the API rejected. Each validation error is
+// reported on the attribute that its `instancePath` refers to, so that users see it next to
+// the value in their configuration. Paths under `/user_config` refer to the fields of the model
+// `M` that are tagged with `user_config:"true"`. The converters of the models do not always send
+// an attribute as is, so a path is only trusted when the value that `request` sent there has the
+// shape of the attribute. When an error does not match an attribute, the whole error is also
+// reported with the given summary and detail.
+func addApiErrorDiagnostics[M any](diags *diag.Diagnostics, err error, s schema.Schema, request any, summary string, detail string) {
+	var apiErr client.ApiResponseError
+	if !errors.As(err, &apiErr) || len(apiErr.Errors) == 0 {
+		addClientErrorDiagnostic(diags, err, summary, detail)
+		return
+	}
+
+	var sent any
+	if body, err := json.Marshal(request); err == nil {
+		_ = json.Unmarshal(body, &sent)
+	}
+	userConfigFields := userConfigAttributeNames[M]()
+	matched, unmatched := 0, 0
+	for _, validationErr := range apiErr.Errors {
+		if client.SkipThisError(validationErr.Message) {
+			continue
+		}
+		attributePath, ok := attributePathFromInstancePath(validationErr.Path, s, userConfigFields, sent)
+		if !ok {
+			unmatched++
+			continue
+		}
+		matched++
+		diags.AddAttributeError(
+			attributePath,
+			summary,
+			fmt.Sprintf("The Mezmo API rejected the value at %s: %s", validationErr.Path, validationErr.Message),
+		)
+	}
+	if unmatched > 0 || matched == 0 {
+		diags.AddError(summary, detail)
+	}
+}
+
+// The names of the attributes of the model that are sent in the `user_config` of the component
+func userConfigAttributeNames[M any]() map[string]bool {
+	names := map[string]bool{}
+	modelType := reflect.TypeOf((*M)(nil)).Elem()
+	if modelType.Kind() != reflect.Struct {
+		return names
+	}
+	for i := 0; i < modelType.NumField(); i++ {
+		field := modelType.Field(i)
+		if field.Tag.Get("user_config") == "true" {
+			names[field.Tag.Get("tfsdk")] = true
+		}
+	}
+	return names
+}
+
+// Converts a json pointer of the API, e.g. `/user_config/brokers/0/port`, to the path of the
+// attribute in the schema. `sent` is the decoded body of the request. Returns false unless
+// every segment is an attribute, list index or map key of the schema, and the sent value at
+// each of them has the type of that attribute or element.
+func attributePathFromInstancePath(instancePath string, s schema.Schema, userConfigFields map[string]bool, sent any) (path.Path, bool) {
+	if instancePath == "" || !strings.HasPrefix(instancePath, "/") {
+		return path.Empty(), false
+	}
+	segments := strings.Split(instancePath[1:], "/")
+	for i, segment := range segments {
+		segments[i] = strings.NewReplacer("~1", "/", "~0", "~").Replace(segment)
+	}
+	if segments[0] == "user_config" {
+		var ok bool
+		if sent, ok = sentChild(sent, segments[0]); !ok {
+			return path.Empty(), false
+		}
+		segments = segments[1:]
+		if len(segments) == 0 || !userConfigFields[segments[0]] {
+			return path.Empty(), false
+		}
+	} else if userConfigFields[segments[0]] {
+		return path.Empty(), false
+	}
+
+	result := path.Empty()
+	attributes := s.Attributes
+	var attribute schema.Attribute
+	for _, segment := range segments {
+		var ok bool
+		if sent, ok = sentChild(sent, segment); !ok {
+			return path.Empty(), false
+		}
+		var valueType attr.Type
+		if attributes != nil {
+			next, ok := attributes[segment]
+			if !ok {
+				return path.Empty(), false
+			}
+			result = result.AtName(segment)
+			attribute, attributes, valueType = next, nil, next.GetType()
+			if nested, ok := next.(schema.SingleNestedAttribute); ok {
+				attributes = nested.Attributes
+			}
+		} else {
+			index, indexErr := strconv.Atoi(segment)
+			switch a := attribute.(type) {
+			case schema.ListNestedAttribute:
+				if indexErr != nil {
+					return path.Empty(), false
+				}
+				result = result.AtListIndex(index)
+				attribute, attributes, valueType = nil, a.NestedObject.Attributes, a.NestedObject.Type()
+			case schema.ListAttribute:
+				if indexErr != nil {
+					return path.Empty(), false
+				}
+				result = result.AtListIndex(index)
+				attribute, valueType = nil, a.ElementType
+			case schema.MapNestedAttribute:
+				result = result.AtMapKey(segment)
+				attribute, attributes, valueType = nil, a.NestedObject.Attributes, a.NestedObject.Type()
+			case schema.MapAttribute:
+				result = result.AtMapKey(segment)
+				attribute, valueType = nil, a.ElementType
+			default:
+				// Elements of sets cannot be addressed, and other attributes have no children
+				return path.Empty(), false
+			}
+		}
+		if !sentValueHasType(sent, valueType) {
+			return path.Empty(), false
+		}
+	}
+	return result, !result.Equal(path.Empty())
+}
+
+// The value of a decoded json object or array at the given key or index
+func sentChild(sent any, segment string) (any, bool) {
+	switch value := sent.(type) {
+	case map[string]any:
+		child, ok := value[segment]
+		return child, ok
+	case []any:
+		index, err := strconv.Atoi(segment)
+		if err != nil || index < 0 || index >= len(value) {
+			return nil, false
+		}
+		return value[index], true
+	}
+	return nil, false
+}
+
+// Whether a decoded json value can be the value of a terraform type. Null matches any type.
+func sentValueHasType(sent any, t attr.Type) bool {
+	if sent == nil {
+		return true
+	}
+	tfType := t.TerraformType(context.Background())
+	switch sent.(type) {
+	case string:
+		return tfType.Is(tftypes.String)
+	case float64:
+		return tfType.Is(tftypes.Number)
+	case bool:
+		return tfType.Is(tftypes.Bool)
+	case []any:
+		return tfType.Is(tftypes.List{}) || tfType.Is(tftypes.Set{}) || tfType.Is(tftypes.Tuple{})
+	case map[string]any:
+		return tfType.Is(tftypes.Map{}) || tfType.Is(tftypes.Object{})
+	}
+	return false
+}
@@ -0,0 +1,137 @@
+package provider
+
+import (
+	"encoding/json"
+	"errors"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/mezmo/terraform-provider-mezmo/v5/internal/client"
+	"github.com/mezmo/terraform-provider-mezmo/v5/internal/provider/models/alerts"
+	. "github.com/mezmo/terraform-provider-mezmo/v5/internal/provider/models/destinations"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestAttributePathFromInstancePath(t *testing.T) {
+	kafkaFields := userConfigAttributeNames[KafkaDestinationModel]()
+	httpFields := userConfigAttributeNames[HttpDestinationModel]()
+	assert.True(t, kafkaFields["brokers"])
+	assert.False(t, kafkaFields["title"])
+
+	decode := func(body string) any {
+		var sent any
+		assert.NoError(t, json.Unmarshal([]byte(body), &sent))
+		return sent
+	}
+	kafkaSent := decode(`{"title": "kafka", "user_config": {
+		"brokers": [{"host": "a", "port": 9092}, {"host": "b", "port": 99999}],
+		"sasl": {"username": "user", "password": "secret"}
+	}}`)
+	httpSent := decode(`{"title": "http", "inputs": ["a", "b", "c"], "user_config": {
+		"uri": "https://example.org",
+		"auth": {"strategy": "basic", "user": "user", "password": "secret"},
+		"headers": [{"header_name": "x-api/key", "header_value": "value"}],
+		"advanced_options": {"tls_protocols": ["h2"]}
+	}}`)
+
+	cases := []struct {
+		instancePath string
+		schema       map[string]bool
+		expected     path.Path
+		ok           bool
+	}{
+		{"/user_config/brokers/0/port", kafkaFields, path.Root("brokers").AtListIndex(0).AtName("port"), true},
+		{"/user_config/brokers/1", kafkaFields, path.Root("brokers").AtListIndex(1), true},
+		{"/user_config/sasl/username", kafkaFields, path.Root("sasl").AtName("username"), true},
+		{"/user_config/auth/password", httpFields, path.Root("auth").AtName("password"), true},
+		{"/user_config/auth", httpFields, path.Root("auth"), true},
+		{"/title", httpFields, path.Root("title"), true},
+		{"/inputs/2", httpFields, path.Root("inputs").AtListIndex(2), true},
+		// Unmatched paths
+		{"", httpFields, path.Empty(), false},
+		{"raw error body", httpFields, path.Empty(), false},
+		{"/user_config", httpFields, path.Empty(), false},
+		{"/user_config/unknown", httpFields, path.Empty(), false},
+		{"/user_config/title", httpFields, path.Empty(), false},
+		{"/uri", httpFields, path.Empty(), false},
+		// Deeper than the schema
+		{"/user_config/auth/unknown/0", httpFields, path.Empty(), false},
+		{"/user_config/brokers/2", kafkaFields, path.Empty(), false},
+		// Attributes that the converter does not send as is: the headers are sent as a list
+		// and the protocols in the advanced options
+		{"/user_config/headers/0", httpFields, path.Empty(), false},
+		{"/user_config/headers/0/header_name", httpFields, path.Empty(), false},
+		{"/user_config/advanced_options/tls_protocols/0", httpFields, path.Empty(), false},
+	}
+	for _, c := range cases {
+		s, sent := HttpDestinationResourceSchema, httpSent
+		if c.schema["brokers"] {
+			s, sent = KafkaDestinationResourceSchema, kafkaSent
+		}
+		result, ok := attributePathFromInstancePath(c.instancePath, s, c.schema, sent)
+		assert.Equal(t, c.ok, ok, c.instancePath)
+		assert.Equal(t, c.expected, result, c.instancePath)
+	}
+}
+
+func TestAddApiErrorDiagnostics(t *testing.T) {
+	apiError := func(body string) error {
+		var err client.ApiResponseError
+		assert.NoError(t, json.Unmarshal([]byte(body), &err))
+		return err
+	}
+
+	t.Run("reports errors on attributes", func(t *testing.T) {
+		var diags diag.Diagnostics
+		err := apiError(`{"status": 400, "code": "EVALIDATION", "errors": [
+			{"instancePath": "/user_config/brokers/0/port", "message": "must be <= 65535"},
+			{"instancePath": "/user_config/topic", "message": "must match a schema in anyOf"}
+		]}`)
+		request := client.Destination{BaseNode: client.BaseNode{UserConfig: map[string]any{
+			"brokers": []map[string]any{{"host": "kafka", "port": 99999}},
+		}}}
+		addApiErrorDiagnostics[KafkaDestinationModel](&diags, err, KafkaDestinationResourceSchema, request,
+			"Error creating destination", "Could not create destination, unexpected error: "+err.Error())
+		assert.Len(t, diags, 1)
+		withPath, ok := diags[0].(diag.DiagnosticWithPath)
+		assert.True(t, ok)
+		assert.Equal(t, path.Root("brokers").AtListIndex(0).AtName("port"), withPath.Path())
+		assert.Equal(t, "Error creating destination", diags[0].Summary())
+		assert.Equal(t, "The Mezmo API rejected the value at /user_config/brokers/0/port: must be <= 65535", diags[0].Detail())
+	})
+
+	t.Run("falls back to a general error", func(t *testing.T) {
+		var diags diag.Diagnostics
+		err := apiError(`{"status": 400, "code": "EVALIDATION", "errors": [
+			{"instancePath": "/user_config/uri", "message": "must match format \"uri\""},
+			{"instancePath": "", "message": "must have required property 'topic'"}
+		]}`)
+		request := client.Destination{BaseNode: client.BaseNode{UserConfig: map[string]any{"uri": "not a uri"}}}
+		addApiErrorDiagnostics[HttpDestinationModel](&diags, err, HttpDestinationResourceSchema, request, "Error", "detail")
+		assert.Len(t, diags, 2)
+		assert.Equal(t, path.Root("uri"), diags[0].(diag.DiagnosticWithPath).Path())
+		assert.Equal(t, "detail", diags[1].Detail())
+		_, ok := diags[1].(diag.DiagnosticWithPath)
+		assert.False(t, ok)
+
+		diags = diag.Diagnostics{}
+		addApiErrorDiagnostics[HttpDestinationModel](&diags, errors.New("connection refused"), HttpDestinationResourceSchema, request, "Error", "detail")
+		assert.Equal(t, diag.Diagnostics{diag.NewErrorDiagnostic("Error", "detail")}, diags)
+	})
+	t.Run("reports errors of alerts", func(t *testing.T) {
+		var diags diag.Diagnostics
+		err := apiError(`{"status": 400, "code": "EVALIDATION", "errors": [
+			{"instancePath": "/inputs/0", "message": "must be a component of the pipeline"},
+			{"instancePath": "/alert_config/general/name", "message": "must NOT have more than 512 characters"}
+		]}`)
+		request := client.Alert{
+			Inputs:      []string{"unknown"},
+			AlertConfig: map[string]any{"general": map[string]any{"name": "alert"}},
+		}
+		addApiErrorDiagnostics[alerts.AbsenceAlertModel](&diags, err, alerts.AbsenceAlertResourceSchema, request, "Error", "detail")
+		assert.Len(t, diags, 2)
+		assert.Equal(t, path.Root("inputs").AtListIndex(0), diags[0].(diag.DiagnosticWithPath).Path())
+		assert.Equal(t, "detail", diags[1].Detail())
+	})
+}
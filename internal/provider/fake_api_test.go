@@ -0,0 +1,77 @@
+package provider
+
+import (
+	"regexp"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-testing/helper/resource"
+	"github.com/mezmo/terraform-provider-mezmo/v5/internal/client/clienttest"
+	. "github.com/mezmo/terraform-provider-mezmo/v5/internal/provider/providertest"
+)
+
+func TestFakeApiComponents(t *testing.T) {
+	server := clienttest.NewServer(t)
+	server.AddValidator(clienttest.RejectUserConfig(clienttest.KIND_SINK, "/user_config/uri", "must match format \"uri\""))
+
+	resource.UnitTest(t, resource.TestCase{
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		PreCheck:                 func() { UnitTestPreCheck(t) },
+		Steps: []resource.TestStep{
+			// Create and Read testing
+			{
+				Config: server.ProviderConfig() + `
+					resource "mezmo_pipeline" "fake" {
+						title = "fake pipeline"
+					}
+					resource "mezmo_http_source" "source" {
+						pipeline_id = mezmo_pipeline.fake.id
+					}
+					resource "mezmo_drop_fields_processor" "drop" {
+						pipeline_id = mezmo_pipeline.fake.id
+						inputs      = [mezmo_http_source.source.id]
+						fields      = [".secret"]
+					}`,
+				Check: resource.ComposeTestCheckFunc(
+					StateHasExpectedValues("mezmo_drop_fields_processor.drop", map[string]any{
+						"inputs.0":      "#mezmo_http_source.source.id",
+						"fields.0":      ".secret",
+						"generation_id": "1",
+					}),
+				),
+			},
+			// Update testing
+			{
+				Config: server.ProviderConfig() + `
+					resource "mezmo_pipeline" "fake" {
+						title = "fake pipeline"
+					}
+					resource "mezmo_http_source" "source" {
+						pipeline_id = mezmo_pipeline.fake.id
+					}
+					resource "mezmo_drop_fields_processor" "drop" {
+						pipeline_id = mezmo_pipeline.fake.id
+						inputs      = [mezmo_http_source.source.id]
+						fields      = [".secret", ".password"]
+					}`,
+				Check: resource.ComposeTestCheckFunc(
+					StateHasExpectedValues("mezmo_drop_fields_processor.drop", map[string]any{
+						"fields.1":      ".password",
+						"generation_id": "2",
+					}),
+				),
+			},
+			// Validation errors of the service are reported
+			{
+				Config: server.ProviderConfig() + `
+					resource "mezmo_pipeline" "fake" {
+						title = "fake pipeline"
+					}
+					resource "mezmo_http_destination" "sink" {
+						pipeline_id = mezmo_pipeline.fake.id
+						uri         = "https://example.org"
+					}`,
+				ExpectError: regexp.MustCompile(`must match format`),
+			},
+		},
+	})
+}
@@ -0,0 +1,66 @@
+package provider
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/mezmo/terraform-provider-mezmo/v5/internal/client"
+	"github.com/mezmo/terraform-provider-mezmo/v5/internal/provider/models/modelutils"
+)
+
+// Reported instead of updating a component that was changed outside of terraform, e.g. in the
+// UI, so that those changes are not silently overwritten. `known` is the configuration in state
+// and `remote` the one in the API.
+func addModifiedOutsideTerraformDiagnostic(diags *diag.Diagnostics, kind string, id string, known map[string]any, remote map[string]any) {
+	detail := fmt.Sprintf(
+		"The %s %s was modified outside of Terraform since it was last refreshed, so it was not "+
+			"updated. Run `terraform plan` to review the remote changes against the configuration, "+
+			"then apply again.",
+		kind, id,
+	)
+	if changes := modelutils.ConfigDiff(known, remote); len(changes) > 0 {
+		detail += "\n\nRemote changes to the configuration:\n" + strings.Join(changes, "\n")
+	}
+	diags.AddError("Component Modified Outside Terraform Since Last Refresh", detail)
+}
+
+// Reports a source, processor or destination whose update the API rejected because its
+// `generation_id` changed since the state was refreshed, with the remote configuration changes
+func reportNodeConflict[T ComponentModel, C any](
+	kind string,
+	state *T,
+	remote *C,
+	fromModel func(*T, *T) (*C, diag.Diagnostics),
+	toModel func(*T, *C) diag.Diagnostics,
+	node func(*C) *client.BaseNode,
+	diags *diag.Diagnostics,
+) bool {
+	known, dd := fromModel(state, state)
+	if dd.HasError() {
+		return false
+	}
+
+	// Going through the model gives both configurations the same shape, e.g. with defaults
+	remoteConfig := node(remote).UserConfig
+	remoteModel := *state
+	if dd := toModel(&remoteModel, remote); !dd.HasError() {
+		if normalized, dd := fromModel(&remoteModel, state); !dd.HasError() {
+			remoteConfig = node(normalized).UserConfig
+		}
+	}
+	addModifiedOutsideTerraformDiagnostic(diags, kind, node(known).Id, node(known).UserConfig, remoteConfig)
+	return true
+}
+
+func sourceNode(c *client.Source) *client.BaseNode {
+	return &c.BaseNode
+}
+
+func processorNode(c *client.Processor) *client.BaseNode {
+	return &c.BaseNode
+}
+
+func destinationNode(c *client.Destination) *client.BaseNode {
+	return &c.BaseNode
+}
@@ -0,0 +1,50 @@
+package provider
+
+import (
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-framework/attr"
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/types/basetypes"
+	"github.com/mezmo/terraform-provider-mezmo/v5/internal/client"
+	. "github.com/mezmo/terraform-provider-mezmo/v5/internal/provider/models/processors"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestReportNodeConflict(t *testing.T) {
+	state := DropFieldsProcessorModel{
+		Id:           basetypes.NewStringValue("processor-id"),
+		PipelineId:   basetypes.NewStringValue("pipeline-id"),
+		Title:        basetypes.NewStringValue("drop"),
+		Description:  basetypes.NewStringNull(),
+		Inputs:       basetypes.NewListValueMust(basetypes.StringType{}, []attr.Value{}),
+		GenerationId: basetypes.NewInt64Value(2),
+		Fields: basetypes.NewListValueMust(basetypes.StringType{}, []attr.Value{
+			basetypes.NewStringValue(".secret"),
+		}),
+	}
+	remote := &client.Processor{BaseNode: client.BaseNode{
+		Id:           "processor-id",
+		Title:        "drop",
+		UserConfig:   map[string]any{"fields": []any{".secret"}},
+		GenerationId: 2,
+	}}
+
+	var diags diag.Diagnostics
+	conflict := reportNodeConflict("processor", &state, remote,
+		DropFieldsProcessorFromModel, DropFieldsProcessorToModel, processorNode, &diags)
+	assert.True(t, conflict)
+	assert.Len(t, diags, 1)
+	assert.Equal(t, "Component Modified Outside Terraform Since Last Refresh", diags[0].Summary())
+	assert.NotContains(t, diags[0].Detail(), "Remote changes to the configuration")
+
+	remote.GenerationId = 3
+	remote.UserConfig = map[string]any{"fields": []any{".secret", ".token"}}
+	diags = nil
+	conflict = reportNodeConflict("processor", &state, remote,
+		DropFieldsProcessorFromModel, DropFieldsProcessorToModel, processorNode, &diags)
+	assert.True(t, conflict)
+	assert.Len(t, diags, 1)
+	assert.Contains(t, diags[0].Detail(), "The processor processor-id was modified outside of Terraform")
+	assert.Contains(t, diags[0].Detail(), `  ~ fields: [".secret"] -> [".secret",".token"]`)
+}
@@ -0,0 +1,121 @@
+package provider
+
+import (
+	"context"
+	"sync"
+
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/types/basetypes"
+	"github.com/mezmo/terraform-provider-mezmo/v5/internal/client"
+	. "github.com/mezmo/terraform-provider-mezmo/v5/internal/provider/models"
+)
+
+// Checks the planned `inputs` of a component against the other components of its pipeline,
+// so that mistakes are reported by `plan` instead of failing halfway through an apply. Inputs
+// that are not known yet, e.g. ids of components created by the same apply, are skipped.
+func validatePlannedInputs(
+	ctx context.Context, c client.Client, kind InputConsumerKind,
+	req resource.ModifyPlanRequest, resp *resource.ModifyPlanResponse,
+) {
+	if c == nil || req.Plan.Raw.IsNull() {
+		return
+	}
+	var pipelineId, id basetypes.StringValue
+	var inputs basetypes.ListValue
+	resp.Diagnostics.Append(req.Plan.GetAttribute(ctx, path.Root("pipeline_id"), &pipelineId)...)
+	resp.Diagnostics.Append(req.Plan.GetAttribute(ctx, path.Root("id"), &id)...)
+	resp.Diagnostics.Append(req.Plan.GetAttribute(ctx, path.Root("inputs"), &inputs)...)
+	if resp.Diagnostics.HasError() || pipelineId.IsUnknown() || inputs.IsNull() || inputs.IsUnknown() {
+		return
+	}
+
+	// Only look at the pipeline when the inputs change
+	if !req.State.Raw.IsNull() {
+		var stateInputs basetypes.ListValue
+		resp.Diagnostics.Append(req.State.GetAttribute(ctx, path.Root("inputs"), &stateInputs)...)
+		if resp.Diagnostics.HasError() || stateInputs.Equal(inputs) {
+			return
+		}
+	}
+
+	knownInputs := []string{}
+	for _, input := range inputs.Elements() {
+		if value, ok := input.(basetypes.StringValue); ok && !value.IsUnknown() && !value.IsNull() {
+			knownInputs = append(knownInputs, value.ValueString())
+		}
+	}
+	if len(knownInputs) == 0 {
+		return
+	}
+
+	graph, cached, err := plannedPipelineGraph(ctx, c, pipelineId.ValueString())
+	if err != nil {
+		// The API reports the error when applying, if there still is one
+		resp.Diagnostics.AddAttributeWarning(
+			path.Root("inputs"),
+			"Could Not Validate Inputs",
+			"Could not read pipeline "+pipelineId.ValueString()+" to validate the inputs: "+err.Error(),
+		)
+		return
+	}
+
+	componentId := ""
+	if !id.IsUnknown() && !id.IsNull() {
+		componentId = id.ValueString()
+	}
+	messages := PipelineInputErrors(graph, kind, componentId, knownInputs)
+	// Components created or changed since the pipeline was read could make the inputs valid
+	if len(messages) > 0 && cached {
+		forgetPlannedPipelineGraph(c, pipelineId.ValueString())
+		if graph, _, err = plannedPipelineGraph(ctx, c, pipelineId.ValueString()); err != nil {
+			return
+		}
+		messages = PipelineInputErrors(graph, kind, componentId, knownInputs)
+	}
+	for _, message := range messages {
+		resp.Diagnostics.AddAttributeError(path.Root("inputs"), "Invalid Input", message)
+	}
+}
+
+// The client that the provider hands to its resources. Every component of a pipeline is planned
+// separately, so the pipelines read to validate inputs are kept until the provider is configured
+// again, which Terraform does for every plan and apply.
+type plannedClient struct {
+	client.Client
+	pipelineGraphs sync.Map
+}
+
+func newPlannedClient(c client.Client) *plannedClient {
+	return &plannedClient{Client: c}
+}
+
+type plannedPipelineGraphEntry struct {
+	once  sync.Once
+	graph *client.PipelineGraph
+	err   error
+}
+
+// Reads a pipeline, or returns the one read earlier with `cached` set. Clients that were not
+// configured by the provider always read it.
+func plannedPipelineGraph(ctx context.Context, c client.Client, pipelineId string) (graph *client.PipelineGraph, cached bool, err error) {
+	planned, ok := c.(*plannedClient)
+	if !ok {
+		graph, err = c.PipelineGraph(pipelineId, ctx)
+		return graph, false, err
+	}
+	value, _ := planned.pipelineGraphs.LoadOrStore(pipelineId, &plannedPipelineGraphEntry{})
+	entry := value.(*plannedPipelineGraphEntry)
+	cached = true
+	entry.once.Do(func() {
+		cached = false
+		entry.graph, entry.err = planned.PipelineGraph(pipelineId, ctx)
+	})
+	return entry.graph, cached, entry.err
+}
+
+func forgetPlannedPipelineGraph(c client.Client, pipelineId string) {
+	if planned, ok := c.(*plannedClient); ok {
+		planned.pipelineGraphs.Delete(pipelineId)
+	}
+}
@@ -0,0 +1,160 @@
+package provider
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"regexp"
+	"sync/atomic"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-testing/helper/resource"
+	"github.com/mezmo/terraform-provider-mezmo/v5/internal/client"
+	"github.com/mezmo/terraform-provider-mezmo/v5/internal/client/clienttest"
+	. "github.com/mezmo/terraform-provider-mezmo/v5/internal/provider/models"
+	. "github.com/mezmo/terraform-provider-mezmo/v5/internal/provider/providertest"
+	"github.com/stretchr/testify/assert"
+)
+
+func processor(id string, inputs []string, outputs ...string) client.Processor {
+	p := client.Processor{BaseNode: client.BaseNode{Id: id, Inputs: inputs}}
+	for _, output := range outputs {
+		p.Outputs = append(p.Outputs, struct {
+			Id    string `json:"id"`
+			Label string `json:"label"`
+		}{Id: output})
+	}
+	return p
+}
+
+func inputsGraph() *client.PipelineGraph {
+	return &client.PipelineGraph{
+		Pipeline: client.Pipeline{Id: "pid"},
+		Sources:  []client.Source{{BaseNode: client.BaseNode{Id: "src"}}},
+		Processors: []client.Processor{
+			processor("route", []string{"src"}, "route.errors", "route._unmatched"),
+			processor("a", []string{"src"}),
+			processor("b", []string{"a"}),
+			processor("c", []string{"b"}),
+		},
+		Destinations: []client.Destination{{BaseNode: client.BaseNode{Id: "sink", Inputs: []string{"c"}}}},
+	}
+}
+
+func TestPipelineInputErrors(t *testing.T) {
+	graph := inputsGraph()
+
+	assert.Empty(t, PipelineInputErrors(graph, INPUT_CONSUMER_PROCESSOR, "", []string{"src", "route.errors", "c"}))
+	assert.Empty(t, PipelineInputErrors(graph, INPUT_CONSUMER_DESTINATION, "sink", []string{"route._unmatched"}))
+	assert.Empty(t, PipelineInputErrors(graph, INPUT_CONSUMER_PROCESSOR, "b", []string{"src"}))
+
+	assert.Equal(t,
+		[]string{`Input "typo" is not a component of pipeline pid.`},
+		PipelineInputErrors(graph, INPUT_CONSUMER_DESTINATION, "", []string{"typo"}),
+	)
+	assert.Equal(t,
+		[]string{`Input "route" is a processor with several outputs. Use one of them instead: route._unmatched, route.errors.`},
+		PipelineInputErrors(graph, INPUT_CONSUMER_PROCESSOR, "", []string{"route"}),
+	)
+	assert.Equal(t,
+		[]string{`Input "sink" is a destination, which has no outputs.`},
+		PipelineInputErrors(graph, INPUT_CONSUMER_PROCESSOR, "", []string{"sink"}),
+	)
+	assert.Equal(t,
+		[]string{`Input "route._unmatched" is the unmatched output of a processor, which cannot be used by an alert.`},
+		PipelineInputErrors(graph, INPUT_CONSUMER_ALERT, "", []string{"route._unmatched"}),
+	)
+	assert.Empty(t, PipelineInputErrors(graph, INPUT_CONSUMER_SINK_ALERT, "", []string{"sink"}))
+	assert.Equal(t,
+		[]string{`Input "a" is an output of this component.`},
+		PipelineInputErrors(graph, INPUT_CONSUMER_PROCESSOR, "a", []string{"a"}),
+	)
+	assert.Equal(t,
+		[]string{`Inputs create a cycle: a -> b -> c -> a.`},
+		PipelineInputErrors(graph, INPUT_CONSUMER_PROCESSOR, "a", []string{"c"}),
+	)
+}
+
+func TestPlannedPipelineGraph(t *testing.T) {
+	fake := clienttest.NewUnstartedServer()
+	var requests atomic.Int32
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		requests.Add(1)
+		fake.ServeHTTP(w, r)
+	}))
+	t.Cleanup(server.Close)
+	c := client.NewClient(server.URL, "", nil)
+	ctx := context.Background()
+	pipeline, err := c.CreatePipeline(&client.Pipeline{Title: "planned"}, ctx)
+	assert.NoError(t, err)
+
+	planned := newPlannedClient(c)
+	requests.Store(0)
+	graph, cached, err := plannedPipelineGraph(ctx, planned, pipeline.Id)
+	assert.NoError(t, err)
+	assert.False(t, cached)
+	assert.Equal(t, pipeline.Id, graph.Pipeline.Id)
+	read := requests.Load()
+	assert.NotZero(t, read)
+
+	// The other components of the pipeline use the same graph
+	_, cached, err = plannedPipelineGraph(ctx, planned, pipeline.Id)
+	assert.NoError(t, err)
+	assert.True(t, cached)
+	assert.Equal(t, read, requests.Load())
+
+	forgetPlannedPipelineGraph(planned, pipeline.Id)
+	_, cached, _ = plannedPipelineGraph(ctx, planned, pipeline.Id)
+	assert.False(t, cached)
+
+	// A pipeline edited between two plans is read again, as the provider is configured for each one
+	_, err = c.CreateSource(pipeline.Id, &client.Source{BaseNode: client.BaseNode{
+		Type:       "http",
+		UserConfig: map[string]any{"decoding": "json"},
+	}}, ctx)
+	assert.NoError(t, err)
+	graph, cached, err = plannedPipelineGraph(ctx, newPlannedClient(c), pipeline.Id)
+	assert.NoError(t, err)
+	assert.False(t, cached)
+	assert.Len(t, graph.Sources, 1)
+
+	// Clients that were not configured by the provider do not keep the pipelines
+	_, cached, _ = plannedPipelineGraph(ctx, c, pipeline.Id)
+	assert.False(t, cached)
+	_, cached, _ = plannedPipelineGraph(ctx, c, pipeline.Id)
+	assert.False(t, cached)
+}
+
+func TestPlannedInputsValidation(t *testing.T) {
+	resource.Test(t, resource.TestCase{
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		PreCheck:                 func() { TestPreCheck(t) },
+		Steps: []resource.TestStep{
+			{
+				Config: GetProviderConfig() + `
+					resource "mezmo_pipeline" "inputs" {
+						title = "input validation"
+					}
+					resource "mezmo_http_source" "source" {
+						pipeline_id = mezmo_pipeline.inputs.id
+					}`,
+			},
+			// Inputs that are known at plan time are checked against the pipeline
+			{
+				Config: GetProviderConfig() + `
+					resource "mezmo_pipeline" "inputs" {
+						title = "input validation"
+					}
+					resource "mezmo_http_source" "source" {
+						pipeline_id = mezmo_pipeline.inputs.id
+					}
+					resource "mezmo_drop_fields_processor" "processor" {
+						pipeline_id = mezmo_pipeline.inputs.id
+						inputs      = ["not-a-component"]
+						fields      = [".secret"]
+					}`,
+				ExpectError: regexp.MustCompile(`(?s)Invalid Input.*Input "not-a-component" is not a component of\s+pipeline`),
+			},
+		},
+	})
+}
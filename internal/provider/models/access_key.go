@@ -1,9 +1,15 @@
 package models
 
 import (
+	"regexp"
+	"time"
+
+	"github.com/hashicorp/terraform-plugin-framework-validators/int64validator"
 	"github.com/hashicorp/terraform-plugin-framework-validators/stringvalidator"
+	ephemeralSchema "github.com/hashicorp/terraform-plugin-framework/ephemeral/schema"
 	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
 	"github.com/hashicorp/terraform-plugin-framework/resource/schema/planmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringdefault"
 	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringplanmodifier"
 	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
 	. "github.com/hashicorp/terraform-plugin-framework/types/basetypes"
@@ -11,14 +17,32 @@ import (
 )
 
 type AccessKeyResourceModel struct {
+	Id                StringValue `tfsdk:"id"`
+	Title             StringValue `tfsdk:"title" user_config:"true"`
+	SourceId          StringValue `tfsdk:"source_id" user_config:"true"`
+	Key               StringValue `tfsdk:"key" user_config:"true"`
+	CreatedAt         StringValue `tfsdk:"created_at"`
+	RotationDays      Int64Value  `tfsdk:"rotation_days"`
+	RotateWhenChanged MapValue    `tfsdk:"rotate_when_changed"`
+	Overlap           StringValue `tfsdk:"overlap"`
+	PreviousId        StringValue `tfsdk:"previous_id"`
+	PreviousKey       StringValue `tfsdk:"previous_key"`
+	PreviousExpiresAt StringValue `tfsdk:"previous_expires_at"`
+}
+
+// A key that only exists while terraform runs. It is never written to the state or plan.
+type AccessKeyEphemeralResourceModel struct {
 	Id       StringValue `tfsdk:"id"`
-	Title    StringValue `tfsdk:"title" user_config:"true"`
-	SourceId StringValue `tfsdk:"source_id" user_config:"true"`
-	Key      StringValue `tfsdk:"key" user_config:"true"`
+	Title    StringValue `tfsdk:"title"`
+	SourceId StringValue `tfsdk:"source_id"`
+	Key      StringValue `tfsdk:"key"`
 }
 
 const AccessKeyType = "generated"
 
+// Accepts the durations understood by time.ParseDuration, e.g. "36h" or "1h30m"
+var durationRegex = regexp.MustCompile(`^([0-9]+(\.[0-9]+)?(ns|us|µs|ms|s|m|h))+$`)
+
 func AccessKeyResourceSchema() schema.Schema {
 	return schema.Schema{
 		Attributes: map[string]schema.Attribute{
@@ -27,8 +51,9 @@ func AccessKeyResourceSchema() schema.Schema {
 				Computed:    true,
 			},
 			"title": schema.StringAttribute{
-				Description: "A descriptive title for the key and/or its use.",
-				Required:    true,
+				Description: "A descriptive title for the key and/or its use. Keys cannot be renamed, so changing " +
+					"the title rotates the key. This requires a nonzero `overlap`.",
+				Required: true,
 				Validators: []validator.String{
 					stringvalidator.LengthAtLeast(1),
 					stringvalidator.LengthAtMost(512),
@@ -50,6 +75,83 @@ func AccessKeyResourceSchema() schema.Schema {
 				Sensitive: true,
 				Computed:  true,
 			},
+			"created_at": schema.StringAttribute{
+				Description: "The time the current key was created. For imported keys, the time of the import, " +
+					"and for keys created by older versions of the provider, the time of the first refresh.",
+				Computed: true,
+			},
+			"rotation_days": schema.Int64Attribute{
+				Description: "Rotate the key once it is older than this number of days. " +
+					"The rotation happens on the first apply after the key expires.",
+				Optional: true,
+				Validators: []validator.Int64{
+					int64validator.AtLeast(1),
+				},
+			},
+			"rotate_when_changed": schema.MapAttribute{
+				Description: "Arbitrary values that rotate the key whenever they change. " +
+					"Changing the `title` also rotates the key. Setting them for the first time does not.",
+				ElementType: StringType{},
+				Optional:    true,
+			},
+			"overlap": schema.StringAttribute{
+				Description: "How long the previous key keeps working after a rotation, as a duration such as " +
+					"`\"72h\"`. The previous key is deleted on the first apply after the overlap ends. " +
+					"Defaults to `\"0s\"`, which rules out rotations: changing the `title` and setting " +
+					"`rotation_days` or `rotate_when_changed` require a nonzero overlap.",
+				Optional: true,
+				Computed: true,
+				Default:  stringdefault.StaticString("0s"),
+				Validators: []validator.String{
+					stringvalidator.RegexMatches(durationRegex, "must be a duration such as \"72h\" or \"1h30m\""),
+				},
+			},
+			"previous_id": schema.StringAttribute{
+				Description: "The id of the key that was replaced by the last rotation, while it is still valid.",
+				Computed:    true,
+			},
+			"previous_key": schema.StringAttribute{
+				Description: "The cleartext key that was replaced by the last rotation, while it is still valid.",
+				Sensitive:   true,
+				Computed:    true,
+			},
+			"previous_expires_at": schema.StringAttribute{
+				Description: "The time after which the previous key will be deleted.",
+				Computed:    true,
+			},
+		},
+	}
+}
+
+func AccessKeyEphemeralResourceSchema() ephemeralSchema.Schema {
+	return ephemeralSchema.Schema{
+		Description: "Creates an access key for the duration of a terraform run, and deletes it " +
+			"afterwards. The key is never stored in the state or plan.",
+		Attributes: map[string]ephemeralSchema.Attribute{
+			"id": ephemeralSchema.StringAttribute{
+				Description: "The id of the access key",
+				Computed:    true,
+			},
+			"title": ephemeralSchema.StringAttribute{
+				Description: "A descriptive title for the key and/or its use.",
+				Required:    true,
+				Validators: []validator.String{
+					stringvalidator.LengthAtLeast(1),
+					stringvalidator.LengthAtMost(512),
+				},
+			},
+			"source_id": ephemeralSchema.StringAttribute{
+				Description: "The uuid of the source (shared or not) for which the access key is created.",
+				Required:    true,
+				Validators: []validator.String{
+					stringvalidator.LengthAtLeast(1),
+				},
+			},
+			"key": ephemeralSchema.StringAttribute{
+				Description: "The cleartext key used for pipeline ingestion of the `source_id`.",
+				Sensitive:   true,
+				Computed:    true,
+			},
 		},
 	}
 }
@@ -68,6 +170,14 @@ func AccessKeyFromModel(plan *AccessKeyResourceModel) *AccessKey {
 	return &accessKey
 }
 
+func AccessKeyFromEphemeralModel(model *AccessKeyEphemeralResourceModel) *AccessKey {
+	return &AccessKey{
+		Title:          model.Title.ValueString(),
+		SharedSourceId: model.SourceId.ValueString(),
+		Type:           AccessKeyType,
+	}
+}
+
 // From an API response to a terraform model
 func AccessKeyToModel(plan *AccessKeyResourceModel, accessKey *AccessKey) {
 	plan.Id = NewStringValue(accessKey.Id)
@@ -75,3 +185,38 @@ func AccessKeyToModel(plan *AccessKeyResourceModel, accessKey *AccessKey) {
 	plan.SourceId = NewStringValue(accessKey.SharedSourceId)
 	plan.Key = NewStringValue(accessKey.Key)
 }
+
+// Whether the current key has to be replaced, based on the prior state and the new plan
+func AccessKeyRotationDue(state *AccessKeyResourceModel, plan *AccessKeyResourceModel, now time.Time) bool {
+	if !plan.Title.Equal(state.Title) {
+		return true
+	}
+	// Keepers added for the first time, e.g. after an import, only record their values
+	if !state.RotateWhenChanged.IsNull() && !plan.RotateWhenChanged.Equal(state.RotateWhenChanged) {
+		return true
+	}
+	if plan.RotationDays.IsNull() || plan.RotationDays.IsUnknown() || state.CreatedAt.IsNull() {
+		return false
+	}
+	createdAt, err := time.Parse(time.RFC3339, state.CreatedAt.ValueString())
+	if err != nil {
+		return false
+	}
+	return !now.Before(createdAt.AddDate(0, 0, int(plan.RotationDays.ValueInt64())))
+}
+
+// Whether the overlap of the previous key is over
+func AccessKeyPreviousExpired(state *AccessKeyResourceModel, now time.Time) bool {
+	if state.PreviousExpiresAt.IsNull() {
+		return false
+	}
+	expiresAt, err := time.Parse(time.RFC3339, state.PreviousExpiresAt.ValueString())
+	return err != nil || !now.Before(expiresAt)
+}
+
+// The previous key's fields, cleared once it is deleted
+func ClearPreviousAccessKey(model *AccessKeyResourceModel) {
+	model.PreviousId = NewStringNull()
+	model.PreviousKey = NewStringNull()
+	model.PreviousExpiresAt = NewStringNull()
+}
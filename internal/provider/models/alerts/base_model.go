@@ -6,13 +6,17 @@ import (
 	"github.com/hashicorp/terraform-plugin-framework-validators/int64validator"
 	"github.com/hashicorp/terraform-plugin-framework-validators/listvalidator"
 	"github.com/hashicorp/terraform-plugin-framework-validators/mapvalidator"
+	"github.com/hashicorp/terraform-plugin-framework-validators/objectvalidator"
 	"github.com/hashicorp/terraform-plugin-framework-validators/stringvalidator"
 	"github.com/hashicorp/terraform-plugin-framework/attr"
 	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/path"
 	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
 	"github.com/hashicorp/terraform-plugin-framework/resource/schema/booldefault"
 	"github.com/hashicorp/terraform-plugin-framework/resource/schema/int64default"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/planmodifier"
 	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringdefault"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringplanmodifier"
 	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
 	"github.com/hashicorp/terraform-plugin-framework/types"
 	. "github.com/hashicorp/terraform-plugin-framework/types/basetypes"
@@ -21,6 +25,109 @@ import (
 
 type SchemaAttributes map[string]schema.Attribute
 
+// The options of the service receiving an alert, shared by alerts and notification channels
+var alertServiceAttributes = map[string]schema.Attribute{
+	"name": schema.StringAttribute{
+		Required:    true,
+		Description: "The name of the service.",
+		Validators: []validator.String{
+			stringvalidator.OneOf("slack", "pager_duty", "webhook", "log_analysis"),
+		},
+	},
+	"uri": schema.StringAttribute{
+		Optional:    true,
+		Description: "The URI of the service (Slack, PagerDuty, Webhook).",
+	},
+	"message_text": schema.StringAttribute{
+		Optional: true,
+		Description: "The text value of the notification message (Slack, Webhook). " +
+			"When using a Webhook, this value may be a text string or " +
+			"stringified JSON. If the Webhook's message can be parsed as JSON, it will be " +
+			"sent as such.",
+	},
+	"summary": schema.StringAttribute{
+		Optional:    true,
+		Description: "Summarize the alert details (PagerDuty).",
+	},
+	"source": schema.StringAttribute{
+		Optional:    true,
+		Description: "The source of the alert (PagerDuty).",
+	},
+	"routing_key": schema.StringAttribute{
+		Optional:    true,
+		Sensitive:   true,
+		Description: "The service's routing key (PagerDuty).",
+	},
+	"event_action": schema.StringAttribute{
+		Optional:    true,
+		Description: "The event action to use (PagerDuty).",
+	},
+	"severity": schema.StringAttribute{
+		Optional:    true,
+		Description: "The severity level of the alert (PagerDuty, Log Analysis).",
+		Validators: []validator.String{
+			stringvalidator.OneOf("INFO", "WARNING", "ERROR", "CRITICAL"),
+		},
+	},
+	"subject": schema.StringAttribute{
+		Optional:    true,
+		Description: "The main subject line of the message (Log Analysis).",
+	},
+	"body": schema.StringAttribute{
+		Optional:    true,
+		Description: "Additional information to be added to the message (Log Analysis).",
+	},
+	"ingestion_key": schema.StringAttribute{
+		Optional:    true,
+		Sensitive:   true,
+		Description: "The ingestion key for the service (Log Analysis).",
+	},
+	"auth": schema.SingleNestedAttribute{
+		Optional:    true,
+		Description: "Configures HTTP authentication (Webhook).",
+		Attributes: map[string]schema.Attribute{
+			"strategy": schema.StringAttribute{
+				Required:    true,
+				Description: "Choose basic or token-based authentication.",
+				Validators:  []validator.String{stringvalidator.OneOf("basic", "bearer")},
+			},
+			"user": schema.StringAttribute{
+				Optional:    true,
+				Description: "The basic authentication user.",
+				Validators:  []validator.String{stringvalidator.LengthAtLeast(1)},
+			},
+			"password": schema.StringAttribute{
+				Sensitive:   true,
+				Optional:    true,
+				Description: "The basic authentication password.",
+				Validators:  []validator.String{stringvalidator.LengthAtLeast(1)},
+			},
+			"token": schema.StringAttribute{
+				Sensitive:   true,
+				Optional:    true,
+				Description: "The bearer token.",
+				Validators:  []validator.String{stringvalidator.LengthAtLeast(1)},
+			},
+		},
+	},
+	"headers": schema.MapAttribute{
+		Optional:    true,
+		Description: "Optional key/val request headers (Webhook).",
+		ElementType: StringType{},
+		Validators: []validator.Map{
+			mapvalidator.All(
+				mapvalidator.KeysAre(stringvalidator.LengthAtLeast(1)),
+				mapvalidator.ValueStringsAre(stringvalidator.LengthAtLeast(1)),
+			),
+		},
+	},
+	"method": schema.StringAttribute{
+		Optional:    true,
+		Description: "The HTTP method to use for the destination (Webhook, default is `post`).",
+		Validators:  []validator.String{stringvalidator.OneOf("post", "put", "patch", "delete", "get", "head", "options", "trace")},
+	},
+}
+
 var baseAlertSchemaAttributes = SchemaAttributes{
 	// Non-config fields
 	"id": schema.StringAttribute{
@@ -37,27 +144,35 @@ var baseAlertSchemaAttributes = SchemaAttributes{
 	"component_kind": schema.StringAttribute{
 		Required: true,
 		Validators: []validator.String{
-			stringvalidator.OneOf("source", "transform"),
+			stringvalidator.OneOf(Component_Kinds...),
 		},
-		Description: "The kind of component that the alert is attached to",
+		Description: "The kind of component that the alert is attached to: `source`, `transform` " +
+			"(a processor), `sink` (a destination), or `pipeline` for alerts on the health of the " +
+			"whole pipeline. Pipeline alerts only support the `metric` event type.",
 	},
 	"component_id": schema.StringAttribute{
-		Required: true,
+		Optional: true,
+		Computed: true,
 		Validators: []validator.String{
 			stringvalidator.LengthAtLeast(1),
 		},
-		Description: "The uuid of the component that the alert is attached to",
+		PlanModifiers: []planmodifier.String{
+			stringplanmodifier.UseStateForUnknown(),
+		},
+		Description: "The uuid of the component that the alert is attached to. Required unless " +
+			"`component_kind` is `pipeline`, in which case it is the `pipeline_id`.",
 	},
 	"inputs": schema.ListAttribute{
 		ElementType: types.StringType,
-		Required:    true,
+		Optional:    true,
 		Validators: []validator.List{
 			listvalidator.ValueStringsAre(stringvalidator.LengthAtLeast(1)),
 			listvalidator.SizeAtLeast(1),
 			listvalidator.UniqueValues(),
 		},
 		Description: "The ids of the input components. This could be the id of a match arm " +
-			"for a route processor, or simply the id of the component.",
+			"for a route processor, or simply the id of the component. Required unless " +
+			"`component_kind` is `pipeline`.",
 	},
 	"active": schema.BoolAttribute{
 		Optional:    true,
@@ -136,114 +251,27 @@ var baseAlertSchemaAttributes = SchemaAttributes{
 	"alert_payload": schema.SingleNestedAttribute{
 		Required: true,
 		MarkdownDescription: "Configure where the alert will be sent, including choosing a service " +
-			"and throttling options. All options for the chosen `service` will be required. " +
+			"or a notification channel, and throttling options. All options for the chosen `service` will be required. " +
 			"All text fields support templating, e.g. `{{.my_field}}` as long as those values " +
 			"can be coerced to a string. For more information, see " +
 			"[our documentation](https://docs.mezmo.com/telemetry-pipelines/syntax-for-editing-pipeline-component-configuration-values#templates).",
 		Attributes: map[string]schema.Attribute{
 			"service": schema.SingleNestedAttribute{
-				Required:    true,
-				Description: "Configuration for the service receiving the alert.",
-				Attributes: map[string]schema.Attribute{
-					"name": schema.StringAttribute{
-						Required:    true,
-						Description: "The name of the service.",
-						Validators: []validator.String{
-							stringvalidator.OneOf("slack", "pager_duty", "webhook", "log_analysis"),
-						},
-					},
-					"uri": schema.StringAttribute{
-						Optional:    true,
-						Description: "The URI of the service (Slack, PagerDuty, Webhook).",
-					},
-					"message_text": schema.StringAttribute{
-						Optional: true,
-						Description: "The text value of the notification message (Slack, Webhook). " +
-							"When using a Webhook, this value may be a text string or " +
-							"stringified JSON. If the Webhook's message can be parsed as JSON, it will be " +
-							"sent as such.",
-					},
-					"summary": schema.StringAttribute{
-						Optional:    true,
-						Description: "Summarize the alert details (PagerDuty).",
-					},
-					"source": schema.StringAttribute{
-						Optional:    true,
-						Description: "The source of the alert (PagerDuty).",
-					},
-					"routing_key": schema.StringAttribute{
-						Optional:    true,
-						Sensitive:   true,
-						Description: "The service's routing key (PagerDuty).",
-					},
-					"event_action": schema.StringAttribute{
-						Optional:    true,
-						Description: "The event action to use (PagerDuty).",
-					},
-					"severity": schema.StringAttribute{
-						Optional:    true,
-						Description: "The severity level of the alert (PagerDuty, Log Analysis).",
-						Validators: []validator.String{
-							stringvalidator.OneOf("INFO", "WARNING", "ERROR", "CRITICAL"),
-						},
-					},
-					"subject": schema.StringAttribute{
-						Optional:    true,
-						Description: "The main subject line of the message (Log Analysis).",
-					},
-					"body": schema.StringAttribute{
-						Optional:    true,
-						Description: "Additional information to be added to the message (Log Analysis).",
-					},
-					"ingestion_key": schema.StringAttribute{
-						Optional:    true,
-						Sensitive:   true,
-						Description: "The ingestion key for the service (Log Analysis).",
-					},
-					"auth": schema.SingleNestedAttribute{
-						Optional:    true,
-						Description: "Configures HTTP authentication (Webhook).",
-						Attributes: map[string]schema.Attribute{
-							"strategy": schema.StringAttribute{
-								Required:    true,
-								Description: "Choose basic or token-based authentication.",
-								Validators:  []validator.String{stringvalidator.OneOf("basic", "bearer")},
-							},
-							"user": schema.StringAttribute{
-								Optional:    true,
-								Description: "The basic authentication user.",
-								Validators:  []validator.String{stringvalidator.LengthAtLeast(1)},
-							},
-							"password": schema.StringAttribute{
-								Sensitive:   true,
-								Optional:    true,
-								Description: "The basic authentication password.",
-								Validators:  []validator.String{stringvalidator.LengthAtLeast(1)},
-							},
-							"token": schema.StringAttribute{
-								Sensitive:   true,
-								Optional:    true,
-								Description: "The bearer token.",
-								Validators:  []validator.String{stringvalidator.LengthAtLeast(1)},
-							},
-						},
-					},
-					"headers": schema.MapAttribute{
-						Optional:    true,
-						Description: "Optional key/val request headers (Webhook).",
-						ElementType: StringType{},
-						Validators: []validator.Map{
-							mapvalidator.All(
-								mapvalidator.KeysAre(stringvalidator.LengthAtLeast(1)),
-								mapvalidator.ValueStringsAre(stringvalidator.LengthAtLeast(1)),
-							),
-						},
-					},
-					"method": schema.StringAttribute{
-						Optional:    true,
-						Description: "The HTTP method to use for the destination (Webhook, default is `post`).",
-						Validators:  []validator.String{stringvalidator.OneOf("post", "put", "patch", "delete", "get", "head", "options", "trace")},
-					},
+				Optional: true,
+				Description: "Configuration for the service receiving the alert. " +
+					"Either `service` or `channel_id` must be set.",
+				Attributes: alertServiceAttributes,
+				Validators: []validator.Object{
+					objectvalidator.ExactlyOneOf(path.MatchRelative().AtParent().AtName("channel_id")),
+				},
+			},
+			"channel_id": schema.StringAttribute{
+				Optional: true,
+				Description: "The id of a `mezmo_notification_channel` to send the alert to, instead of " +
+					"configuring the `service` on every alert. Changes made to the channel apply to all " +
+					"alerts that reference it.",
+				Validators: []validator.String{
+					stringvalidator.LengthAtLeast(1),
 				},
 			},
 			"throttling": schema.SingleNestedAttribute{
@@ -283,34 +311,47 @@ type CheckedFields struct {
 	GroupBy        ListValue
 }
 
-// Convert the api response for `alert_payload` into a Terraform model
-func GetAlertPayloadToModel(component map[string]any) ObjectValue {
-	// All properties need to be defined regardless of service name. Initialize with null values.
-	serviceTypes := map[string]attr.Type{
-		"name":          StringType{},
-		"uri":           StringType{},
-		"message_text":  StringType{},
-		"summary":       StringType{},
-		"source":        StringType{},
-		"routing_key":   StringType{},
-		"event_action":  StringType{},
-		"severity":      StringType{},
-		"subject":       StringType{},
-		"body":          StringType{},
-		"ingestion_key": StringType{},
-		"auth": ObjectType{
-			AttrTypes: map[string]attr.Type{
-				"strategy": StringType{},
-				"user":     StringType{},
-				"password": StringType{},
-				"token":    StringType{},
-			},
-		},
-		"headers": MapType{
-			ElemType: StringType{},
+// All properties need to be defined regardless of service name
+var alertServiceAttrTypes = map[string]attr.Type{
+	"name":          StringType{},
+	"uri":           StringType{},
+	"message_text":  StringType{},
+	"summary":       StringType{},
+	"source":        StringType{},
+	"routing_key":   StringType{},
+	"event_action":  StringType{},
+	"severity":      StringType{},
+	"subject":       StringType{},
+	"body":          StringType{},
+	"ingestion_key": StringType{},
+	"auth": ObjectType{
+		AttrTypes: map[string]attr.Type{
+			"strategy": StringType{},
+			"user":     StringType{},
+			"password": StringType{},
+			"token":    StringType{},
 		},
-		"method": StringType{},
-	}
+	},
+	"headers": MapType{
+		ElemType: StringType{},
+	},
+	"method": StringType{},
+}
+
+var alertThrottlingAttrTypes = map[string]attr.Type{
+	"window_secs": Int64Type{},
+	"threshold":   Int64Type{},
+}
+
+var alertPayloadAttrTypes = map[string]attr.Type{
+	"service":    ObjectType{AttrTypes: alertServiceAttrTypes},
+	"channel_id": StringType{},
+	"throttling": ObjectType{AttrTypes: alertThrottlingAttrTypes},
+}
+
+// Convert the api response for a `service` into a Terraform model
+func AlertServiceToModel(component map[string]any) ObjectValue {
+	// Initialize with null values, then fill in the ones the service uses
 	serviceValues := map[string]attr.Value{
 		"name":          NewStringNull(),
 		"uri":           NewStringNull(),
@@ -323,21 +364,16 @@ func GetAlertPayloadToModel(component map[string]any) ObjectValue {
 		"subject":       NewStringNull(),
 		"body":          NewStringNull(),
 		"ingestion_key": NewStringNull(),
-		"auth":          NewObjectNull(serviceTypes["auth"].(ObjectType).AttributeTypes()),
+		"auth":          NewObjectNull(alertServiceAttrTypes["auth"].(ObjectType).AttributeTypes()),
 		"headers":       NewMapNull(StringType{}),
 		"method":        NewStringNull(),
 	}
-	throttlingTypes := map[string]attr.Type{
-		"window_secs": Int64Type{},
-		"threshold":   Int64Type{},
-	}
-	throttlingValues := map[string]attr.Value{}
-	for key, value := range component["service"].(map[string]any) {
+	for key, value := range component {
 		switch key {
 		case "auth":
 			auth, _ := value.(map[string]any)
 			if auth["strategy"] != "none" {
-				authTypes := serviceTypes["auth"].(ObjectType).AttributeTypes()
+				authTypes := alertServiceAttrTypes["auth"].(ObjectType).AttributeTypes()
 				authValues := MapAnyFillMissingValues(authTypes, auth, MapKeys(authTypes))
 				serviceValues[key] = NewObjectValueMust(authTypes, authValues)
 			}
@@ -351,39 +387,44 @@ func GetAlertPayloadToModel(component map[string]any) ObjectValue {
 					value := obj["header_value"].(string)
 					headerMap[key] = value
 				}
-				serviceValues["headers"] = NewMapValueMust(serviceTypes["headers"].(MapType).ElementType(), MapAnyToMapValues(headerMap))
+				serviceValues["headers"] = NewMapValueMust(alertServiceAttrTypes["headers"].(MapType).ElementType(), MapAnyToMapValues(headerMap))
 			}
 		default:
 			serviceValues[key] = NewStringValue(value.(string))
 		}
 	}
+	return NewObjectValueMust(alertServiceAttrTypes, serviceValues)
+}
+
+// Convert the api response for `alert_payload` into a Terraform model. The api may return the
+// service of a referenced notification channel, but the alert keeps the reference instead.
+func GetAlertPayloadToModel(component map[string]any) ObjectValue {
+	service := NewObjectNull(alertServiceAttrTypes)
+	channelId := NewStringNull()
+	if id, _ := component["channel_id"].(string); id != "" {
+		channelId = NewStringValue(id)
+	} else {
+		service = AlertServiceToModel(component["service"].(map[string]any))
+	}
+
+	throttlingValues := map[string]attr.Value{}
 	for key, value := range component["throttling"].(map[string]any) {
 		// All throttling values are float64/Int64
 		throttlingValues[key] = NewInt64Value(int64(value.(float64)))
 	}
 
-	alertPayloadAttrs := NewObjectValueMust(map[string]attr.Type{
-		"service":    ObjectType{AttrTypes: serviceTypes},
-		"throttling": ObjectType{AttrTypes: throttlingTypes},
-	}, map[string]attr.Value{
-		"service":    NewObjectValueMust(serviceTypes, serviceValues),
-		"throttling": NewObjectValueMust(throttlingTypes, throttlingValues),
+	return NewObjectValueMust(alertPayloadAttrTypes, map[string]attr.Value{
+		"service":    service,
+		"channel_id": channelId,
+		"throttling": NewObjectValueMust(alertThrottlingAttrTypes, throttlingValues),
 	})
-
-	return alertPayloadAttrs
 }
 
-// Assemble the `alert_payload` object to be sent to the service, including
-// error checking. The payload must match the required structure for the chosen service,
-// i.e. there can be no extra fields for options that the service `name` doesn't expect.
-func GetAlertPayloadFromModel(v attr.Value, dd *diag.Diagnostics) map[string]any {
-	value, ok := v.(ObjectValue)
-	if !ok {
-		panic(fmt.Errorf("Expected an object but did not receive one: %+v", v))
-	}
-	attrs := value.Attributes()
-
-	serviceValues := attrs["service"].(ObjectValue).Attributes()
+// Assemble a `service` object to be sent to the service, including error checking.
+// The payload must match the required structure for the chosen service, i.e. there
+// can be no extra fields for options that the service `name` doesn't expect.
+func AlertServiceFromModel(value ObjectValue, dd *diag.Diagnostics) map[string]any {
+	serviceValues := value.Attributes()
 	serviceName := serviceValues["name"].(StringValue).ValueString()
 	service := map[string]any{
 		"name": serviceName,
@@ -569,10 +610,34 @@ func GetAlertPayloadFromModel(v attr.Value, dd *diag.Diagnostics) map[string]any
 		service["ingestion_key"] = ingestionKey.ValueString()
 	}
 
-	// Create the full payload, including `service` and `throttling` (if provided).
+	return service
+}
+
+// Assemble the `alert_payload` object to be sent to the service, including
+// error checking. An alert either references a notification channel by its id, or
+// configures its own `service`.
+func GetAlertPayloadFromModel(v attr.Value, dd *diag.Diagnostics) map[string]any {
+	value, ok := v.(ObjectValue)
+	if !ok {
+		panic(fmt.Errorf("Expected an object but did not receive one: %+v", v))
+	}
+	attrs := value.Attributes()
+
+	// Create the full payload, including `service` or `channel_id`, and `throttling` (if provided).
 	// `null` values cannot be sent, so we must be surgical about the object's structure.
-	alertPayload := map[string]any{
-		"service": service,
+	alertPayload := map[string]any{}
+	channelId, _ := attrs["channel_id"].(StringValue)
+	service, _ := attrs["service"].(ObjectValue)
+	switch {
+	case !channelId.IsNull():
+		alertPayload["channel_id"] = channelId.ValueString()
+	case !service.IsNull():
+		alertPayload["service"] = AlertServiceFromModel(service, dd)
+	default:
+		dd.AddError(
+			"Error in plan",
+			"Either `service` or `channel_id` is required in `alert_payload`",
+		)
 	}
 
 	if throttlingObj, ok := attrs["throttling"]; ok && !throttlingObj.IsNull() {
@@ -2,7 +2,6 @@ package alerts
 
 import (
 	"github.com/hashicorp/terraform-plugin-framework-validators/stringvalidator"
-	"github.com/hashicorp/terraform-plugin-framework/attr"
 	"github.com/hashicorp/terraform-plugin-framework/diag"
 	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
 	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
@@ -82,7 +81,7 @@ func ChangeAlertFromModel(plan *ChangeAlertModel, previousState *ChangeAlertMode
 		return nil, dd
 	}
 
-	// Inputs are required, so we can assemble them first
+	// Pipeline alerts have no inputs
 	inputs := make([]string, 0)
 	for _, v := range plan.Inputs.Elements() {
 		value, _ := v.(StringValue)
@@ -92,7 +91,7 @@ func ChangeAlertFromModel(plan *ChangeAlertModel, previousState *ChangeAlertMode
 	component := Alert{
 		PipelineId:    plan.PipelineId.ValueString(),
 		ComponentKind: plan.ComponentKind.ValueString(),
-		ComponentId:   plan.ComponentId.ValueString(),
+		ComponentId:   AlertComponentIdFromModel(plan.ComponentKind, plan.ComponentId, plan.PipelineId),
 		Inputs:        inputs,
 		AlertConfig: map[string]any{
 			"general": map[string]any{
@@ -151,13 +150,7 @@ func ChangeAlertToModel(plan *ChangeAlertModel, component *Alert) {
 	plan.ComponentKind = NewStringValue(component.ComponentKind)
 	plan.ComponentId = NewStringValue(component.ComponentId)
 	plan.Active = NewBoolValue(component.Active)
-	if component.Inputs != nil {
-		inputs := make([]attr.Value, 0)
-		for _, v := range component.Inputs {
-			inputs = append(inputs, NewStringValue(v))
-		}
-		plan.Inputs = NewListValueMust(StringType{}, inputs)
-	}
+	plan.Inputs = AlertInputsToModel(plan.Inputs, component.Inputs)
 
 	general := component.AlertConfig["general"].(map[string]any)
 	evaluation := component.AlertConfig["evaluation"].(map[string]any)
@@ -0,0 +1,131 @@
+package alerts
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/hashicorp/terraform-plugin-framework/attr"
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	. "github.com/hashicorp/terraform-plugin-framework/types/basetypes"
+)
+
+const (
+	COMPONENT_KIND_SOURCE    = "source"
+	COMPONENT_KIND_TRANSFORM = "transform"
+	COMPONENT_KIND_SINK      = "sink"
+	COMPONENT_KIND_PIPELINE  = "pipeline"
+)
+
+// The event types that can be alerted on for each kind of component. Pipeline alerts watch the
+// health metrics of the whole pipeline, such as its error rate, so they only support metrics.
+var Component_Kind_Event_Types = map[string][]string{
+	COMPONENT_KIND_SOURCE:    {"log", "metric"},
+	COMPONENT_KIND_TRANSFORM: {"log", "metric"},
+	COMPONENT_KIND_SINK:      {"log", "metric"},
+	COMPONENT_KIND_PIPELINE:  {"metric"},
+}
+
+var Component_Kinds = []string{
+	COMPONENT_KIND_SOURCE,
+	COMPONENT_KIND_TRANSFORM,
+	COMPONENT_KIND_SINK,
+	COMPONENT_KIND_PIPELINE,
+}
+
+// The alert fields that depend on `component_kind`
+type ComponentKindFields struct {
+	PipelineId    StringValue
+	ComponentKind StringValue
+	ComponentId   StringValue
+	EventType     StringValue
+	Inputs        ListValue
+}
+
+// Checks the fields that depend on `component_kind`. Values that are not known yet are skipped.
+func ComponentKindErrorChecks(plan *ComponentKindFields, dd *diag.Diagnostics) *diag.Diagnostics {
+	if plan.ComponentKind.IsNull() || plan.ComponentKind.IsUnknown() {
+		return dd
+	}
+	kind := plan.ComponentKind.ValueString()
+	eventTypes, ok := Component_Kind_Event_Types[kind]
+	if !ok {
+		// Reported by the attribute's own validator
+		return dd
+	}
+
+	if !plan.EventType.IsNull() && !plan.EventType.IsUnknown() && !containsString(eventTypes, plan.EventType.ValueString()) {
+		dd.AddAttributeError(
+			path.Root("event_type"),
+			"Invalid Event Type",
+			fmt.Sprintf("A '%s' alert supports these event types: %s.", kind, strings.Join(eventTypes, ", ")),
+		)
+	}
+
+	if kind == COMPONENT_KIND_PIPELINE {
+		if !plan.Inputs.IsNull() {
+			dd.AddAttributeError(
+				path.Root("inputs"),
+				"Error in plan",
+				"A 'pipeline' alert watches the whole pipeline and cannot have `inputs`",
+			)
+		}
+		if known(plan.ComponentId) && known(plan.PipelineId) && plan.ComponentId.ValueString() != plan.PipelineId.ValueString() {
+			dd.AddAttributeError(
+				path.Root("component_id"),
+				"Error in plan",
+				"The `component_id` of a 'pipeline' alert must be the `pipeline_id`, or be omitted",
+			)
+		}
+		return dd
+	}
+
+	if plan.ComponentId.IsNull() {
+		dd.AddAttributeError(
+			path.Root("component_id"),
+			"Error in plan",
+			fmt.Sprintf("A '%s' alert requires the `component_id` of the component that it is attached to", kind),
+		)
+	}
+	if plan.Inputs.IsNull() {
+		dd.AddAttributeError(
+			path.Root("inputs"),
+			"Error in plan",
+			fmt.Sprintf("A '%s' alert requires `inputs`", kind),
+		)
+	}
+	return dd
+}
+
+// The component id sent to the API. Pipeline alerts are attached to the pipeline itself.
+func AlertComponentIdFromModel(kind StringValue, componentId StringValue, pipelineId StringValue) string {
+	if kind.ValueString() == COMPONENT_KIND_PIPELINE && !known(componentId) {
+		return pipelineId.ValueString()
+	}
+	return componentId.ValueString()
+}
+
+// Pipeline alerts have no inputs, which is kept as null rather than an empty list
+func AlertInputsToModel(plan ListValue, inputs []string) ListValue {
+	if inputs == nil || (len(inputs) == 0 && plan.IsNull()) {
+		return plan
+	}
+	values := make([]attr.Value, 0, len(inputs))
+	for _, v := range inputs {
+		values = append(values, NewStringValue(v))
+	}
+	return NewListValueMust(StringType{}, values)
+}
+
+func known(value StringValue) bool {
+	return !value.IsNull() && !value.IsUnknown()
+}
+
+func containsString(values []string, value string) bool {
+	for _, v := range values {
+		if v == value {
+			return true
+		}
+	}
+	return false
+}
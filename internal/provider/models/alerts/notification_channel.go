@@ -0,0 +1,70 @@
+package alerts
+
+import (
+	"github.com/hashicorp/terraform-plugin-framework-validators/stringvalidator"
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/planmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringplanmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
+	. "github.com/hashicorp/terraform-plugin-framework/types/basetypes"
+	. "github.com/mezmo/terraform-provider-mezmo/v5/internal/client"
+)
+
+type NotificationChannelResourceModel struct {
+	Id      StringValue `tfsdk:"id"`
+	Title   StringValue `tfsdk:"title" user_config:"true"`
+	Service ObjectValue `tfsdk:"service" user_config:"true"`
+}
+
+func NotificationChannelResourceSchema() schema.Schema {
+	return schema.Schema{
+		Description: "Configures a service that receives alert notifications. Alerts send their " +
+			"notifications to the channel by setting `alert_payload.channel_id`, so that the service " +
+			"is configured once and shared by all of them.",
+		Attributes: map[string]schema.Attribute{
+			"id": schema.StringAttribute{
+				Description: "The id of the notification channel.",
+				Computed:    true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.UseStateForUnknown(),
+				},
+			},
+			"title": schema.StringAttribute{
+				Description: "A descriptive name for the notification channel.",
+				Required:    true,
+				Validators: []validator.String{
+					stringvalidator.LengthAtLeast(1),
+					stringvalidator.LengthAtMost(512),
+				},
+			},
+			"service": schema.SingleNestedAttribute{
+				Required: true,
+				MarkdownDescription: "Configuration for the service receiving the notifications. All options " +
+					"for the chosen `name` will be required. The options are the same as the `service` of an " +
+					"alert's `alert_payload`, including support for templating.",
+				Attributes: alertServiceAttributes,
+			},
+		},
+	}
+}
+
+// From terraform schema/model to a struct for sending to the API
+func NotificationChannelFromModel(plan *NotificationChannelResourceModel) (*NotificationChannel, diag.Diagnostics) {
+	dd := diag.Diagnostics{}
+	channel := NotificationChannel{
+		Title:   plan.Title.ValueString(),
+		Service: AlertServiceFromModel(plan.Service, &dd),
+	}
+	if !plan.Id.IsUnknown() {
+		channel.Id = plan.Id.ValueString()
+	}
+	return &channel, dd
+}
+
+// From an API response to a terraform model
+func NotificationChannelToModel(plan *NotificationChannelResourceModel, channel *NotificationChannel) {
+	plan.Id = NewStringValue(channel.Id)
+	plan.Title = NewStringValue(channel.Title)
+	plan.Service = AlertServiceToModel(channel.Service)
+}
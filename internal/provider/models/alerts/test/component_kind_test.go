@@ -0,0 +1,217 @@
+package alerts
+
+import (
+	"regexp"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-framework/attr"
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	. "github.com/hashicorp/terraform-plugin-framework/types/basetypes"
+	"github.com/hashicorp/terraform-plugin-testing/helper/resource"
+	. "github.com/mezmo/terraform-provider-mezmo/v5/internal/provider/models/alerts"
+	. "github.com/mezmo/terraform-provider-mezmo/v5/internal/provider/providertest"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestComponentKindErrorChecks(t *testing.T) {
+	inputs := NewListValueMust(StringType{}, []attr.Value{NewStringValue("component-id")})
+	testCases := []struct {
+		description string
+		fields      ComponentKindFields
+		errors      []string
+	}{
+		{
+			description: "sink alert on logs",
+			fields: ComponentKindFields{
+				ComponentKind: NewStringValue("sink"),
+				ComponentId:   NewStringValue("component-id"),
+				EventType:     NewStringValue("log"),
+				Inputs:        inputs,
+			},
+		},
+		{
+			description: "pipeline alert without a component",
+			fields: ComponentKindFields{
+				PipelineId:    NewStringValue("pipeline-id"),
+				ComponentKind: NewStringValue("pipeline"),
+				ComponentId:   NewStringNull(),
+				EventType:     NewStringValue("metric"),
+				Inputs:        NewListNull(StringType{}),
+			},
+		},
+		{
+			description: "pipeline alert on logs, with inputs and another component",
+			fields: ComponentKindFields{
+				PipelineId:    NewStringValue("pipeline-id"),
+				ComponentKind: NewStringValue("pipeline"),
+				ComponentId:   NewStringValue("component-id"),
+				EventType:     NewStringValue("log"),
+				Inputs:        inputs,
+			},
+			errors: []string{
+				"A 'pipeline' alert supports these event types: metric.",
+				"A 'pipeline' alert watches the whole pipeline and cannot have `inputs`",
+				"The `component_id` of a 'pipeline' alert must be the `pipeline_id`, or be omitted",
+			},
+		},
+		{
+			description: "source alert without a component or inputs",
+			fields: ComponentKindFields{
+				ComponentKind: NewStringValue("source"),
+				ComponentId:   NewStringNull(),
+				EventType:     NewStringValue("metric"),
+				Inputs:        NewListNull(StringType{}),
+			},
+			errors: []string{
+				"A 'source' alert requires the `component_id` of the component that it is attached to",
+				"A 'source' alert requires `inputs`",
+			},
+		},
+		{
+			description: "unknown kind is not checked yet",
+			fields: ComponentKindFields{
+				ComponentKind: NewStringUnknown(),
+				ComponentId:   NewStringNull(),
+				EventType:     NewStringValue("log"),
+				Inputs:        NewListNull(StringType{}),
+			},
+		},
+	}
+
+	for _, testCase := range testCases {
+		t.Run(testCase.description, func(t *testing.T) {
+			dd := diag.Diagnostics{}
+			ComponentKindErrorChecks(&testCase.fields, &dd)
+			errors := []string{}
+			for _, d := range dd.Errors() {
+				errors = append(errors, d.Detail())
+			}
+			if testCase.errors == nil {
+				assert.Empty(t, errors)
+			} else {
+				assert.Equal(t, testCase.errors, errors)
+			}
+		})
+	}
+}
+
+func TestAccAlertComponentKinds(t *testing.T) {
+	const cacheKey = "alert_component_kinds"
+	resource.Test(t, resource.TestCase{
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		PreCheck:                 func() { TestPreCheck(t) },
+		Steps: []resource.TestStep{
+			// Pipeline alerts only support metrics
+			{
+				Config: GetProviderConfig() + `
+					resource "mezmo_threshold_alert" "pipeline_logs" {
+						pipeline_id = "pipeline-id"
+						component_kind = "pipeline"
+						name = "pipeline alert"
+						event_type = "log"
+						operation = "custom"
+						script = "function myFunc(a, e, m) { return a }"
+						conditional = {
+							expressions = [
+								{
+									field = ".errors"
+									operator = "greater"
+									value_number = 10
+								}
+							]
+						}
+						alert_payload = {
+							service = {
+								name = "slack"
+								uri = "https://example.org/slack"
+								message_text = "Too many errors"
+							}
+						}
+					}`,
+				ExpectError: regexp.MustCompile(`A 'pipeline' alert supports these event types: metric`),
+			},
+			// Other kinds need a component and inputs
+			{
+				Config: GetProviderConfig() + `
+					resource "mezmo_absence_alert" "no_component" {
+						pipeline_id = "pipeline-id"
+						component_kind = "sink"
+						name = "sink alert"
+						event_type = "metric"
+						alert_payload = {
+							service = {
+								name = "slack"
+								uri = "https://example.org/slack"
+								message_text = "No data"
+							}
+						}
+					}`,
+				ExpectError: regexp.MustCompile("(?s)A 'sink' alert requires the `component_id`.*A 'sink' alert requires\\s+`inputs`"),
+			},
+			// A destination that stops receiving data, and errors across the pipeline
+			{
+				Config: SetCachedConfig(cacheKey, `
+					resource "mezmo_pipeline" "test_parent" {
+						title = "pipeline"
+					}
+					resource "mezmo_http_source" "my_source" {
+						pipeline_id = mezmo_pipeline.test_parent.id
+					}
+					resource "mezmo_blackhole_destination" "my_destination" {
+						pipeline_id = mezmo_pipeline.test_parent.id
+						inputs = [mezmo_http_source.my_source.id]
+					}`) + `
+					resource "mezmo_absence_alert" "sink" {
+						pipeline_id = mezmo_pipeline.test_parent.id
+						component_kind = "sink"
+						component_id = mezmo_blackhole_destination.my_destination.id
+						inputs = [mezmo_blackhole_destination.my_destination.id]
+						name = "destination stopped receiving data"
+						event_type = "metric"
+						alert_payload = {
+							service = {
+								name = "slack"
+								uri = "https://example.org/slack"
+								message_text = "No data reached the destination"
+							}
+						}
+					}
+					resource "mezmo_threshold_alert" "pipeline" {
+						pipeline_id = mezmo_pipeline.test_parent.id
+						component_kind = "pipeline"
+						name = "pipeline errors"
+						event_type = "metric"
+						operation = "sum"
+						conditional = {
+							expressions = [
+								{
+									field = ".errors"
+									operator = "greater"
+									value_number = 10
+								}
+							]
+						}
+						alert_payload = {
+							service = {
+								name = "slack"
+								uri = "https://example.org/slack"
+								message_text = "Too many errors"
+							}
+						}
+					}`,
+				Check: resource.ComposeTestCheckFunc(
+					StateHasExpectedValues("mezmo_absence_alert.sink", map[string]any{
+						"component_kind": "sink",
+						"component_id":   "#mezmo_blackhole_destination.my_destination.id",
+						"inputs.0":       "#mezmo_blackhole_destination.my_destination.id",
+					}),
+					StateHasExpectedValues("mezmo_threshold_alert.pipeline", map[string]any{
+						"component_kind": "pipeline",
+						"component_id":   "#mezmo_pipeline.test_parent.id",
+						"inputs.#":       nil,
+					}),
+				),
+			},
+		},
+	})
+}
@@ -0,0 +1,220 @@
+package alerts
+
+import (
+	"regexp"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-framework/attr"
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	. "github.com/hashicorp/terraform-plugin-framework/types/basetypes"
+	"github.com/hashicorp/terraform-plugin-testing/helper/resource"
+	. "github.com/mezmo/terraform-provider-mezmo/v5/internal/provider/models/alerts"
+	. "github.com/mezmo/terraform-provider-mezmo/v5/internal/provider/providertest"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestAlertPayloadChannelReference(t *testing.T) {
+	// The api may expand the channel into its service. The reference is kept instead.
+	payload := GetAlertPayloadToModel(map[string]any{
+		"channel_id": "channel-id",
+		"service":    map[string]any{"name": "slack", "uri": "https://example.org", "message_text": "text"},
+		"throttling": map[string]any{"window_secs": float64(60), "threshold": float64(1)},
+	})
+	attrs := payload.Attributes()
+	assert.Equal(t, NewStringValue("channel-id"), attrs["channel_id"])
+	assert.True(t, attrs["service"].IsNull())
+
+	dd := diag.Diagnostics{}
+	assert.Equal(t, map[string]any{
+		"channel_id": "channel-id",
+		"throttling": map[string]any{"window_secs": int64(60), "threshold": int64(1)},
+	}, GetAlertPayloadFromModel(payload, &dd))
+	assert.False(t, dd.HasError())
+
+	// Without a reference, the service is sent as before
+	payload = GetAlertPayloadToModel(map[string]any{
+		"service":    map[string]any{"name": "slack", "uri": "https://example.org", "message_text": "text"},
+		"throttling": map[string]any{"window_secs": float64(60), "threshold": float64(1)},
+	})
+	attrs = payload.Attributes()
+	assert.True(t, attrs["channel_id"].IsNull())
+	assert.Equal(t, NewStringValue("slack"), attrs["service"].(ObjectValue).Attributes()["name"])
+	assert.Equal(t, map[string]any{"name": "slack", "uri": "https://example.org", "message_text": "text"},
+		GetAlertPayloadFromModel(payload, &dd)["service"])
+	assert.False(t, dd.HasError())
+
+	payload = NewObjectValueMust(payload.AttributeTypes(nil), map[string]attr.Value{
+		"service":    NewObjectNull(attrs["service"].(ObjectValue).AttributeTypes(nil)),
+		"channel_id": NewStringNull(),
+		"throttling": attrs["throttling"],
+	})
+	GetAlertPayloadFromModel(payload, &dd)
+	assert.Equal(t, "Either `service` or `channel_id` is required in `alert_payload`", dd.Errors()[0].Detail())
+}
+
+func TestAccNotificationChannel(t *testing.T) {
+	const cacheKey = "notification_channel"
+	resource.Test(t, resource.TestCase{
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		PreCheck:                 func() { TestPreCheck(t) },
+		Steps: []resource.TestStep{
+			// The service options are checked like an alert's
+			{
+				Config: GetProviderConfig() + `
+					resource "mezmo_notification_channel" "bad_channel" {
+						title = "bad channel"
+						service = {
+							name = "slack"
+							uri  = "https://example.org"
+						}
+					}`,
+				ExpectError: regexp.MustCompile("(?s).*`message_text` is required for the `slack` service"),
+			},
+			// Create and Read testing
+			{
+				Config: SetCachedConfig(cacheKey, `
+					resource "mezmo_pipeline" "test_parent" {
+						title = "pipeline"
+					}
+					resource "mezmo_http_source" "my_source" {
+						pipeline_id = mezmo_pipeline.test_parent.id
+					}`) + `
+					resource "mezmo_notification_channel" "on_call" {
+						title = "on call"
+						service = {
+							name          = "log_analysis"
+							subject       = "Alert"
+							body          = "You received an alert"
+							ingestion_key = "abc123"
+							severity      = "INFO"
+						}
+					}
+					resource "mezmo_threshold_alert" "with_channel" {
+						pipeline_id    = mezmo_pipeline.test_parent.id
+						component_kind = "source"
+						component_id   = mezmo_http_source.my_source.id
+						inputs         = [mezmo_http_source.my_source.id]
+						name           = "my threshold alert"
+						event_type     = "metric"
+						operation      = "sum"
+						conditional = {
+							expressions = [
+								{
+									field        = ".event_count"
+									operator     = "greater"
+									value_number = 5000
+								}
+							],
+						}
+						alert_payload = {
+							channel_id = mezmo_notification_channel.on_call.id
+						}
+					}`,
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestMatchResourceAttr(
+						"mezmo_notification_channel.on_call", "id", regexp.MustCompile(`[\w-]{36}`)),
+					StateHasExpectedValues("mezmo_notification_channel.on_call", map[string]any{
+						"title":                 "on call",
+						"service.name":          "log_analysis",
+						"service.subject":       "Alert",
+						"service.body":          "You received an alert",
+						"service.ingestion_key": "abc123",
+						"service.severity":      "INFO",
+						"service.uri":           nil,
+					}),
+					StateHasExpectedValues("mezmo_threshold_alert.with_channel", map[string]any{
+						"alert_payload.channel_id":           "#mezmo_notification_channel.on_call.id",
+						"alert_payload.service.%":            nil,
+						"alert_payload.throttling.threshold": "1",
+					}),
+				),
+			},
+			// Import
+			{
+				ResourceName:      "mezmo_notification_channel.on_call",
+				ImportState:       true,
+				ImportStateVerify: true,
+			},
+			// Update the channel, the alert keeps referencing it
+			{
+				Config: GetCachedConfig(cacheKey) + `
+					resource "mezmo_notification_channel" "on_call" {
+						title = "on call slack"
+						service = {
+							name         = "slack"
+							uri          = "https://hooks.slack.com/services/T000/B000/XXXX"
+							message_text = "{{.name}} alert fired"
+						}
+					}
+					resource "mezmo_threshold_alert" "with_channel" {
+						pipeline_id    = mezmo_pipeline.test_parent.id
+						component_kind = "source"
+						component_id   = mezmo_http_source.my_source.id
+						inputs         = [mezmo_http_source.my_source.id]
+						name           = "my threshold alert"
+						event_type     = "metric"
+						operation      = "sum"
+						conditional = {
+							expressions = [
+								{
+									field        = ".event_count"
+									operator     = "greater"
+									value_number = 5000
+								}
+							],
+						}
+						alert_payload = {
+							channel_id = mezmo_notification_channel.on_call.id
+						}
+					}`,
+				Check: resource.ComposeTestCheckFunc(
+					StateHasExpectedValues("mezmo_notification_channel.on_call", map[string]any{
+						"title":                 "on call slack",
+						"service.name":          "slack",
+						"service.uri":           "https://hooks.slack.com/services/T000/B000/XXXX",
+						"service.message_text":  "{{.name}} alert fired",
+						"service.ingestion_key": nil,
+					}),
+					StateHasExpectedValues("mezmo_threshold_alert.with_channel", map[string]any{
+						"alert_payload.channel_id": "#mezmo_notification_channel.on_call.id",
+						"alert_payload.service.%":  nil,
+					}),
+				),
+			},
+			// An alert cannot set both a service and a channel
+			{
+				Config: GetCachedConfig(cacheKey) + `
+					resource "mezmo_threshold_alert" "both" {
+						pipeline_id    = mezmo_pipeline.test_parent.id
+						component_kind = "source"
+						component_id   = mezmo_http_source.my_source.id
+						inputs         = [mezmo_http_source.my_source.id]
+						name           = "my threshold alert"
+						event_type     = "metric"
+						operation      = "sum"
+						conditional = {
+							expressions = [
+								{
+									field        = ".event_count"
+									operator     = "greater"
+									value_number = 5000
+								}
+							],
+						}
+						alert_payload = {
+							channel_id = "some-channel-id"
+							service = {
+								name          = "log_analysis"
+								subject       = "Alert"
+								body          = "You received an alert"
+								ingestion_key = "abc123"
+								severity      = "INFO"
+							}
+						}
+					}`,
+				ExpectError: regexp.MustCompile(`(?s)Invalid Attribute Combination`),
+			},
+			// Delete testing automatically occurs in TestCase
+		},
+	})
+}
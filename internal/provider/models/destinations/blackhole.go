@@ -7,6 +7,7 @@ import (
 	. "github.com/hashicorp/terraform-plugin-framework/types"
 	"github.com/hashicorp/terraform-plugin-framework/types/basetypes"
 	. "github.com/mezmo/terraform-provider-mezmo/v5/internal/client"
+	. "github.com/mezmo/terraform-provider-mezmo/v5/internal/provider/models/modelutils"
 )
 
 const BLACKHOLE_DESTINATION_NODE_NAME = "blackhole"
@@ -27,16 +28,16 @@ var BlackholeDestinationResourceSchema = schema.Schema{
 	Attributes:  ExtendBaseAttributes(map[string]schema.Attribute{}, nil),
 }
 
+var blackholeDestinationUserConfig = NewUserConfigCodec[BlackholeDestinationModel](BlackholeDestinationResourceSchema, nil)
+
 func BlackholeDestinationFromModel(plan *BlackholeDestinationModel, previousState *BlackholeDestinationModel) (*Destination, diag.Diagnostics) {
-	dd := diag.Diagnostics{}
+	userConfig, dd := blackholeDestinationUserConfig.FromModel(plan)
 	component := Destination{
 		BaseNode: BaseNode{
 			Type:        BLACKHOLE_DESTINATION_NODE_NAME,
 			Title:       plan.Title.ValueString(),
 			Description: plan.Description.ValueString(),
-			UserConfig: map[string]any{
-				"ack_enabled": plan.AckEnabled.ValueBool(),
-			},
+			UserConfig:  userConfig,
 		},
 	}
 
@@ -57,7 +58,7 @@ func BlackholeDestinationFromModel(plan *BlackholeDestinationModel, previousStat
 	return &component, dd
 }
 
-func BlackholeDestinationToModel(plan *BlackholeDestinationModel, component *Destination) {
+func BlackholeDestinationToModel(plan *BlackholeDestinationModel, component *Destination) diag.Diagnostics {
 	plan.Id = StringValue(component.Id)
 	if component.Title != "" {
 		plan.Title = StringValue(component.Title)
@@ -73,8 +74,5 @@ func BlackholeDestinationToModel(plan *BlackholeDestinationModel, component *Des
 		}
 		plan.Inputs = ListValueMust(StringType, inputs)
 	}
-	if component.UserConfig["ack_enabled"] != nil {
-		value, _ := component.UserConfig["ack_enabled"].(bool)
-		plan.AckEnabled = BoolValue(value)
-	}
+	return blackholeDestinationUserConfig.ToModel(plan, component.UserConfig)
 }
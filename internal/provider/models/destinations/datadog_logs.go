@@ -48,20 +48,17 @@ var DatadogLogsDestinationResourceSchema = schema.Schema{
 	}, nil),
 }
 
+var datadogLogsDestinationUserConfig = modelutils.NewUserConfigCodec[DatadogLogsDestinationModel](DatadogLogsDestinationResourceSchema, nil)
+
 func DatadogLogsFromModel(plan *DatadogLogsDestinationModel, previousState *DatadogLogsDestinationModel) (*Destination, diag.Diagnostics) {
-	dd := diag.Diagnostics{}
+	userConfig, dd := datadogLogsDestinationUserConfig.FromModel(plan)
 	component := Destination{
 		BaseNode: BaseNode{
 			Type:        DATADOG_LOGS_DESTINATION_NODE_NAME,
 			Title:       plan.Title.ValueString(),
 			Description: plan.Description.ValueString(),
 			Inputs:      modelutils.StringListValueToStringSlice(plan.Inputs),
-			UserConfig: map[string]any{
-				"api_key":     plan.ApiKey.ValueString(),
-				"site":        plan.Site.ValueString(),
-				"compression": plan.Compression.ValueString(),
-				"ack_enabled": plan.AckEnabled.ValueBool(),
-			},
+			UserConfig:  userConfig,
 		},
 	}
 
@@ -73,7 +70,7 @@ func DatadogLogsFromModel(plan *DatadogLogsDestinationModel, previousState *Data
 	return &component, dd
 }
 
-func DatadogLogsDestinationToModel(plan *DatadogLogsDestinationModel, component *Destination) {
+func DatadogLogsDestinationToModel(plan *DatadogLogsDestinationModel, component *Destination) diag.Diagnostics {
 	plan.Id = StringValue(component.Id)
 	if component.Title != "" {
 		plan.Title = StringValue(component.Title)
@@ -83,8 +80,5 @@ func DatadogLogsDestinationToModel(plan *DatadogLogsDestinationModel, component
 	}
 	plan.GenerationId = Int64Value(component.GenerationId)
 	plan.Inputs = modelutils.SliceToStringListValue(component.Inputs)
-	plan.ApiKey = StringValue(component.UserConfig["api_key"].(string))
-	plan.Site = StringValue(component.UserConfig["site"].(string))
-	plan.Compression = StringValue(component.UserConfig["compression"].(string))
-	plan.AckEnabled = BoolValue(component.UserConfig["ack_enabled"].(bool))
+	return datadogLogsDestinationUserConfig.ToModel(plan, component.UserConfig)
 }
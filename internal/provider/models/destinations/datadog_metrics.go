@@ -42,18 +42,16 @@ var DatadogMetricsDestinationResourceSchema = schema.Schema{
 	}, nil),
 }
 
+var datadogMetricsDestinationUserConfig = modelutils.NewUserConfigCodec[DatadogMetricsDestinationModel](DatadogMetricsDestinationResourceSchema, nil)
+
 func DatadogMetricsFromModel(plan *DatadogMetricsDestinationModel, previousState *DatadogMetricsDestinationModel) (*Destination, diag.Diagnostics) {
-	dd := diag.Diagnostics{}
+	userConfig, dd := datadogMetricsDestinationUserConfig.FromModel(plan)
 	component := Destination{
 		BaseNode: BaseNode{
 			Type:        DATADOG_METRICS_DESTINATION_NODE_NAME,
 			Title:       plan.Title.ValueString(),
 			Description: plan.Description.ValueString(),
-			UserConfig: map[string]any{
-				"api_key":     plan.ApiKey.ValueString(),
-				"site":        plan.Site.ValueString(),
-				"ack_enabled": plan.AckEnabled.ValueBool(),
-			},
+			UserConfig:  userConfig,
 		},
 	}
 
@@ -69,7 +67,7 @@ func DatadogMetricsFromModel(plan *DatadogMetricsDestinationModel, previousState
 	return &component, dd
 }
 
-func DatadogMetricsDestinationToModel(plan *DatadogMetricsDestinationModel, component *Destination) {
+func DatadogMetricsDestinationToModel(plan *DatadogMetricsDestinationModel, component *Destination) diag.Diagnostics {
 	plan.Id = StringValue(component.Id)
 	if component.Title != "" {
 		plan.Title = StringValue(component.Title)
@@ -79,16 +77,5 @@ func DatadogMetricsDestinationToModel(plan *DatadogMetricsDestinationModel, comp
 	}
 	plan.GenerationId = Int64Value(component.GenerationId)
 	plan.Inputs = modelutils.SliceToStringListValue(component.Inputs)
-	if component.UserConfig["api_key"] != nil {
-		value, _ := component.UserConfig["api_key"].(string)
-		plan.ApiKey = StringValue(value)
-	}
-	if component.UserConfig["site"] != nil {
-		value, _ := component.UserConfig["site"].(string)
-		plan.Site = StringValue(value)
-	}
-	if component.UserConfig["ack_enabled"] != nil {
-		value, _ := component.UserConfig["ack_enabled"].(bool)
-		plan.AckEnabled = BoolValue(value)
-	}
+	return datadogMetricsDestinationUserConfig.ToModel(plan, component.UserConfig)
 }
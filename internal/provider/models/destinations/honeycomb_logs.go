@@ -42,8 +42,10 @@ var HoneycombLogsResourceSchema = schema.Schema{
 	}, nil),
 }
 
+var honeycombLogsDestinationUserConfig = NewUserConfigCodec[HoneycombLogsDestinationModel](HoneycombLogsResourceSchema, nil)
+
 func HoneycombLogsFromModel(plan *HoneycombLogsDestinationModel, previousState *HoneycombLogsDestinationModel) (*Destination, diag.Diagnostics) {
-	dd := diag.Diagnostics{}
+	userConfig, dd := honeycombLogsDestinationUserConfig.FromModel(plan)
 
 	component := Destination{
 		BaseNode: BaseNode{
@@ -51,11 +53,7 @@ func HoneycombLogsFromModel(plan *HoneycombLogsDestinationModel, previousState *
 			Title:       plan.Title.ValueString(),
 			Description: plan.Description.ValueString(),
 			Inputs:      StringListValueToStringSlice(plan.Inputs),
-			UserConfig: map[string]any{
-				"ack_enabled": plan.AckEnabled.ValueBool(),
-				"api_key":     plan.ApiKey.ValueString(),
-				"dataset":     plan.DataSet.ValueString(),
-			},
+			UserConfig:  userConfig,
 		},
 	}
 
@@ -67,7 +65,7 @@ func HoneycombLogsFromModel(plan *HoneycombLogsDestinationModel, previousState *
 	return &component, dd
 }
 
-func HoneycombLogsToModel(plan *HoneycombLogsDestinationModel, component *Destination) {
+func HoneycombLogsToModel(plan *HoneycombLogsDestinationModel, component *Destination) diag.Diagnostics {
 	plan.Id = StringValue(component.Id)
 	if component.Title != "" {
 		plan.Title = StringValue(component.Title)
@@ -77,7 +75,5 @@ func HoneycombLogsToModel(plan *HoneycombLogsDestinationModel, component *Destin
 	}
 	plan.GenerationId = Int64Value(component.GenerationId)
 	plan.Inputs = SliceToStringListValue(component.Inputs)
-	plan.AckEnabled = BoolValue(component.UserConfig["ack_enabled"].(bool))
-	plan.ApiKey = StringValue(component.UserConfig["api_key"].(string))
-	plan.DataSet = StringValue(component.UserConfig["dataset"].(string))
+	return honeycombLogsDestinationUserConfig.ToModel(plan, component.UserConfig)
 }
@@ -52,8 +52,10 @@ var NewRelicDestinationResourceSchema = schema.Schema{
 	}, nil),
 }
 
+var newRelicDestinationUserConfig = NewUserConfigCodec[NewRelicDestinationModel](NewRelicDestinationResourceSchema, nil)
+
 func NewRelicDestinationFromModel(plan *NewRelicDestinationModel, previousState *NewRelicDestinationModel) (*Destination, diag.Diagnostics) {
-	dd := diag.Diagnostics{}
+	userConfig, dd := newRelicDestinationUserConfig.FromModel(plan)
 
 	component := Destination{
 		BaseNode: BaseNode{
@@ -61,12 +63,7 @@ func NewRelicDestinationFromModel(plan *NewRelicDestinationModel, previousState
 			Title:       plan.Title.ValueString(),
 			Description: plan.Description.ValueString(),
 			Inputs:      StringListValueToStringSlice(plan.Inputs),
-			UserConfig: map[string]any{
-				"ack_enabled": plan.AckEnabled.ValueBool(),
-				"api":         plan.Api.ValueString(),
-				"account_id":  plan.AccountId.ValueString(),
-				"license_key": plan.LicenseKey.ValueString(),
-			},
+			UserConfig:  userConfig,
 		},
 	}
 
@@ -78,7 +75,7 @@ func NewRelicDestinationFromModel(plan *NewRelicDestinationModel, previousState
 	return &component, dd
 }
 
-func NewRelicDestinationToModel(plan *NewRelicDestinationModel, component *Destination) {
+func NewRelicDestinationToModel(plan *NewRelicDestinationModel, component *Destination) diag.Diagnostics {
 	plan.Id = StringValue(component.Id)
 	if component.Title != "" {
 		plan.Title = StringValue(component.Title)
@@ -88,8 +85,5 @@ func NewRelicDestinationToModel(plan *NewRelicDestinationModel, component *Desti
 	}
 	plan.GenerationId = Int64Value(component.GenerationId)
 	plan.Inputs = SliceToStringListValue(component.Inputs)
-	plan.AckEnabled = BoolValue(component.UserConfig["ack_enabled"].(bool))
-	plan.Api = StringValue(component.UserConfig["api"].(string))
-	plan.AccountId = StringValue(component.UserConfig["account_id"].(string))
-	plan.LicenseKey = StringValue(component.UserConfig["license_key"].(string))
+	return newRelicDestinationUserConfig.ToModel(plan, component.UserConfig)
 }
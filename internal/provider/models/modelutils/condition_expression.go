@@ -0,0 +1,376 @@
+package modelutils
+
+import (
+	"fmt"
+	"slices"
+	"strconv"
+	"strings"
+	"unicode"
+)
+
+// Operators that only test the field, and therefore take no value
+var unaryOperatorLabels = []string{
+	"exists",
+	"is_array",
+	"is_boolean",
+	"is_empty",
+	"is_metric",
+	"is_null",
+	"is_number",
+	"is_object",
+	"is_string",
+}
+
+var comparisonOperators = map[string]string{
+	"==": "equal",
+	"!=": "equal",
+	">":  "greater",
+	">=": "greater_or_equal",
+	"<":  "less",
+	"<=": "less_or_equal",
+	"=~": "regex_match",
+}
+
+// Parses a condition written as an expression, such as
+//
+//	status >= 500 && (service == "api" || negate(starts_with(path, "/health")))
+//
+// into the conditional shape used by the API, which is what `UnwindConditionalToModel`
+// accepts. Comparisons use ==, !=, >, >=, <, <= and =~ (regex_match). Any other operator is
+// called as a function with the field and, unless it only tests the field, a value. A
+// comparison or function can be wrapped in `negate()`. Parentheses group expressions, and
+// && binds tighter than ||.
+func ParseConditionExpression(expression string, operators []string) (map[string]any, error) {
+	tokens, err := tokenizeCondition(expression)
+	if err != nil {
+		return nil, err
+	}
+	p := conditionParser{tokens: tokens, operators: operators}
+	node, err := p.parseOr()
+	if err != nil {
+		return nil, err
+	}
+	if next := p.peek(); next.kind != tokenEnd {
+		return nil, fmt.Errorf("unexpected %s at position %d", next, next.pos)
+	}
+	if _, isGroup := node["expressions"]; !isGroup {
+		node = map[string]any{
+			"expressions":       []any{node},
+			"logical_operation": "AND",
+		}
+	}
+	if depth := conditionDepth(node); depth > MAX_NESTED_LEVELS {
+		return nil, fmt.Errorf(
+			"expression is nested %d levels deep, but at most %d levels are supported",
+			depth, MAX_NESTED_LEVELS,
+		)
+	}
+	return node, nil
+}
+
+func conditionDepth(node map[string]any) int {
+	depth := 0
+	expressions, _ := node["expressions"].([]any)
+	for _, e := range expressions {
+		child := e.(map[string]any)
+		if _, isGroup := child["expressions"]; isGroup {
+			depth = max(depth, conditionDepth(child)+1)
+		}
+	}
+	return depth
+}
+
+type tokenKind int
+
+const (
+	tokenEnd tokenKind = iota
+	tokenIdent
+	tokenString
+	tokenNumber
+	tokenComparison
+	tokenAnd
+	tokenOr
+	tokenOpen
+	tokenClose
+	tokenComma
+)
+
+type conditionToken struct {
+	kind  tokenKind
+	text  string
+	value any
+	pos   int
+}
+
+func (t conditionToken) String() string {
+	if t.kind == tokenEnd {
+		return "end of expression"
+	}
+	return strconv.Quote(t.text)
+}
+
+func isIdentStart(r rune) bool {
+	return unicode.IsLetter(r) || r == '_' || r == '.' || r == '$' || r == '@'
+}
+
+func isIdentPart(r rune) bool {
+	return isIdentStart(r) || unicode.IsDigit(r) || r == '-' || r == '[' || r == ']'
+}
+
+func tokenizeCondition(expression string) ([]conditionToken, error) {
+	tokens := []conditionToken{}
+	runes := []rune(expression)
+	for i := 0; i < len(runes); {
+		r := runes[i]
+		start := i
+		switch {
+		case unicode.IsSpace(r):
+			i++
+			continue
+		case r == '(':
+			tokens = append(tokens, conditionToken{kind: tokenOpen, text: "(", pos: start})
+			i++
+		case r == ')':
+			tokens = append(tokens, conditionToken{kind: tokenClose, text: ")", pos: start})
+			i++
+		case r == ',':
+			tokens = append(tokens, conditionToken{kind: tokenComma, text: ",", pos: start})
+			i++
+		case r == '&' || r == '|':
+			if i+1 >= len(runes) || runes[i+1] != r {
+				return nil, fmt.Errorf("unexpected %q at position %d, expected && or ||", string(r), start)
+			}
+			kind := tokenAnd
+			if r == '|' {
+				kind = tokenOr
+			}
+			tokens = append(tokens, conditionToken{kind: kind, text: string(runes[i : i+2]), pos: start})
+			i += 2
+		case strings.ContainsRune("=!<>", r):
+			text := string(r)
+			if i+1 < len(runes) {
+				if _, ok := comparisonOperators[text+string(runes[i+1])]; ok {
+					text += string(runes[i+1])
+				}
+			}
+			if _, ok := comparisonOperators[text]; !ok {
+				return nil, fmt.Errorf("unknown comparison %q at position %d", text, start)
+			}
+			tokens = append(tokens, conditionToken{kind: tokenComparison, text: text, pos: start})
+			i += len([]rune(text))
+		case r == '"':
+			i++
+			for i < len(runes) && runes[i] != '"' {
+				if runes[i] == '\\' {
+					i++
+				}
+				i++
+			}
+			if i >= len(runes) {
+				return nil, fmt.Errorf("unterminated string starting at position %d", start)
+			}
+			i++
+			text := string(runes[start:i])
+			value, err := strconv.Unquote(text)
+			if err != nil {
+				return nil, fmt.Errorf("invalid string %s at position %d: %s", text, start, err)
+			}
+			tokens = append(tokens, conditionToken{kind: tokenString, text: text, value: value, pos: start})
+		case unicode.IsDigit(r) || r == '-':
+			i++
+			for i < len(runes) && (unicode.IsDigit(runes[i]) || strings.ContainsRune(".eE+-", runes[i])) {
+				i++
+			}
+			text := string(runes[start:i])
+			value, err := strconv.ParseFloat(text, 64)
+			if err != nil {
+				return nil, fmt.Errorf("invalid number %q at position %d", text, start)
+			}
+			tokens = append(tokens, conditionToken{kind: tokenNumber, text: text, value: value, pos: start})
+		case isIdentStart(r):
+			for i < len(runes) && isIdentPart(runes[i]) {
+				i++
+			}
+			text := string(runes[start:i])
+			tokens = append(tokens, conditionToken{kind: tokenIdent, text: text, pos: start})
+		default:
+			return nil, fmt.Errorf("unexpected %q at position %d", string(r), start)
+		}
+	}
+	return append(tokens, conditionToken{kind: tokenEnd, pos: len(runes)}), nil
+}
+
+type conditionParser struct {
+	tokens    []conditionToken
+	pos       int
+	operators []string
+}
+
+func (p *conditionParser) peek() conditionToken {
+	return p.tokens[p.pos]
+}
+
+func (p *conditionParser) next() conditionToken {
+	token := p.tokens[p.pos]
+	if token.kind != tokenEnd {
+		p.pos++
+	}
+	return token
+}
+
+func (p *conditionParser) expect(kind tokenKind, expected string) (conditionToken, error) {
+	token := p.next()
+	if token.kind != kind {
+		return token, fmt.Errorf("unexpected %s at position %d, expected %s", token, token.pos, expected)
+	}
+	return token, nil
+}
+
+func (p *conditionParser) parseOr() (map[string]any, error) {
+	return p.parseGroup(tokenOr, "OR", p.parseAnd)
+}
+
+func (p *conditionParser) parseAnd() (map[string]any, error) {
+	return p.parseGroup(tokenAnd, "AND", p.parseUnary)
+}
+
+// Collects operands joined by the same logical operator into a single group. Operands that
+// are groups of the same operation are flattened into it.
+func (p *conditionParser) parseGroup(
+	kind tokenKind, operation string, operand func() (map[string]any, error),
+) (map[string]any, error) {
+	first, err := operand()
+	if err != nil {
+		return nil, err
+	}
+	if p.peek().kind != kind {
+		return first, nil
+	}
+	expressions := []any{}
+	add := func(node map[string]any) {
+		if node["logical_operation"] == operation {
+			expressions = append(expressions, node["expressions"].([]any)...)
+		} else {
+			expressions = append(expressions, node)
+		}
+	}
+	add(first)
+	for p.peek().kind == kind {
+		p.next()
+		node, err := operand()
+		if err != nil {
+			return nil, err
+		}
+		add(node)
+	}
+	return map[string]any{"expressions": expressions, "logical_operation": operation}, nil
+}
+
+func (p *conditionParser) parseUnary() (map[string]any, error) {
+	token := p.peek()
+	if token.kind == tokenOpen {
+		p.next()
+		node, err := p.parseOr()
+		if err != nil {
+			return nil, err
+		}
+		if _, err := p.expect(tokenClose, `")"`); err != nil {
+			return nil, err
+		}
+		return node, nil
+	}
+	if token.kind == tokenIdent && token.text == "negate" && p.tokens[p.pos+1].kind == tokenOpen {
+		p.next()
+		p.next()
+		node, err := p.parseOr()
+		if err != nil {
+			return nil, err
+		}
+		if _, isGroup := node["expressions"]; isGroup {
+			return nil, fmt.Errorf(
+				"negate() at position %d can only wrap a single comparison, not a group", token.pos,
+			)
+		}
+		if _, err := p.expect(tokenClose, `")"`); err != nil {
+			return nil, err
+		}
+		node["negate"] = !node["negate"].(bool)
+		return node, nil
+	}
+	return p.parsePredicate()
+}
+
+func (p *conditionParser) parseValue() (any, error) {
+	token := p.next()
+	if token.kind != tokenString && token.kind != tokenNumber {
+		return nil, fmt.Errorf("unexpected %s at position %d, expected a string or a number", token, token.pos)
+	}
+	return token.value, nil
+}
+
+func (p *conditionParser) checkOperator(operator string, pos int) error {
+	if !slices.Contains(p.operators, operator) {
+		return fmt.Errorf(
+			"unknown operator %q at position %d, expected one of: %s",
+			operator, pos, strings.Join(p.operators, ", "),
+		)
+	}
+	return nil
+}
+
+func (p *conditionParser) parsePredicate() (map[string]any, error) {
+	token, err := p.expect(tokenIdent, "a field or an operator")
+	if err != nil {
+		return nil, err
+	}
+
+	// Function style, e.g. starts_with(path, "/health") or exists(path)
+	if p.peek().kind == tokenOpen {
+		operator := token.text
+		if err := p.checkOperator(operator, token.pos); err != nil {
+			return nil, err
+		}
+		p.next()
+		field, err := p.expect(tokenIdent, "a field")
+		if err != nil {
+			return nil, err
+		}
+		var value any = ""
+		if !slices.Contains(unaryOperatorLabels, operator) {
+			if _, err := p.expect(tokenComma, `","`); err != nil {
+				return nil, err
+			}
+			if value, err = p.parseValue(); err != nil {
+				return nil, err
+			}
+		}
+		if _, err := p.expect(tokenClose, `")"`); err != nil {
+			return nil, err
+		}
+		return conditionLeaf(field.text, operator, value, false), nil
+	}
+
+	// Comparison style, e.g. status >= 500
+	comparison, err := p.expect(tokenComparison, "a comparison")
+	if err != nil {
+		return nil, err
+	}
+	operator := comparisonOperators[comparison.text]
+	if err := p.checkOperator(operator, comparison.pos); err != nil {
+		return nil, err
+	}
+	value, err := p.parseValue()
+	if err != nil {
+		return nil, err
+	}
+	return conditionLeaf(token.text, operator, value, comparison.text == "!="), nil
+}
+
+func conditionLeaf(field string, operator string, value any, negate bool) map[string]any {
+	return map[string]any{
+		"field":        field,
+		"str_operator": operator,
+		"value":        value,
+		"negate":       negate,
+	}
+}
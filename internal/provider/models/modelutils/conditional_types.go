@@ -278,3 +278,22 @@ func UnwindConditionalFromModel(v attr.Value) map[string]any {
 
 	return conditional
 }
+
+// Converts the conditional field `name` of a model with a `UserConfigCodec`. Null conditionals
+// are left out of the `user_config`.
+func ConditionalUserConfigHook(name string, operators []string) UserConfigHook {
+	return UserConfigHook{
+		FromModel: func(value attr.Value, userConfig map[string]any) {
+			if !value.IsNull() && !value.IsUnknown() {
+				userConfig[name] = UnwindConditionalFromModel(value)
+			}
+		},
+		ToModel: func(userConfig map[string]any) (attr.Value, bool) {
+			conditional, ok := userConfig[name].(map[string]any)
+			if !ok {
+				return nil, false
+			}
+			return UnwindConditionalToModel(conditional, operators), true
+		},
+	}
+}
@@ -0,0 +1,61 @@
+package modelutils
+
+import (
+	"encoding/json"
+	"fmt"
+	"sort"
+)
+
+// Lists the differences between two component configurations, one line per changed field.
+// Nested objects are compared field by field and reported with a dotted path, e.g.
+// `auth.strategy`. Other values are compared as JSON.
+func ConfigDiff(before map[string]any, after map[string]any) []string {
+	return configDiff("", before, after)
+}
+
+func configDiff(prefix string, before map[string]any, after map[string]any) []string {
+	keys := make(map[string]bool, len(before)+len(after))
+	for key := range before {
+		keys[key] = true
+	}
+	for key := range after {
+		keys[key] = true
+	}
+	sorted := make([]string, 0, len(keys))
+	for key := range keys {
+		sorted = append(sorted, key)
+	}
+	sort.Strings(sorted)
+
+	lines := []string{}
+	for _, key := range sorted {
+		path := prefix + key
+		beforeValue, inBefore := before[key]
+		afterValue, inAfter := after[key]
+		switch {
+		case !inBefore:
+			lines = append(lines, fmt.Sprintf("  + %s: %s", path, diffValue(afterValue)))
+		case !inAfter:
+			lines = append(lines, fmt.Sprintf("  - %s: %s", path, diffValue(beforeValue)))
+		default:
+			beforeMap, beforeIsMap := beforeValue.(map[string]any)
+			afterMap, afterIsMap := afterValue.(map[string]any)
+			if beforeIsMap && afterIsMap {
+				lines = append(lines, configDiff(path+".", beforeMap, afterMap)...)
+				continue
+			}
+			if beforeJson, afterJson := diffValue(beforeValue), diffValue(afterValue); beforeJson != afterJson {
+				lines = append(lines, fmt.Sprintf("  ~ %s: %s -> %s", path, beforeJson, afterJson))
+			}
+		}
+	}
+	return lines
+}
+
+func diffValue(value any) string {
+	encoded, err := json.Marshal(value)
+	if err != nil {
+		return fmt.Sprintf("%v", value)
+	}
+	return string(encoded)
+}
@@ -0,0 +1,120 @@
+package modelutils_test
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/mezmo/terraform-provider-mezmo/v5/internal/provider/models/modelutils"
+	"github.com/stretchr/testify/assert"
+)
+
+func leaf(field string, operator string, value any, negate bool) map[string]any {
+	return map[string]any{"field": field, "str_operator": operator, "value": value, "negate": negate}
+}
+
+func TestParseConditionExpression(t *testing.T) {
+	testCases := []struct {
+		description string
+		expression  string
+		expected    map[string]any
+	}{
+		{
+			description: "single comparison is wrapped in a group",
+			expression:  `.status >= 500`,
+			expected: map[string]any{
+				"logical_operation": "AND",
+				"expressions":       []any{leaf(".status", "greater_or_equal", 500.0, false)},
+			},
+		},
+		{
+			description: "nested groups, functions and negation",
+			expression:  `status >= 500 && (service == "api" || negate(starts_with(path, "/health")))`,
+			expected: map[string]any{
+				"logical_operation": "AND",
+				"expressions": []any{
+					leaf("status", "greater_or_equal", 500.0, false),
+					map[string]any{
+						"logical_operation": "OR",
+						"expressions": []any{
+							leaf("service", "equal", "api", false),
+							leaf("path", "starts_with", "/health", true),
+						},
+					},
+				},
+			},
+		},
+		{
+			description: "&& binds tighter than || and chains are flattened",
+			expression:  `a == 1 || b != "x" && exists(c) || d =~ "^v[0-9]+"`,
+			expected: map[string]any{
+				"logical_operation": "OR",
+				"expressions": []any{
+					leaf("a", "equal", 1.0, false),
+					map[string]any{
+						"logical_operation": "AND",
+						"expressions": []any{
+							leaf("b", "equal", "x", true),
+							leaf("c", "exists", "", false),
+						},
+					},
+					leaf("d", "regex_match", "^v[0-9]+", false),
+				},
+			},
+		},
+	}
+
+	for _, tt := range testCases {
+		t.Run(tt.description, func(t *testing.T) {
+			actual, err := modelutils.ParseConditionExpression(tt.expression, modelutils.Non_Change_Operator_Labels)
+			assert.NoError(t, err)
+			assert.Equal(t, tt.expected, actual)
+
+			// The result round trips through the terraform model
+			model := modelutils.UnwindConditionalToModel(actual, modelutils.Non_Change_Operator_Labels)
+			assert.False(t, model.IsNull())
+		})
+	}
+}
+
+func TestParseConditionExpressionErrors(t *testing.T) {
+	testCases := map[string]string{
+		`percent_change_greater(cpu, 10)`: `unknown operator "percent_change_greater" at position 0`,
+		`status = 500`:                    `unknown comparison "=" at position 7`,
+		`status >= 500 &&`:                `unexpected end of expression at position 16`,
+		`(status >= 500`:                  `expected ")"`,
+		`name == "unterminated`:           `unterminated string starting at position 8`,
+		`negate(a == 1 && b == 2)`:        `negate() at position 0 can only wrap a single comparison`,
+		`contains(message)`:               `unexpected ")" at position 16, expected ","`,
+		`status >= 500 status`:            `unexpected "status" at position 14`,
+		`status > 1 & status < 2`:         `expected && or ||`,
+		`level == error`:                  `expected a string or a number`,
+		`((((((((a == 1 || b == 2) && c == 3) || d == 4) && e == 5) || f == 6) && g == 7) || h == 8) && i == 9)`: `at most 5 levels are supported`,
+	}
+
+	for expression, expected := range testCases {
+		t.Run(expression, func(t *testing.T) {
+			_, err := modelutils.ParseConditionExpression(expression, modelutils.Non_Change_Operator_Labels)
+			assert.Error(t, err)
+			if err != nil {
+				assert.True(t, strings.Contains(err.Error(), expected), err.Error())
+			}
+		})
+	}
+}
+
+func TestParseConditionExpressionMaxDepth(t *testing.T) {
+	// Alternating operations nest one level deeper each time
+	expression := "a == 1"
+	for i := 0; i < modelutils.MAX_NESTED_LEVELS+1; i++ {
+		operation := " && "
+		if i%2 == 1 {
+			operation = " || "
+		}
+		expression = "(" + expression + operation + "a == 1)"
+	}
+	actual, err := modelutils.ParseConditionExpression(expression, modelutils.Non_Change_Operator_Labels)
+	assert.NoError(t, err)
+	assert.NotPanics(t, func() {
+		modelutils.UnwindConditionalToModel(actual, modelutils.Non_Change_Operator_Labels)
+	})
+}
@@ -0,0 +1,31 @@
+package modelutils_test
+
+import (
+	"testing"
+
+	"github.com/mezmo/terraform-provider-mezmo/v5/internal/provider/models/modelutils"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestConfigDiff(t *testing.T) {
+	before := map[string]any{
+		"fields":  []any{".a"},
+		"enabled": true,
+		"auth":    map[string]any{"strategy": "basic", "user": "me"},
+		"removed": "value",
+	}
+	after := map[string]any{
+		"fields":  []any{".a", ".b"},
+		"enabled": true,
+		"auth":    map[string]any{"strategy": "bearer", "user": "me"},
+		"added":   1,
+	}
+
+	assert.Equal(t, []string{
+		`  + added: 1`,
+		`  ~ auth.strategy: "basic" -> "bearer"`,
+		`  ~ fields: [".a"] -> [".a",".b"]`,
+		`  - removed: "value"`,
+	}, modelutils.ConfigDiff(before, after))
+	assert.Empty(t, modelutils.ConfigDiff(before, before))
+}
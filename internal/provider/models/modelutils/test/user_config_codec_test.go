@@ -0,0 +1,202 @@
+package modelutils_test
+
+import (
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-framework/attr"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	. "github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/mezmo/terraform-provider-mezmo/v5/internal/provider/models/modelutils"
+	"github.com/stretchr/testify/assert"
+)
+
+type codecModel struct {
+	Id      String `tfsdk:"id"`
+	Fields  List   `tfsdk:"fields" user_config:"true"`
+	Count   Int64  `tfsdk:"count" user_config:"true"`
+	Enabled Bool   `tfsdk:"enabled" user_config:"true"`
+	Auth    Object `tfsdk:"auth" user_config:"true"`
+	Headers Map    `tfsdk:"headers" user_config:"true"`
+	Nested  String `tfsdk:"nested" user_config:"true"`
+}
+
+var codecSchema = schema.Schema{
+	Attributes: map[string]schema.Attribute{
+		"id":      schema.StringAttribute{Computed: true},
+		"fields":  schema.ListAttribute{ElementType: StringType, Required: true},
+		"count":   schema.Int64Attribute{Optional: true},
+		"enabled": schema.BoolAttribute{Optional: true},
+		"auth": schema.SingleNestedAttribute{
+			Optional: true,
+			Attributes: map[string]schema.Attribute{
+				"user":     schema.StringAttribute{Required: true},
+				"password": schema.StringAttribute{Optional: true},
+			},
+		},
+		"headers": schema.MapAttribute{ElementType: StringType, Optional: true},
+		"nested":  schema.StringAttribute{Optional: true},
+	},
+}
+
+var authTypes = map[string]attr.Type{"user": StringType, "password": StringType}
+
+// `nested` is sent as `options.nested`, and `headers` is left out when null
+var codec = modelutils.NewUserConfigCodec[codecModel](codecSchema, map[string]modelutils.UserConfigHook{
+	"headers": {OmitNull: true},
+	"nested": {
+		FromModel: func(value attr.Value, userConfig map[string]any) {
+			userConfig["options"] = map[string]any{"nested": value.(String).ValueString()}
+		},
+		ToModel: func(userConfig map[string]any) (attr.Value, bool) {
+			options, ok := userConfig["options"].(map[string]any)
+			if !ok {
+				return nil, false
+			}
+			return StringValue(options["nested"].(string)), true
+		},
+	},
+})
+
+func TestUserConfigCodecFromModel(t *testing.T) {
+	plan := codecModel{
+		Id:      StringUnknown(),
+		Fields:  ListValueMust(StringType, []attr.Value{StringValue(".a"), StringValue(".b")}),
+		Count:   Int64Value(3),
+		Enabled: BoolNull(),
+		Auth: ObjectValueMust(authTypes, map[string]attr.Value{
+			"user":     StringValue("me"),
+			"password": StringNull(),
+		}),
+		Headers: MapValueMust(StringType, map[string]attr.Value{"x-key": StringValue("value")}),
+		Nested:  StringValue("inside"),
+	}
+	userConfig, dd := codec.FromModel(&plan)
+	assert.False(t, dd.HasError())
+	assert.Equal(t, map[string]any{
+		"fields":  []any{".a", ".b"},
+		"count":   int64(3),
+		"enabled": false,
+		"auth":    map[string]any{"user": "me"},
+		"headers": map[string]any{"x-key": "value"},
+		"options": map[string]any{"nested": "inside"},
+	}, userConfig)
+
+	// Null values are sent as zero values, as the converters of the models did before the codec
+	plan = codecModel{
+		Fields:  ListNull(StringType),
+		Count:   Int64Null(),
+		Enabled: BoolNull(),
+		Auth:    ObjectNull(authTypes),
+		Headers: MapNull(StringType),
+		Nested:  StringValue("inside"),
+	}
+	userConfig, dd = codec.FromModel(&plan)
+	assert.False(t, dd.HasError())
+	assert.Equal(t, map[string]any{
+		"fields":  []any{},
+		"count":   int64(0),
+		"enabled": false,
+		"auth":    map[string]any{},
+		"options": map[string]any{"nested": "inside"},
+	}, userConfig)
+}
+
+func TestValueFromModel(t *testing.T) {
+	value, dd := modelutils.ValueFromModel(ListValueMust(StringType, []attr.Value{StringValue(".a"), StringNull()}), "fields")
+	assert.False(t, dd.HasError())
+	assert.Equal(t, []any{".a"}, value)
+
+	value, dd = modelutils.ValueFromModel(DynamicValue(StringValue("dynamic")), "dynamic")
+	assert.Nil(t, value)
+	assert.True(t, dd.HasError())
+	assert.Equal(t, "Unsupported user_config value", dd.Errors()[0].Summary())
+}
+
+func TestUserConfigCodecToModel(t *testing.T) {
+	plan := codecModel{
+		Id:      StringValue("unchanged"),
+		Fields:  ListNull(StringType),
+		Count:   Int64Value(1),
+		Enabled: BoolValue(true),
+		Auth:    ObjectNull(authTypes),
+		Headers: MapNull(StringType),
+		Nested:  StringNull(),
+	}
+	dd := codec.ToModel(&plan, map[string]any{
+		"fields":  []any{".a"},
+		"count":   float64(5),
+		"enabled": nil,
+		"auth":    map[string]any{"user": "me"},
+		"options": map[string]any{"nested": "inside"},
+	})
+
+	assert.False(t, dd.HasError())
+	assert.Equal(t, StringValue("unchanged"), plan.Id)
+	assert.Equal(t, ListValueMust(StringType, []attr.Value{StringValue(".a")}), plan.Fields)
+	assert.Equal(t, Int64Value(5), plan.Count)
+	// Null in the user_config, like the converters of the models did before the codec
+	assert.Equal(t, BoolValue(true), plan.Enabled)
+	assert.Equal(t, ObjectValueMust(authTypes, map[string]attr.Value{
+		"user":     StringValue("me"),
+		"password": StringNull(),
+	}), plan.Auth)
+	// Missing from the user_config
+	assert.Equal(t, MapNull(StringType), plan.Headers)
+	assert.Equal(t, StringValue("inside"), plan.Nested)
+
+	// Values of another type are reported, and leave the field unchanged
+	dd = codec.ToModel(&plan, map[string]any{"count": "5", "fields": []any{".b", float64(1)}})
+	assert.Len(t, dd.Errors(), 2)
+	assert.Equal(t, "Unexpected user_config value", dd.Errors()[0].Summary())
+	assert.Contains(t, dd.Errors()[0].Detail(), "type float64 for fields.1")
+	assert.Contains(t, dd.Errors()[1].Detail(), "type string for count")
+	assert.Equal(t, Int64Value(5), plan.Count)
+	assert.Equal(t, ListValueMust(StringType, []attr.Value{StringValue(".a")}), plan.Fields)
+}
+
+func TestNewUserConfigCodec(t *testing.T) {
+	type missingModel struct {
+		Unknown String `tfsdk:"unknown" user_config:"true"`
+	}
+	assert.Panics(t, func() {
+		modelutils.NewUserConfigCodec[missingModel](codecSchema, nil)
+	})
+	assert.Panics(t, func() {
+		modelutils.NewUserConfigCodec[codecModel](codecSchema, map[string]modelutils.UserConfigHook{"id": {}})
+	})
+}
+
+func TestConditionalUserConfigHook(t *testing.T) {
+	type conditionalModel struct {
+		Exclude Object `tfsdk:"exclude" user_config:"true"`
+	}
+	exclude := modelutils.ParentConditionalAttribute(modelutils.Non_Change_Operator_Labels)
+	conditionalCodec := modelutils.NewUserConfigCodec[conditionalModel](schema.Schema{
+		Attributes: map[string]schema.Attribute{"exclude": exclude},
+	}, map[string]modelutils.UserConfigHook{
+		"exclude": modelutils.ConditionalUserConfigHook("exclude", modelutils.Non_Change_Operator_Labels),
+	})
+
+	plan := conditionalModel{Exclude: ObjectNull(exclude.GetType().(ObjectType).AttrTypes)}
+	userConfig, _ := conditionalCodec.FromModel(&plan)
+	assert.Equal(t, map[string]any{}, userConfig)
+
+	conditionalCodec.ToModel(&plan, map[string]any{
+		"exclude": map[string]any{
+			"expressions": []any{
+				map[string]any{"field": ".status", "str_operator": "equal", "value": float64(500), "negate": true},
+			},
+			"logical_operation": "OR",
+		},
+	})
+	assert.False(t, plan.Exclude.IsNull())
+	userConfig, _ = conditionalCodec.FromModel(&plan)
+	assert.Equal(t, map[string]any{
+		"exclude": map[string]any{
+			"expressions": []map[string]any{
+				{"field": ".status", "str_operator": "equal", "value": float64(500), "negate": true},
+			},
+			"logical_operation": "OR",
+		},
+	}, userConfig)
+}
@@ -0,0 +1,356 @@
+package modelutils
+
+import (
+	"encoding/json"
+	"fmt"
+	"math/big"
+	"reflect"
+
+	"github.com/hashicorp/terraform-plugin-framework/attr"
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/types/basetypes"
+)
+
+// Converts a field that is not sent as is in the `user_config`, e.g. a conditional, or a value
+// that the API nests in another object. Either function can be left nil to use the codec's
+// conversion for that direction.
+type UserConfigHook struct {
+	// Sets the value of the field in `userConfig`. Null and unknown values are passed too.
+	FromModel func(value attr.Value, userConfig map[string]any)
+	// Returns the value of the field, or false to leave the field of the model unchanged
+	ToModel func(userConfig map[string]any) (attr.Value, bool)
+	// Leaves the field out of the `user_config` when it is null, instead of sending the zero
+	// value of its type
+	OmitNull bool
+}
+
+type userConfigField struct {
+	index    int
+	name     string
+	attrType attr.Type
+	hook     UserConfigHook
+}
+
+// Maps the fields of a model that are tagged with `user_config:"true"` to and from the
+// `user_config` of its component. Values are stored under the `tfsdk` name of the field,
+// and the schema gives the types of the values read from the API.
+type UserConfigCodec[M any] struct {
+	fields []userConfigField
+}
+
+// Creates the codec of a model. Hooks are keyed by the `tfsdk` name of the field they convert.
+// Panics when a tagged field is not in the schema, or a hook is not for a tagged field.
+func NewUserConfigCodec[M any](s schema.Schema, hooks map[string]UserConfigHook) UserConfigCodec[M] {
+	modelType := reflect.TypeOf((*M)(nil)).Elem()
+	codec := UserConfigCodec[M]{}
+	for i := 0; i < modelType.NumField(); i++ {
+		field := modelType.Field(i)
+		if field.Tag.Get("user_config") != "true" {
+			continue
+		}
+		name := field.Tag.Get("tfsdk")
+		attribute, ok := s.Attributes[name]
+		if !ok {
+			panic(fmt.Errorf("Field %s of %s has no attribute %q in the schema. Developer error.", field.Name, modelType, name))
+		}
+		codec.fields = append(codec.fields, userConfigField{
+			index:    i,
+			name:     name,
+			attrType: attribute.GetType(),
+			hook:     hooks[name],
+		})
+	}
+	for name := range hooks {
+		if !codec.hasField(name) {
+			panic(fmt.Errorf("Hook %q is not for a user_config field of %s. Developer error.", name, modelType))
+		}
+	}
+	return codec
+}
+
+func (c UserConfigCodec[M]) hasField(name string) bool {
+	for _, field := range c.fields {
+		if field.name == name {
+			return true
+		}
+	}
+	return false
+}
+
+// Returns the `user_config` of the plan. Null and unknown values are sent as the zero value of
+// their type, e.g. an empty list, unless their hook omits them.
+func (c UserConfigCodec[M]) FromModel(plan *M) (map[string]any, diag.Diagnostics) {
+	dd := diag.Diagnostics{}
+	userConfig := make(map[string]any, len(c.fields))
+	model := reflect.ValueOf(plan).Elem()
+	for _, field := range c.fields {
+		value := model.Field(field.index).Interface().(attr.Value)
+		if field.hook.FromModel != nil {
+			field.hook.FromModel(value, userConfig)
+			continue
+		}
+		if value.IsNull() || value.IsUnknown() {
+			if field.hook.OmitNull {
+				continue
+			}
+			converted, diags := zeroValue(field.attrType, field.name)
+			dd.Append(diags...)
+			userConfig[field.name] = converted
+			continue
+		}
+		converted, diags := ValueFromModel(value, field.name)
+		dd.Append(diags...)
+		userConfig[field.name] = converted
+	}
+	return userConfig, dd
+}
+
+// Sets the fields of the plan from the `user_config` of the component. Fields that are missing
+// from it or null in it are left unchanged. Values that do not match the type of their field
+// are reported as errors.
+func (c UserConfigCodec[M]) ToModel(plan *M, userConfig map[string]any) diag.Diagnostics {
+	dd := diag.Diagnostics{}
+	model := reflect.ValueOf(plan).Elem()
+	for _, field := range c.fields {
+		var value attr.Value
+		if field.hook.ToModel != nil {
+			converted, ok := field.hook.ToModel(userConfig)
+			if !ok {
+				continue
+			}
+			value = converted
+		} else {
+			raw := userConfig[field.name]
+			if raw == nil {
+				continue
+			}
+			converted, diags := ValueToModel(raw, field.attrType, field.name)
+			if dd.Append(diags...); diags.HasError() {
+				continue
+			}
+			value = converted
+		}
+		model.Field(field.index).Set(reflect.ValueOf(value))
+	}
+	return dd
+}
+
+// Converts a terraform value to the value sent to the API. Null and unknown values return nil,
+// and are left out of objects and lists. `name` is only used to describe unsupported values.
+func ValueFromModel(value attr.Value, name string) (any, diag.Diagnostics) {
+	dd := diag.Diagnostics{}
+	if value.IsNull() || value.IsUnknown() {
+		return nil, dd
+	}
+	switch value := value.(type) {
+	case basetypes.StringValue:
+		return value.ValueString(), dd
+	case basetypes.Int64Value:
+		return value.ValueInt64(), dd
+	case basetypes.Float64Value:
+		return value.ValueFloat64(), dd
+	case basetypes.NumberValue:
+		number, _ := value.ValueBigFloat().Float64()
+		return number, dd
+	case basetypes.BoolValue:
+		return value.ValueBool(), dd
+	case basetypes.ListValue:
+		return elementsFromModel(value.Elements(), name)
+	case basetypes.SetValue:
+		return elementsFromModel(value.Elements(), name)
+	case basetypes.MapValue:
+		return attributesFromModel(value.Elements(), name)
+	case basetypes.ObjectValue:
+		return attributesFromModel(value.Attributes(), name)
+	}
+	dd.AddError(
+		"Unsupported user_config value",
+		fmt.Sprintf("The value of %s has the unsupported type %T. Please report this issue to Mezmo.", name, value),
+	)
+	return nil, dd
+}
+
+func elementsFromModel(elements []attr.Value, name string) ([]any, diag.Diagnostics) {
+	dd := diag.Diagnostics{}
+	result := make([]any, 0, len(elements))
+	for i, element := range elements {
+		converted, diags := ValueFromModel(element, fmt.Sprintf("%s.%d", name, i))
+		dd.Append(diags...)
+		if converted != nil {
+			result = append(result, converted)
+		}
+	}
+	return result, dd
+}
+
+func attributesFromModel(attributes map[string]attr.Value, name string) (map[string]any, diag.Diagnostics) {
+	dd := diag.Diagnostics{}
+	result := make(map[string]any, len(attributes))
+	for key, attribute := range attributes {
+		converted, diags := ValueFromModel(attribute, name+"."+key)
+		dd.Append(diags...)
+		if converted != nil {
+			result[key] = converted
+		}
+	}
+	return result, dd
+}
+
+// The value sent for a null field, e.g. an empty string or list
+func zeroValue(attrType attr.Type, name string) (any, diag.Diagnostics) {
+	dd := diag.Diagnostics{}
+	switch attrType.(type) {
+	case basetypes.StringType:
+		return "", dd
+	case basetypes.Int64Type:
+		return int64(0), dd
+	case basetypes.Float64Type, basetypes.NumberType:
+		return float64(0), dd
+	case basetypes.BoolType:
+		return false, dd
+	case basetypes.ListType, basetypes.SetType:
+		return []any{}, dd
+	case basetypes.MapType, basetypes.ObjectType:
+		return map[string]any{}, dd
+	}
+	dd.AddError(
+		"Unsupported user_config value",
+		fmt.Sprintf("The attribute %s has the unsupported type %s. Please report this issue to Mezmo.", name, attrType),
+	)
+	return nil, dd
+}
+
+// Converts a value of an API response to a terraform value of the given type. Values that do
+// not match the type are reported as errors. `name` is only used to describe them.
+func ValueToModel(value any, attrType attr.Type, name string) (attr.Value, diag.Diagnostics) {
+	dd := diag.Diagnostics{}
+	if value == nil {
+		if null, ok := nullValue(attrType); ok {
+			return null, dd
+		}
+		dd.AddError(
+			"Unsupported user_config value",
+			fmt.Sprintf("The attribute %s has the unsupported type %s. Please report this issue to Mezmo.", name, attrType),
+		)
+		return nil, dd
+	}
+	switch attrType := attrType.(type) {
+	case basetypes.StringType:
+		if s, ok := value.(string); ok {
+			return basetypes.NewStringValue(s), dd
+		}
+	case basetypes.Int64Type:
+		if n, ok := numberFromJson(value); ok {
+			return basetypes.NewInt64Value(int64(n)), dd
+		}
+	case basetypes.Float64Type:
+		if n, ok := numberFromJson(value); ok {
+			return basetypes.NewFloat64Value(n), dd
+		}
+	case basetypes.NumberType:
+		if n, ok := numberFromJson(value); ok {
+			return basetypes.NewNumberValue(big.NewFloat(n)), dd
+		}
+	case basetypes.BoolType:
+		if b, ok := value.(bool); ok {
+			return basetypes.NewBoolValue(b), dd
+		}
+	case basetypes.ListType:
+		if list, ok := value.([]any); ok {
+			elements, diags := elementsToModel(list, attrType.ElemType, name)
+			if diags.HasError() {
+				return nil, diags
+			}
+			return basetypes.NewListValueMust(attrType.ElemType, elements), dd
+		}
+	case basetypes.SetType:
+		if list, ok := value.([]any); ok {
+			elements, diags := elementsToModel(list, attrType.ElemType, name)
+			if diags.HasError() {
+				return nil, diags
+			}
+			return basetypes.NewSetValueMust(attrType.ElemType, elements), dd
+		}
+	case basetypes.MapType:
+		if m, ok := value.(map[string]any); ok {
+			elements := make(map[string]attr.Value, len(m))
+			for key, element := range m {
+				converted, diags := ValueToModel(element, attrType.ElemType, name+"."+key)
+				if diags.HasError() {
+					return nil, diags
+				}
+				elements[key] = converted
+			}
+			return basetypes.NewMapValueMust(attrType.ElemType, elements), dd
+		}
+	case basetypes.ObjectType:
+		if m, ok := value.(map[string]any); ok {
+			attributes := make(map[string]attr.Value, len(attrType.AttrTypes))
+			for key, t := range attrType.AttrTypes {
+				converted, diags := ValueToModel(m[key], t, name+"."+key)
+				if diags.HasError() {
+					return nil, diags
+				}
+				attributes[key] = converted
+			}
+			return basetypes.NewObjectValueMust(attrType.AttrTypes, attributes), dd
+		}
+	}
+	dd.AddError(
+		"Unexpected user_config value",
+		fmt.Sprintf("The Mezmo API returned a value of type %T for %s, which does not match its type %s.", value, name, attrType),
+	)
+	return nil, dd
+}
+
+func elementsToModel(list []any, elemType attr.Type, name string) ([]attr.Value, diag.Diagnostics) {
+	elements := make([]attr.Value, 0, len(list))
+	for i, element := range list {
+		converted, diags := ValueToModel(element, elemType, fmt.Sprintf("%s.%d", name, i))
+		if diags.HasError() {
+			return nil, diags
+		}
+		elements = append(elements, converted)
+	}
+	return elements, diag.Diagnostics{}
+}
+
+func numberFromJson(value any) (float64, bool) {
+	switch n := value.(type) {
+	case float64:
+		return n, true
+	case int64:
+		return float64(n), true
+	case int:
+		return float64(n), true
+	case json.Number:
+		f, err := n.Float64()
+		return f, err == nil
+	}
+	return 0, false
+}
+
+func nullValue(attrType attr.Type) (attr.Value, bool) {
+	switch attrType := attrType.(type) {
+	case basetypes.StringType:
+		return basetypes.NewStringNull(), true
+	case basetypes.Int64Type:
+		return basetypes.NewInt64Null(), true
+	case basetypes.Float64Type:
+		return basetypes.NewFloat64Null(), true
+	case basetypes.NumberType:
+		return basetypes.NewNumberNull(), true
+	case basetypes.BoolType:
+		return basetypes.NewBoolNull(), true
+	case basetypes.ListType:
+		return basetypes.NewListNull(attrType.ElemType), true
+	case basetypes.SetType:
+		return basetypes.NewSetNull(attrType.ElemType), true
+	case basetypes.MapType:
+		return basetypes.NewMapNull(attrType.ElemType), true
+	case basetypes.ObjectType:
+		return basetypes.NewObjectNull(attrType.AttrTypes), true
+	}
+	return nil, false
+}
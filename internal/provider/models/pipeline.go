@@ -1,18 +1,27 @@
 package models
 
 import (
+	"fmt"
 	"time"
 
+	"github.com/hashicorp/terraform-plugin-framework-validators/stringvalidator"
+	"github.com/hashicorp/terraform-plugin-framework/attr"
+	datasourceSchema "github.com/hashicorp/terraform-plugin-framework/datasource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/path"
 	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/booldefault"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
 	. "github.com/hashicorp/terraform-plugin-framework/types"
 	. "github.com/mezmo/terraform-provider-mezmo/v5/internal/client"
 )
 
 type PipelineResourceModel struct {
-	Id        String `tfsdk:"id"`
-	Title     String `tfsdk:"title"`
-	CreatedAt String `tfsdk:"created_at"`
-	UpdatedAt String `tfsdk:"updated_at"`
+	Id               String `tfsdk:"id"`
+	Title            String `tfsdk:"title"`
+	CreatedAt        String `tfsdk:"created_at"`
+	UpdatedAt        String `tfsdk:"updated_at"`
+	StrictMembership Bool   `tfsdk:"strict_membership"`
+	ComponentIds     Set    `tfsdk:"component_ids"`
 }
 
 func PipelineResourceSchema() schema.Schema {
@@ -30,6 +39,63 @@ func PipelineResourceSchema() schema.Schema {
 			"updated_at": schema.StringAttribute{
 				Computed: true,
 			},
+			"strict_membership": schema.BoolAttribute{
+				Description: "When enabled, refreshing the pipeline lists its sources, processors, " +
+					"destinations and alerts, and warns about any that are missing from `component_ids`, " +
+					"e.g. components added through the UI. The refreshed list is saved by the next apply, " +
+					"which acknowledges them. Since the pipeline is applied before its components, " +
+					"components created by Terraform are acknowledged the same way.",
+				Optional: true,
+				Computed: true,
+				Default:  booldefault.StaticBool(false),
+			},
+			"component_ids": schema.SetAttribute{
+				Description: "The ids of the pipeline's sources, processors, destinations and alerts as of " +
+					"the last refresh. Only set when `strict_membership` is enabled.",
+				ElementType: StringType,
+				Computed:    true,
+			},
+		},
+	}
+}
+
+type PipelineDataSourceModel struct {
+	Id        String `tfsdk:"id"`
+	Title     String `tfsdk:"title"`
+	CreatedAt String `tfsdk:"created_at"`
+	UpdatedAt String `tfsdk:"updated_at"`
+}
+
+func PipelineDataSourceSchema() datasourceSchema.Schema {
+	return datasourceSchema.Schema{
+		Description: "Look up an existing pipeline by its id or by its exact title.",
+		Attributes: map[string]datasourceSchema.Attribute{
+			"id": datasourceSchema.StringAttribute{
+				Description: "The id of the pipeline. Exactly one of `id` or `title` must be specified.",
+				Optional:    true,
+				Computed:    true,
+				Validators: []validator.String{
+					stringvalidator.LengthAtLeast(1),
+					stringvalidator.ExactlyOneOf(path.MatchRoot("title")),
+				},
+			},
+			"title": datasourceSchema.StringAttribute{
+				Description: "The exact title of the pipeline. The lookup fails if more than one pipeline " +
+					"has this title. Exactly one of `id` or `title` must be specified.",
+				Optional: true,
+				Computed: true,
+				Validators: []validator.String{
+					stringvalidator.LengthAtLeast(1),
+				},
+			},
+			"created_at": datasourceSchema.StringAttribute{
+				Description: "The time the pipeline was created.",
+				Computed:    true,
+			},
+			"updated_at": datasourceSchema.StringAttribute{
+				Description: "The time the pipeline was last updated.",
+				Computed:    true,
+			},
 		},
 	}
 }
@@ -58,3 +124,75 @@ func PipelineToModel(plan *PipelineResourceModel, pipeline *Pipeline) {
 		plan.UpdatedAt = StringValue(pipeline.CreatedAt.Format(time.RFC3339Nano))
 	}
 }
+
+// A component found in a pipeline, as reported by strict membership checks
+type PipelineComponent struct {
+	Id    string
+	Kind  string
+	Type  string
+	Title string
+}
+
+func (c PipelineComponent) String() string {
+	if c.Title == "" {
+		return fmt.Sprintf("%s %s (%s)", c.Type, c.Kind, c.Id)
+	}
+	return fmt.Sprintf("%s %s %q (%s)", c.Type, c.Kind, c.Title, c.Id)
+}
+
+// Lists every node and alert of a pipeline graph
+func PipelineGraphComponents(graph *PipelineGraph) []PipelineComponent {
+	var components []PipelineComponent
+	for _, source := range graph.Sources {
+		components = append(components, PipelineComponent{source.Id, "source", source.Type, source.Title})
+	}
+	for _, processor := range graph.Processors {
+		components = append(components, PipelineComponent{processor.Id, "processor", processor.Type, processor.Title})
+	}
+	for _, destination := range graph.Destinations {
+		components = append(components, PipelineComponent{destination.Id, "destination", destination.Type, destination.Title})
+	}
+	for _, alert := range graph.Alerts {
+		var alertType, name string
+		if evaluation, ok := alert.AlertConfig["evaluation"].(map[string]any); ok {
+			alertType, _ = evaluation["alert_type"].(string)
+		}
+		if general, ok := alert.AlertConfig["general"].(map[string]any); ok {
+			name, _ = general["name"].(string)
+		}
+		components = append(components, PipelineComponent{alert.Id, "alert", alertType, name})
+	}
+	return components
+}
+
+// Returns the components whose ids are not in `known`
+func UnmanagedPipelineComponents(components []PipelineComponent, known Set) []PipelineComponent {
+	knownIds := make(map[string]bool, len(known.Elements()))
+	for _, id := range known.Elements() {
+		knownIds[id.(String).ValueString()] = true
+	}
+	var unmanaged []PipelineComponent
+	for _, component := range components {
+		if !knownIds[component.Id] {
+			unmanaged = append(unmanaged, component)
+		}
+	}
+	return unmanaged
+}
+
+func PipelineComponentIds(components []PipelineComponent) Set {
+	ids := make([]attr.Value, 0, len(components))
+	for _, component := range components {
+		ids = append(ids, StringValue(component.Id))
+	}
+	return SetValueMust(StringType, ids)
+}
+
+func PipelineToDataSourceModel(model *PipelineDataSourceModel, pipeline *Pipeline) {
+	var resourceModel PipelineResourceModel
+	PipelineToModel(&resourceModel, pipeline)
+	model.Id = resourceModel.Id
+	model.Title = resourceModel.Title
+	model.CreatedAt = resourceModel.CreatedAt
+	model.UpdatedAt = resourceModel.UpdatedAt
+}
@@ -0,0 +1,91 @@
+package models
+
+import (
+	"sort"
+	"strings"
+
+	. "github.com/hashicorp/terraform-plugin-framework/types/basetypes"
+)
+
+type PipelineGraphResourceModel struct {
+	Id         StringValue `tfsdk:"id"`
+	PipelineId StringValue `tfsdk:"pipeline_id"`
+	Nodes      MapValue    `tfsdk:"nodes"`
+}
+
+// Splits a node input into the key of the node it references and, for processors with
+// several outputs, the name of the output
+func SplitGraphInput(input string) (key string, output string) {
+	key, output, _ = strings.Cut(input, ".")
+	return key, output
+}
+
+// Orders the node keys so that every node comes after the nodes used as its inputs. When the
+// inputs form a cycle, the keys of the nodes that could not be ordered are returned instead.
+func PipelineGraphOrder(inputs map[string][]string) (order []string, cycle []string) {
+	dependencies := make(map[string]map[string]bool, len(inputs))
+	for key, keyInputs := range inputs {
+		dependencies[key] = make(map[string]bool)
+		for _, input := range keyInputs {
+			if dependency, _ := SplitGraphInput(input); dependency != "" {
+				if _, isNode := inputs[dependency]; isNode {
+					dependencies[key][dependency] = true
+				}
+			}
+		}
+	}
+
+	order = make([]string, 0, len(inputs))
+	for len(dependencies) > 0 {
+		ready := []string{}
+		for key, keyDependencies := range dependencies {
+			if len(keyDependencies) == 0 {
+				ready = append(ready, key)
+			}
+		}
+		if len(ready) == 0 {
+			for key := range dependencies {
+				cycle = append(cycle, key)
+			}
+			sort.Strings(cycle)
+			return nil, cycle
+		}
+		sort.Strings(ready)
+		for _, key := range ready {
+			delete(dependencies, key)
+			for _, keyDependencies := range dependencies {
+				delete(keyDependencies, key)
+			}
+		}
+		order = append(order, ready...)
+	}
+	return order, nil
+}
+
+// Replaces the node keys in `inputs` with the ids of their components. Inputs that do not
+// reference a node are kept as they are.
+func ResolveGraphInputs(inputs []string, ids map[string]string) []string {
+	resolved := make([]string, 0, len(inputs))
+	for _, input := range inputs {
+		key, output := SplitGraphInput(input)
+		id, ok := ids[key]
+		switch {
+		case !ok:
+			resolved = append(resolved, input)
+		case output == "":
+			resolved = append(resolved, id)
+		default:
+			resolved = append(resolved, id+"."+output)
+		}
+	}
+	return resolved
+}
+
+// The reverse of `ResolveGraphInputs`, for inputs read from the API
+func GraphInputsFromIds(inputs []string, ids map[string]string) []string {
+	keys := make(map[string]string, len(ids))
+	for key, id := range ids {
+		keys[id] = key
+	}
+	return ResolveGraphInputs(inputs, keys)
+}
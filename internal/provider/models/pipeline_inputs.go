@@ -0,0 +1,118 @@
+package models
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+
+	. "github.com/mezmo/terraform-provider-mezmo/v5/internal/client"
+)
+
+// The kinds of components whose `inputs` are checked against the pipeline
+type InputConsumerKind string
+
+const (
+	INPUT_CONSUMER_PROCESSOR   InputConsumerKind = "processor"
+	INPUT_CONSUMER_DESTINATION InputConsumerKind = "destination"
+	INPUT_CONSUMER_ALERT       InputConsumerKind = "alert"
+	INPUT_CONSUMER_SINK_ALERT  InputConsumerKind = "sink_alert" // Watches the data reaching a destination
+)
+
+const unmatchedOutputSuffix = "._unmatched"
+
+// Checks the planned `inputs` of a component against the components of its pipeline, and
+// returns a message for each input that the API would reject. `componentId` is empty when the
+// component does not exist yet.
+func PipelineInputErrors(graph *PipelineGraph, kind InputConsumerKind, componentId string, inputs []string) []string {
+	// Ids that can be used as an input, mapped to the id of the component producing them
+	producers := make(map[string]string)
+	// Processors with several outputs, which have to be referenced through one of them
+	multipleOutputs := make(map[string][]string)
+	// Processor inputs, used to walk the graph upstream when looking for cycles
+	upstream := make(map[string][]string)
+
+	for _, source := range graph.Sources {
+		producers[source.Id] = source.Id
+	}
+	for _, processor := range graph.Processors {
+		upstream[processor.Id] = processor.Inputs
+		if len(processor.Outputs) == 0 {
+			producers[processor.Id] = processor.Id
+			continue
+		}
+		for _, output := range processor.Outputs {
+			producers[output.Id] = processor.Id
+			multipleOutputs[processor.Id] = append(multipleOutputs[processor.Id], output.Id)
+		}
+	}
+	destinations := make(map[string]bool)
+	for _, destination := range graph.Destinations {
+		destinations[destination.Id] = true
+	}
+
+	errors := []string{}
+	for _, input := range inputs {
+		if producer, ok := producers[input]; ok {
+			if producer == componentId {
+				errors = append(errors, fmt.Sprintf("Input %q is an output of this component.", input))
+			} else if (kind == INPUT_CONSUMER_ALERT || kind == INPUT_CONSUMER_SINK_ALERT) && strings.HasSuffix(input, unmatchedOutputSuffix) {
+				errors = append(errors, fmt.Sprintf(
+					"Input %q is the unmatched output of a processor, which cannot be used by an alert.", input,
+				))
+			}
+			continue
+		}
+		if outputs, ok := multipleOutputs[input]; ok {
+			sort.Strings(outputs)
+			errors = append(errors, fmt.Sprintf(
+				"Input %q is a processor with several outputs. Use one of them instead: %s.",
+				input, strings.Join(outputs, ", "),
+			))
+		} else if destinations[input] {
+			// Alerts on a destination watch the data that reaches it
+			if kind != INPUT_CONSUMER_SINK_ALERT {
+				errors = append(errors, fmt.Sprintf("Input %q is a destination, which has no outputs.", input))
+			}
+		} else {
+			errors = append(errors, fmt.Sprintf(
+				"Input %q is not a component of pipeline %s.", input, graph.Pipeline.Id,
+			))
+		}
+	}
+
+	// Only processors feed other components, so only they can close a cycle
+	if kind == INPUT_CONSUMER_PROCESSOR && componentId != "" && len(errors) == 0 {
+		upstream[componentId] = inputs
+		if cycle := inputCycle(componentId, producers, upstream); cycle != nil {
+			errors = append(errors, fmt.Sprintf("Inputs create a cycle: %s.", strings.Join(cycle, " -> ")))
+		}
+	}
+	return errors
+}
+
+// Walks the graph upstream from a component. When it leads back to the component, returns
+// the ids along the cycle in the direction that data flows.
+func inputCycle(componentId string, producers map[string]string, upstream map[string][]string) []string {
+	visited := make(map[string]bool)
+	var walk func(id string) []string
+	walk = func(id string) []string {
+		for _, input := range upstream[id] {
+			producer, ok := producers[input]
+			if !ok {
+				continue
+			}
+			if producer == componentId {
+				return []string{componentId, id}
+			}
+			if visited[producer] {
+				continue
+			}
+			visited[producer] = true
+			if cycle := walk(producer); cycle != nil {
+				return append(cycle, id)
+			}
+		}
+		return nil
+	}
+	return walk(componentId)
+}
@@ -0,0 +1,99 @@
+package models
+
+import (
+	"sort"
+	"time"
+
+	"github.com/hashicorp/terraform-plugin-framework-validators/stringvalidator"
+	"github.com/hashicorp/terraform-plugin-framework/attr"
+	"github.com/hashicorp/terraform-plugin-framework/datasource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
+	. "github.com/hashicorp/terraform-plugin-framework/types/basetypes"
+	. "github.com/mezmo/terraform-provider-mezmo/v5/internal/client"
+)
+
+type PipelineRevisionsDataSourceModel struct {
+	PipelineId        StringValue `tfsdk:"pipeline_id"`
+	PublishedRevision StringValue `tfsdk:"published_revision"`
+	Revisions         ListValue   `tfsdk:"revisions"`
+}
+
+var pipelineRevisionAttrTypes = map[string]attr.Type{
+	"id":           StringType{},
+	"published_at": StringType{},
+	"published_by": StringType{},
+}
+
+func PipelineRevisionsDataSourceSchema() schema.Schema {
+	return schema.Schema{
+		Description: "Lists the published revisions of a pipeline, newest first. A revision can be " +
+			"published again with the `revision` attribute of `mezmo_publish_pipeline`.",
+		Attributes: map[string]schema.Attribute{
+			"pipeline_id": schema.StringAttribute{
+				Description: "The id of the pipeline.",
+				Required:    true,
+				Validators: []validator.String{
+					stringvalidator.LengthAtLeast(1),
+				},
+			},
+			"published_revision": schema.StringAttribute{
+				Description: "The id of the revision that is currently published.",
+				Computed:    true,
+			},
+			"revisions": schema.ListNestedAttribute{
+				Description: "The published revisions of the pipeline.",
+				Computed:    true,
+				NestedObject: schema.NestedAttributeObject{
+					Attributes: map[string]schema.Attribute{
+						"id": schema.StringAttribute{
+							Description: "The id of the revision.",
+							Computed:    true,
+						},
+						"published_at": schema.StringAttribute{
+							Description: "When the revision was published, in RFC 3339 format.",
+							Computed:    true,
+						},
+						"published_by": schema.StringAttribute{
+							Description: "The user who published the revision.",
+							Computed:    true,
+						},
+					},
+				},
+			},
+		},
+	}
+}
+
+// From API responses to a terraform model
+func PipelineRevisionsToModel(model *PipelineRevisionsDataSourceModel, pipeline *Pipeline, revisions []PipelineRevision) {
+	model.PublishedRevision = NewStringNull()
+	if pipeline.PublishedRevisionId != "" {
+		model.PublishedRevision = NewStringValue(pipeline.PublishedRevisionId)
+	}
+
+	sorted := append([]PipelineRevision{}, revisions...)
+	sort.SliceStable(sorted, func(i, j int) bool {
+		if sorted[i].PublishedAt == nil || sorted[j].PublishedAt == nil {
+			return sorted[j].PublishedAt == nil && sorted[i].PublishedAt != nil
+		}
+		return sorted[i].PublishedAt.After(*sorted[j].PublishedAt)
+	})
+
+	values := make([]attr.Value, 0, len(sorted))
+	for _, revision := range sorted {
+		publishedAt := NewStringNull()
+		if revision.PublishedAt != nil {
+			publishedAt = NewStringValue(revision.PublishedAt.UTC().Format(time.RFC3339))
+		}
+		publishedBy := NewStringNull()
+		if revision.PublishedBy != "" {
+			publishedBy = NewStringValue(revision.PublishedBy)
+		}
+		values = append(values, NewObjectValueMust(pipelineRevisionAttrTypes, map[string]attr.Value{
+			"id":           NewStringValue(revision.Id),
+			"published_at": publishedAt,
+			"published_by": publishedBy,
+		}))
+	}
+	model.Revisions = NewListValueMust(ObjectType{AttrTypes: pipelineRevisionAttrTypes}, values)
+}
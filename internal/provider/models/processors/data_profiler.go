@@ -65,14 +65,18 @@ func ListAttributeSchemaGenerator(attributeName string) schema.ListAttribute {
 	}
 }
 
+var dataProfilerProcessorUserConfig = NewUserConfigCodec[DataProfilerProcessorModel](DataProfilerProcessorResourceSchema, map[string]UserConfigHook{
+	"label_fields": {OmitNull: true},
+})
+
 func DataProfilerProcessorFromModel(plan *DataProfilerProcessorModel, previousState *DataProfilerProcessorModel) (*Processor, diag.Diagnostics) {
-	dd := diag.Diagnostics{}
+	userConfig, dd := dataProfilerProcessorUserConfig.FromModel(plan)
 	component := Processor{
 		BaseNode: BaseNode{
 			Type:        DATA_PROFILER_PROCESSOR_NODE_NAME,
 			Title:       plan.Title.ValueString(),
 			Description: plan.Description.ValueString(),
-			UserConfig:  map[string]any{},
+			UserConfig:  userConfig,
 		},
 	}
 
@@ -82,18 +86,10 @@ func DataProfilerProcessorFromModel(plan *DataProfilerProcessorModel, previousSt
 	}
 	component.Inputs = StringListValueToStringSlice(plan.Inputs)
 
-	component.UserConfig["app_fields"] = StringListValueToStringSlice(plan.AppFields)
-	component.UserConfig["host_fields"] = StringListValueToStringSlice(plan.HostFields)
-	component.UserConfig["level_fields"] = StringListValueToStringSlice(plan.LevelFields)
-	component.UserConfig["line_fields"] = StringListValueToStringSlice(plan.LineFields)
-	if !plan.LabelFields.IsNull() {
-		component.UserConfig["label_fields"] = StringListValueToStringSlice(plan.LabelFields)
-	}
-
 	return &component, dd
 }
 
-func DataProfilerProcessorToModel(plan *DataProfilerProcessorModel, component *Processor) {
+func DataProfilerProcessorToModel(plan *DataProfilerProcessorModel, component *Processor) diag.Diagnostics {
 	plan.Id = NewStringValue(component.Id)
 	if component.Title != "" {
 		plan.Title = NewStringValue(component.Title)
@@ -103,13 +99,5 @@ func DataProfilerProcessorToModel(plan *DataProfilerProcessorModel, component *P
 	}
 	plan.GenerationId = NewInt64Value(component.GenerationId)
 	plan.Inputs = SliceToStringListValue(component.Inputs)
-
-	plan.AppFields = SliceToStringListValue(component.UserConfig["app_fields"].([]any))
-	plan.HostFields = SliceToStringListValue(component.UserConfig["host_fields"].([]any))
-	plan.LevelFields = SliceToStringListValue(component.UserConfig["level_fields"].([]any))
-	plan.LineFields = SliceToStringListValue(component.UserConfig["line_fields"].([]any))
-	if component.UserConfig["label_fields"] != nil {
-		plan.LabelFields = SliceToStringListValue(component.UserConfig["label_fields"].([]any))
-	}
-
+	return dataProfilerProcessorUserConfig.ToModel(plan, component.UserConfig)
 }
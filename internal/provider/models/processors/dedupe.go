@@ -65,14 +65,16 @@ var DedupeProcessorResourceSchema = schema.Schema{
 	}),
 }
 
+var dedupeProcessorUserConfig = NewUserConfigCodec[DedupeProcessorModel](DedupeProcessorResourceSchema, nil)
+
 func DedupeProcessorFromModel(plan *DedupeProcessorModel, previousState *DedupeProcessorModel) (*Processor, diag.Diagnostics) {
-	dd := diag.Diagnostics{}
+	userConfig, dd := dedupeProcessorUserConfig.FromModel(plan)
 	component := Processor{
 		BaseNode: BaseNode{
 			Type:        DEDUPE_PROCESSOR_NODE_NAME,
 			Title:       plan.Title.ValueString(),
 			Description: plan.Description.ValueString(),
-			UserConfig:  make(map[string]any),
+			UserConfig:  userConfig,
 		},
 	}
 
@@ -82,15 +84,11 @@ func DedupeProcessorFromModel(plan *DedupeProcessorModel, previousState *DedupeP
 	}
 
 	component.Inputs = StringListValueToStringSlice(plan.Inputs)
-	component.UserConfig["fields"] = StringListValueToStringSlice(plan.Fields)
-	// Default values make the plan always to have this values defined
-	component.UserConfig["number_of_events"] = plan.NumberOfEvents.ValueInt64()
-	component.UserConfig["comparison_type"] = plan.ComparisonType.ValueString()
 
 	return &component, dd
 }
 
-func DedupeProcessorToModel(plan *DedupeProcessorModel, component *Processor) {
+func DedupeProcessorToModel(plan *DedupeProcessorModel, component *Processor) diag.Diagnostics {
 	plan.Id = StringValue(component.Id)
 	if component.Title != "" {
 		plan.Title = StringValue(component.Title)
@@ -100,11 +98,5 @@ func DedupeProcessorToModel(plan *DedupeProcessorModel, component *Processor) {
 	}
 	plan.GenerationId = Int64Value(component.GenerationId)
 	plan.Inputs = SliceToStringListValue(component.Inputs)
-	plan.Fields = SliceToStringListValue(component.UserConfig["fields"].([]any))
-	if component.UserConfig["number_of_events"] != nil {
-		plan.NumberOfEvents = Int64Value(int64(component.UserConfig["number_of_events"].(float64)))
-	}
-	if component.UserConfig["comparison_type"] != nil {
-		plan.ComparisonType = StringValue(component.UserConfig["comparison_type"].(string))
-	}
+	return dedupeProcessorUserConfig.ToModel(plan, component.UserConfig)
 }
@@ -39,17 +39,17 @@ var DropFieldsProcessorResourceSchema = schema.Schema{
 	}),
 }
 
+var dropFieldsProcessorUserConfig = NewUserConfigCodec[DropFieldsProcessorModel](DropFieldsProcessorResourceSchema, nil)
+
 func DropFieldsProcessorFromModel(plan *DropFieldsProcessorModel, previousState *DropFieldsProcessorModel) (*Processor, diag.Diagnostics) {
-	dd := diag.Diagnostics{}
+	userConfig, dd := dropFieldsProcessorUserConfig.FromModel(plan)
 	component := Processor{
 		BaseNode: BaseNode{
 			Type:        DROP_FIELDS_PROCESSOR_NODE_NAME,
 			Title:       plan.Title.ValueString(),
 			Description: plan.Description.ValueString(),
 			Inputs:      StringListValueToStringSlice(plan.Inputs),
-			UserConfig: map[string]any{
-				"fields": StringListValueToStringSlice(plan.Fields),
-			},
+			UserConfig:  userConfig,
 		},
 	}
 
@@ -61,7 +61,7 @@ func DropFieldsProcessorFromModel(plan *DropFieldsProcessorModel, previousState
 	return &component, dd
 }
 
-func DropFieldsProcessorToModel(plan *DropFieldsProcessorModel, component *Processor) {
+func DropFieldsProcessorToModel(plan *DropFieldsProcessorModel, component *Processor) diag.Diagnostics {
 	plan.Id = StringValue(component.Id)
 	if component.Title != "" {
 		plan.Title = StringValue(component.Title)
@@ -71,5 +71,5 @@ func DropFieldsProcessorToModel(plan *DropFieldsProcessorModel, component *Proce
 	}
 	plan.GenerationId = Int64Value(component.GenerationId)
 	plan.Inputs = SliceToStringListValue(component.Inputs)
-	plan.Fields = SliceToStringListValue(component.UserConfig["fields"].([]any))
+	return dropFieldsProcessorUserConfig.ToModel(plan, component.UserConfig)
 }
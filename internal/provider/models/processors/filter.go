@@ -43,16 +43,18 @@ var FilterProcessorResourceSchema = schema.Schema{
 	}),
 }
 
+var filterProcessorUserConfig = NewUserConfigCodec[FilterProcessorModel](FilterProcessorResourceSchema, map[string]UserConfigHook{
+	"conditional": ConditionalUserConfigHook("conditional", Non_Change_Operator_Labels),
+})
+
 func FilterProcessorFromModel(plan *FilterProcessorModel, previousState *FilterProcessorModel) (*Processor, diag.Diagnostics) {
-	dd := diag.Diagnostics{}
+	userConfig, dd := filterProcessorUserConfig.FromModel(plan)
 	component := &Processor{
 		BaseNode: BaseNode{
 			Type:        FILTER_PROCESSOR_NODE_NAME,
 			Title:       plan.Title.ValueString(),
 			Description: plan.Description.ValueString(),
-			UserConfig: map[string]any{
-				"action": plan.Action.ValueString(),
-			},
+			UserConfig:  userConfig,
 		},
 	}
 
@@ -62,12 +64,11 @@ func FilterProcessorFromModel(plan *FilterProcessorModel, previousState *FilterP
 	}
 
 	component.Inputs = StringListValueToStringSlice(plan.Inputs)
-	component.UserConfig["conditional"] = UnwindConditionalFromModel(plan.Conditional)
 
 	return component, dd
 }
 
-func FilterProcessorToModel(plan *FilterProcessorModel, component *Processor) {
+func FilterProcessorToModel(plan *FilterProcessorModel, component *Processor) diag.Diagnostics {
 	plan.Id = NewStringValue(component.Id)
 	if component.Title != "" {
 		plan.Title = NewStringValue(component.Title)
@@ -77,6 +78,5 @@ func FilterProcessorToModel(plan *FilterProcessorModel, component *Processor) {
 	}
 	plan.GenerationId = NewInt64Value(component.GenerationId)
 	plan.Inputs = SliceToStringListValue(component.Inputs)
-	plan.Action = NewStringValue(component.UserConfig["action"].(string))
-	plan.Conditional = UnwindConditionalToModel(component.UserConfig["conditional"].(map[string]any), Non_Change_Operator_Labels)
+	return filterProcessorUserConfig.ToModel(plan, component.UserConfig)
 }
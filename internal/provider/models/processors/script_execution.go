@@ -37,17 +37,17 @@ var ScriptExecutionProcessorResourceSchema = schema.Schema{
 	}),
 }
 
+var scriptExecutionProcessorUserConfig = NewUserConfigCodec[ScriptExecutionProcessorModel](ScriptExecutionProcessorResourceSchema, nil)
+
 func ScriptExecutionProcessorFromModel(plan *ScriptExecutionProcessorModel, previousState *ScriptExecutionProcessorModel) (*Processor, diag.Diagnostics) {
-	dd := diag.Diagnostics{}
+	userConfig, dd := scriptExecutionProcessorUserConfig.FromModel(plan)
 	component := Processor{
 		BaseNode: BaseNode{
 			Type:        SCRIPT_EXECUTION_PROCESSOR_NODE_NAME,
 			Title:       plan.Title.ValueString(),
 			Description: plan.Description.ValueString(),
 			Inputs:      StringListValueToStringSlice(plan.Inputs),
-			UserConfig: map[string]any{
-				"script": plan.Script.ValueString(),
-			},
+			UserConfig:  userConfig,
 		},
 	}
 
@@ -59,7 +59,7 @@ func ScriptExecutionProcessorFromModel(plan *ScriptExecutionProcessorModel, prev
 	return &component, dd
 }
 
-func ScriptExecutionProcessorToModel(plan *ScriptExecutionProcessorModel, component *Processor) {
+func ScriptExecutionProcessorToModel(plan *ScriptExecutionProcessorModel, component *Processor) diag.Diagnostics {
 	plan.Id = StringValue(component.Id)
 	if component.Title != "" {
 		plan.Title = StringValue(component.Title)
@@ -69,5 +69,5 @@ func ScriptExecutionProcessorToModel(plan *ScriptExecutionProcessorModel, compon
 	}
 	plan.GenerationId = Int64Value(component.GenerationId)
 	plan.Inputs = SliceToStringListValue(component.Inputs)
-	plan.Script = StringValue(component.UserConfig["script"].(string))
+	return scriptExecutionProcessorUserConfig.ToModel(plan, component.UserConfig)
 }
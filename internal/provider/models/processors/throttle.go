@@ -55,17 +55,19 @@ var ThrottleProcessorResourceSchema = schema.Schema{
 	}),
 }
 
+var throttleProcessorUserConfig = NewUserConfigCodec[ThrottleProcessorModel](ThrottleProcessorResourceSchema, map[string]UserConfigHook{
+	"exclude":   ConditionalUserConfigHook("exclude", Non_Change_Operator_Labels),
+	"key_field": {OmitNull: true},
+})
+
 func ThrottleProcessorFromModel(plan *ThrottleProcessorModel, previousState *ThrottleProcessorModel) (*Processor, diag.Diagnostics) {
-	dd := diag.Diagnostics{}
+	userConfig, dd := throttleProcessorUserConfig.FromModel(plan)
 	component := Processor{
 		BaseNode: BaseNode{
 			Type:        THROTTLE_PROCESSOR_NODE_NAME,
 			Title:       plan.Title.ValueString(),
 			Description: plan.Description.ValueString(),
-			UserConfig: map[string]any{
-				"threshold": plan.Threshold.ValueInt64(),
-				"window_ms": plan.WindowMS.ValueInt64(),
-			},
+			UserConfig:  userConfig,
 		},
 	}
 
@@ -76,18 +78,10 @@ func ThrottleProcessorFromModel(plan *ThrottleProcessorModel, previousState *Thr
 
 	component.Inputs = StringListValueToStringSlice(plan.Inputs)
 
-	if !plan.KeyField.IsNull() {
-		component.UserConfig["key_field"] = plan.KeyField.ValueString()
-	}
-
-	if !plan.Exclude.IsNull() {
-		component.UserConfig["exclude"] = UnwindConditionalFromModel(plan.Exclude)
-	}
-
 	return &component, dd
 }
 
-func ThrottleProcessorToModel(plan *ThrottleProcessorModel, component *Processor) {
+func ThrottleProcessorToModel(plan *ThrottleProcessorModel, component *Processor) diag.Diagnostics {
 	plan.Id = StringValue(component.Id)
 	if component.Title != "" {
 		plan.Title = StringValue(component.Title)
@@ -98,14 +92,5 @@ func ThrottleProcessorToModel(plan *ThrottleProcessorModel, component *Processor
 
 	plan.GenerationId = Int64Value(component.GenerationId)
 	plan.Inputs = SliceToStringListValue(component.Inputs)
-	plan.Threshold = Int64Value(int64(component.UserConfig["threshold"].(float64)))
-	plan.WindowMS = Int64Value(int64(component.UserConfig["window_ms"].(float64)))
-
-	if keyField, ok := component.UserConfig["key_field"]; ok {
-		plan.KeyField = StringValue(keyField.(string))
-	}
-
-	if exclude, ok := component.UserConfig["exclude"]; ok {
-		plan.Exclude = UnwindConditionalToModel(exclude.(map[string]any), Non_Change_Operator_Labels)
-	}
+	return throttleProcessorUserConfig.ToModel(plan, component.UserConfig)
 }
@@ -44,18 +44,17 @@ var UnrollProcessorResourceSchema = schema.Schema{
 	}),
 }
 
+var unrollProcessorUserConfig = NewUserConfigCodec[UnrollProcessorModel](UnrollProcessorResourceSchema, nil)
+
 func UnrollProcessorFromModel(plan *UnrollProcessorModel, previousState *UnrollProcessorModel) (*Processor, diag.Diagnostics) {
-	dd := diag.Diagnostics{}
+	userConfig, dd := unrollProcessorUserConfig.FromModel(plan)
 	component := Processor{
 		BaseNode: BaseNode{
 			Type:        UNROLL_PROCESSOR_NODE_NAME,
 			Title:       plan.Title.ValueString(),
 			Description: plan.Description.ValueString(),
 			Inputs:      StringListValueToStringSlice(plan.Inputs),
-			UserConfig: map[string]any{
-				"field":       plan.Field.ValueString(),
-				"values_only": plan.ValuesOnly.ValueBool(),
-			},
+			UserConfig:  userConfig,
 		},
 	}
 
@@ -67,7 +66,7 @@ func UnrollProcessorFromModel(plan *UnrollProcessorModel, previousState *UnrollP
 	return &component, dd
 }
 
-func UnrollProcessorToModel(plan *UnrollProcessorModel, component *Processor) {
+func UnrollProcessorToModel(plan *UnrollProcessorModel, component *Processor) diag.Diagnostics {
 	plan.Id = StringValue(component.Id)
 	if component.Title != "" {
 		plan.Title = StringValue(component.Title)
@@ -77,6 +76,5 @@ func UnrollProcessorToModel(plan *UnrollProcessorModel, component *Processor) {
 	}
 	plan.GenerationId = Int64Value(component.GenerationId)
 	plan.Inputs = SliceToStringListValue(component.Inputs)
-	plan.Field = StringValue(component.UserConfig["field"].(string))
-	plan.ValuesOnly = BoolValue(component.UserConfig["values_only"].(bool))
+	return unrollProcessorUserConfig.ToModel(plan, component.UserConfig)
 }
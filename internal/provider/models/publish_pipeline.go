@@ -1,20 +1,41 @@
 package models
 
 import (
+	"fmt"
+
+	"github.com/hashicorp/terraform-plugin-framework-validators/int64validator"
+	"github.com/hashicorp/terraform-plugin-framework-validators/stringvalidator"
 	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/booldefault"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/int64default"
 	"github.com/hashicorp/terraform-plugin-framework/resource/schema/planmodifier"
 	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringplanmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
 	. "github.com/hashicorp/terraform-plugin-framework/types/basetypes"
 	. "github.com/mezmo/terraform-provider-mezmo/v5/internal/client"
 )
 
+// The default number of seconds to wait for a published pipeline to be running
+const DEFAULT_DEPLOYMENT_TIMEOUT = 600
+
 type PublishPipelineResourceModel struct {
-	PipelineId StringValue `tfsdk:"pipeline_id"`
+	PipelineId        StringValue `tfsdk:"pipeline_id"`
+	Triggers          MapValue    `tfsdk:"triggers"`
+	PublishedRevision StringValue `tfsdk:"published_revision"`
+	Revision          StringValue `tfsdk:"revision"`
+	AlwaysPublish     BoolValue   `tfsdk:"always_publish"`
+	WaitForDeployment BoolValue   `tfsdk:"wait_for_deployment"`
+	DeploymentTimeout Int64Value  `tfsdk:"deployment_timeout"`
 }
 
 func PublishPipelineResourceSchema() schema.Schema {
 	return schema.Schema{
 		MarkdownDescription: "This resource will monitor a pipeline for changes, and publish it when necessary.\n" +
+			"\nA publish is planned when `triggers` or `revision` change, or when the pipeline has changes that " +
+			"were not published yet. Use `triggers` with values that change along with the pipeline's components, " +
+			"such as their `generation_id`, so that changes are published in the same apply that makes them. Set " +
+			"`always_publish` to publish on every apply instead. When the API does not report whether the " +
+			"pipeline has unpublished changes, every apply publishes it.\n" +
 			"\n## Configuration\n" +
 			"To make sure a pipeline and its components exist before publishing, the configuration of this resource " +
 			"requires the use of child modules and `depends_on`. The pipeline's configuration should exist in a " +
@@ -52,6 +73,53 @@ resource "mezmo_pipeline" "my_pipeline" {
 					stringplanmodifier.RequiresReplace(),
 				},
 			},
+			"triggers": schema.MapAttribute{
+				Description: "Arbitrary values that cause the pipeline to be published again whenever they change.",
+				ElementType: StringType{},
+				Optional:    true,
+			},
+			"published_revision": schema.StringAttribute{
+				Description: "The id of the pipeline's published revision.",
+				Computed:    true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.UseStateForUnknown(),
+				},
+			},
+			"revision": schema.StringAttribute{
+				Description: "The id of an earlier revision to publish instead of the pipeline's current " +
+					"components, e.g. to roll back a bad deploy. The revisions of a pipeline are listed by the " +
+					"`mezmo_pipeline_revisions` data source. Changes made to the components are not published " +
+					"while this is set; remove it to publish them again.",
+				Optional: true,
+				Validators: []validator.String{
+					stringvalidator.LengthAtLeast(1),
+				},
+			},
+			"always_publish": schema.BoolAttribute{
+				Description: "Publish the pipeline on every apply, whether or not anything changed. " +
+					"Every plan will show this resource as being created.",
+				Optional: true,
+				Computed: true,
+				Default:  booldefault.StaticBool(false),
+			},
+			"wait_for_deployment": schema.BoolAttribute{
+				Description: "Wait for the published pipeline to be deployed and running. " +
+					"The apply fails if the deployment fails or does not finish within `deployment_timeout`. " +
+					"When the API does not report the deployment status, the apply only warns and does not wait.",
+				Optional: true,
+				Computed: true,
+				Default:  booldefault.StaticBool(false),
+			},
+			"deployment_timeout": schema.Int64Attribute{
+				Description: fmt.Sprintf("The number of seconds to wait for the deployment when "+
+					"`wait_for_deployment` is enabled. Defaults to %d.", DEFAULT_DEPLOYMENT_TIMEOUT),
+				Optional: true,
+				Computed: true,
+				Default:  int64default.StaticInt64(DEFAULT_DEPLOYMENT_TIMEOUT),
+				Validators: []validator.Int64{
+					int64validator.AtLeast(1),
+				},
+			},
 		},
 	}
 }
@@ -68,3 +136,11 @@ func PublishPipelineFromModel(plan *PublishPipelineResourceModel) *PublishPipeli
 func PublishPipelineToModel(plan *PublishPipelineResourceModel, publishPipeline *PublishPipeline) {
 	plan.PipelineId = NewStringValue(publishPipeline.PipelineId)
 }
+
+// Records the published state of a pipeline
+func PublishedPipelineToModel(plan *PublishPipelineResourceModel, pipeline *Pipeline) {
+	plan.PublishedRevision = NewStringNull()
+	if pipeline.PublishedRevisionId != "" {
+		plan.PublishedRevision = NewStringValue(pipeline.PublishedRevisionId)
+	}
+}
@@ -2,6 +2,8 @@ package models
 
 import (
 	"github.com/hashicorp/terraform-plugin-framework-validators/stringvalidator"
+	"github.com/hashicorp/terraform-plugin-framework/attr"
+	datasourceSchema "github.com/hashicorp/terraform-plugin-framework/datasource/schema"
 	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
 	"github.com/hashicorp/terraform-plugin-framework/resource/schema/planmodifier"
 	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringplanmodifier"
@@ -26,11 +28,17 @@ var PUSH_SOURCE_TYPES = []string{
 }
 
 type SharedSourceResourceModel struct {
-	Id          StringValue `tfsdk:"id"`
-	ConsumerId  StringValue `tfsdk:"consumer_id"`
-	Title       StringValue `tfsdk:"title" user_config:"true"`
-	Description StringValue `tfsdk:"description" user_config:"true"`
-	Type        StringValue `tfsdk:"type" user_config:"true"`
+	Id                StringValue `tfsdk:"id"`
+	ConsumerId        StringValue `tfsdk:"consumer_id"`
+	Title             StringValue `tfsdk:"title" user_config:"true"`
+	Description       StringValue `tfsdk:"description" user_config:"true"`
+	Type              StringValue `tfsdk:"type" user_config:"true"`
+	AttachedPipelines ListValue   `tfsdk:"attached_pipelines"`
+}
+
+var sharedSourceAttachmentAttrTypes = map[string]attr.Type{
+	"pipeline_id": StringType{},
+	"source_id":   StringType{},
 }
 
 func SharedSourceResourceSchema() schema.Schema {
@@ -71,6 +79,70 @@ func SharedSourceResourceSchema() schema.Schema {
 					stringplanmodifier.RequiresReplace(),
 				},
 			},
+			"attached_pipelines": schema.ListNestedAttribute{
+				Description: "The pipeline sources that consume the shared source. " +
+					"They stop receiving data when the shared source is deleted.",
+				Computed: true,
+				NestedObject: schema.NestedAttributeObject{
+					Attributes: map[string]schema.Attribute{
+						"pipeline_id": schema.StringAttribute{
+							Description: "The id of the pipeline.",
+							Computed:    true,
+						},
+						"source_id": schema.StringAttribute{
+							Description: "The id of the pipeline source using the shared source.",
+							Computed:    true,
+						},
+					},
+				},
+			},
+		},
+	}
+}
+
+func SharedSourceDataSourceSchema() datasourceSchema.Schema {
+	return datasourceSchema.Schema{
+		Description: "Look up an existing shared source by its id.",
+		Attributes: map[string]datasourceSchema.Attribute{
+			"id": datasourceSchema.StringAttribute{
+				Description: "The id of the shared source.",
+				Required:    true,
+				Validators: []validator.String{
+					stringvalidator.LengthAtLeast(1),
+				},
+			},
+			"consumer_id": datasourceSchema.StringAttribute{
+				Description: "Consumer ID of the shared source.",
+				Computed:    true,
+			},
+			"title": datasourceSchema.StringAttribute{
+				Description: "A descriptive name for the shared source.",
+				Computed:    true,
+			},
+			"description": datasourceSchema.StringAttribute{
+				Description: "Details describing the shared source.",
+				Computed:    true,
+			},
+			"type": datasourceSchema.StringAttribute{
+				Description: "The type of source that is shared, e.g. `http`.",
+				Computed:    true,
+			},
+			"attached_pipelines": datasourceSchema.ListNestedAttribute{
+				Description: "The pipeline sources that consume the shared source.",
+				Computed:    true,
+				NestedObject: datasourceSchema.NestedAttributeObject{
+					Attributes: map[string]datasourceSchema.Attribute{
+						"pipeline_id": datasourceSchema.StringAttribute{
+							Description: "The id of the pipeline.",
+							Computed:    true,
+						},
+						"source_id": datasourceSchema.StringAttribute{
+							Description: "The id of the pipeline source using the shared source.",
+							Computed:    true,
+						},
+					},
+				},
+			},
 		},
 	}
 }
@@ -98,5 +170,16 @@ func SharedSourceToModel(plan *SharedSourceResourceModel, source *SharedSource)
 	plan.Type = NewStringValue(source.Type)
 	if source.Description != "" {
 		plan.Description = NewStringValue(source.Description)
+	} else {
+		plan.Description = NewStringNull()
+	}
+
+	attachments := make([]attr.Value, 0, len(source.AttachedPipelines))
+	for _, attachment := range source.AttachedPipelines {
+		attachments = append(attachments, NewObjectValueMust(sharedSourceAttachmentAttrTypes, map[string]attr.Value{
+			"pipeline_id": NewStringValue(attachment.PipelineId),
+			"source_id":   NewStringValue(attachment.SourceId),
+		}))
 	}
+	plan.AttachedPipelines = NewListValueMust(ObjectType{AttrTypes: sharedSourceAttachmentAttrTypes}, attachments)
 }
@@ -0,0 +1,193 @@
+package provider
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"reflect"
+
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/mezmo/terraform-provider-mezmo/v5/internal/client"
+	. "github.com/mezmo/terraform-provider-mezmo/v5/internal/provider/models/alerts"
+)
+
+var (
+	_ resource.Resource                = &NotificationChannelResource{}
+	_ resource.ResourceWithConfigure   = &NotificationChannelResource{}
+	_ resource.ResourceWithImportState = &NotificationChannelResource{}
+)
+
+func NewNotificationChannelResource() resource.Resource {
+	return &NotificationChannelResource{}
+}
+
+type NotificationChannelResource struct {
+	client client.Client
+}
+
+func (r *NotificationChannelResource) TypeName() string {
+	return PROVIDER_TYPE_NAME + "_notification_channel"
+}
+
+func (r *NotificationChannelResource) NodeType() string {
+	return "notification_channel"
+}
+
+func (r *NotificationChannelResource) TerraformSchema() schema.Schema {
+	return NotificationChannelResourceSchema()
+}
+
+func (*NotificationChannelResource) Schema(_ context.Context, _ resource.SchemaRequest, resp *resource.SchemaResponse) {
+	resp.Schema = NotificationChannelResourceSchema()
+}
+
+func (r *NotificationChannelResource) Metadata(_ context.Context, req resource.MetadataRequest, resp *resource.MetadataResponse) {
+	resp.TypeName = r.TypeName()
+}
+
+func (r *NotificationChannelResource) ImportState(ctx context.Context, req resource.ImportStateRequest, resp *resource.ImportStateResponse) {
+	resource.ImportStatePassthroughID(ctx, path.Root("id"), req, resp)
+}
+
+func (r *NotificationChannelResource) ConvertToTerraformModel(component *reflect.Value) (*reflect.Value, error) {
+	if !component.CanInterface() {
+		return nil, errors.New("component Value does not contain an interfaceable type")
+	}
+
+	channel, ok := component.Interface().(client.NotificationChannel)
+	if !ok {
+		return nil, errors.New("component Value cannot be cast to a Notification Channel")
+	}
+
+	var model NotificationChannelResourceModel
+	NotificationChannelToModel(&model, &channel)
+	res := reflect.ValueOf(model)
+	return &res, nil
+}
+
+// Configure implements resource.ResourceWithConfigure.
+func (r *NotificationChannelResource) Configure(_ context.Context, req resource.ConfigureRequest, resp *resource.ConfigureResponse) {
+	if req.ProviderData == nil {
+		return
+	}
+
+	client, ok := req.ProviderData.(client.Client)
+
+	if !ok {
+		resp.Diagnostics.AddError(
+			"Unexpected Data Source Configure Type",
+			fmt.Sprintf("Expected client.Client, got: %T. Please report this issue to Mezmo.", req.ProviderData),
+		)
+
+		return
+	}
+
+	r.client = client
+}
+
+// POST notification channel
+func (r *NotificationChannelResource) Create(ctx context.Context, req resource.CreateRequest, resp *resource.CreateResponse) {
+	var plan NotificationChannelResourceModel
+	if diags := req.Plan.Get(ctx, &plan); setDiagnosticsHasError(diags, &resp.Diagnostics) {
+		return
+	}
+	channel, dd := NotificationChannelFromModel(&plan)
+	if setDiagnosticsHasError(dd, &resp.Diagnostics) {
+		return
+	}
+
+	stored, err := r.client.CreateNotificationChannel(channel, ctx)
+	if err != nil {
+		addClientErrorDiagnostic(&resp.Diagnostics, err,
+			"Error creating notification channel",
+			"Could not create notification channel, unexpected error: "+err.Error(),
+		)
+		return
+	}
+
+	NotificationChannelToModel(&plan, stored)
+	diags := resp.State.Set(ctx, plan)
+	setDiagnosticsHasError(diags, &resp.Diagnostics)
+}
+
+func (r *NotificationChannelResource) Read(ctx context.Context, req resource.ReadRequest, resp *resource.ReadResponse) {
+	// Get current state
+	var state NotificationChannelResourceModel
+	if diags := req.State.Get(ctx, &state); setDiagnosticsHasError(diags, &resp.Diagnostics) {
+		return
+	}
+
+	channel, err := r.client.NotificationChannel(state.Id.ValueString(), ctx)
+	// force re-creation of manually deleted resources
+	if client.IsNotFoundError(err) {
+		resp.State.RemoveResource(ctx)
+		return
+	}
+	if err != nil {
+		addClientErrorDiagnostic(&resp.Diagnostics, err,
+			"Error Reading Notification Channel",
+			"Could not read notification channel with id "+state.Id.ValueString()+": "+err.Error(),
+		)
+		return
+	}
+
+	NotificationChannelToModel(&state, channel)
+	diags := resp.State.Set(ctx, state)
+	setDiagnosticsHasError(diags, &resp.Diagnostics)
+}
+
+func (r *NotificationChannelResource) Update(ctx context.Context, req resource.UpdateRequest, resp *resource.UpdateResponse) {
+	// Retrieve values from plan
+	var plan NotificationChannelResourceModel
+	if diags := req.Plan.Get(ctx, &plan); setDiagnosticsHasError(diags, &resp.Diagnostics) {
+		return
+	}
+	var state NotificationChannelResourceModel
+	if diags := req.State.Get(ctx, &state); setDiagnosticsHasError(diags, &resp.Diagnostics) {
+		return
+	}
+
+	channel, dd := NotificationChannelFromModel(&plan)
+	if setDiagnosticsHasError(dd, &resp.Diagnostics) {
+		return
+	}
+	// Set id from the current state
+	channel.Id = state.Id.ValueString()
+
+	stored, err := r.client.UpdateNotificationChannel(channel, ctx)
+	if err != nil {
+		addClientErrorDiagnostic(&resp.Diagnostics, err,
+			"Error Updating Notification Channel",
+			"Could not update notification channel, unexpected error: "+err.Error(),
+		)
+		return
+	}
+
+	NotificationChannelToModel(&plan, stored)
+	diags := resp.State.Set(ctx, plan)
+	resp.Diagnostics.Append(diags...)
+}
+
+// Delete implements resource.Resource.
+func (r *NotificationChannelResource) Delete(ctx context.Context, req resource.DeleteRequest, resp *resource.DeleteResponse) {
+	var state NotificationChannelResourceModel
+	if diags := req.State.Get(ctx, &state); setDiagnosticsHasError(diags, &resp.Diagnostics) {
+		return
+	}
+
+	err := r.client.DeleteNotificationChannel(state.Id.ValueString(), ctx)
+	if client.IsNotFoundError(err) {
+		// If not found, just ignore and let TF clean up state on its own
+		return
+	}
+	if err != nil {
+		addClientErrorDiagnostic(&resp.Diagnostics, err,
+			"Error Deleting Notification Channel",
+			"Could not delete notification channel, unexpected error: "+err.Error(),
+		)
+		return
+	}
+}
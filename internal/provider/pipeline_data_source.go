@@ -0,0 +1,116 @@
+package provider
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/hashicorp/terraform-plugin-framework/datasource"
+	"github.com/mezmo/terraform-provider-mezmo/v5/internal/client"
+	. "github.com/mezmo/terraform-provider-mezmo/v5/internal/provider/models"
+)
+
+var (
+	_ datasource.DataSource              = &PipelineDataSource{}
+	_ datasource.DataSourceWithConfigure = &PipelineDataSource{}
+)
+
+func NewPipelineDataSource() datasource.DataSource {
+	return &PipelineDataSource{}
+}
+
+type PipelineDataSource struct {
+	client client.Client
+}
+
+func (d *PipelineDataSource) TypeName() string {
+	return PROVIDER_TYPE_NAME + "_pipeline"
+}
+
+// Configure implements datasource.DataSourceWithConfigure.
+func (d *PipelineDataSource) Configure(_ context.Context, req datasource.ConfigureRequest, resp *datasource.ConfigureResponse) {
+	if req.ProviderData == nil {
+		return
+	}
+
+	client, ok := req.ProviderData.(client.Client)
+
+	if !ok {
+		resp.Diagnostics.AddError(
+			"Unexpected Data Source Configure Type",
+			fmt.Sprintf("Expected client.Client, got: %T. Please report this issue to Mezmo.", req.ProviderData),
+		)
+
+		return
+	}
+
+	d.client = client
+}
+
+// Metadata implements datasource.DataSource.
+func (d *PipelineDataSource) Metadata(_ context.Context, _ datasource.MetadataRequest, resp *datasource.MetadataResponse) {
+	resp.TypeName = d.TypeName()
+}
+
+// Schema implements datasource.DataSource.
+func (d *PipelineDataSource) Schema(_ context.Context, _ datasource.SchemaRequest, resp *datasource.SchemaResponse) {
+	resp.Schema = PipelineDataSourceSchema()
+}
+
+// Read implements datasource.DataSource.
+func (d *PipelineDataSource) Read(ctx context.Context, req datasource.ReadRequest, resp *datasource.ReadResponse) {
+	var config PipelineDataSourceModel
+	if diags := req.Config.Get(ctx, &config); setDiagnosticsHasError(diags, &resp.Diagnostics) {
+		return
+	}
+
+	var pipeline *client.Pipeline
+	if !config.Id.IsNull() {
+		found, err := d.client.Pipeline(config.Id.ValueString(), ctx)
+		if err != nil {
+			addClientErrorDiagnostic(&resp.Diagnostics, err,
+				"Error Reading Pipeline",
+				"Could not read pipeline with id "+config.Id.ValueString()+": "+err.Error(),
+			)
+			return
+		}
+		pipeline = found
+	} else {
+		title := config.Title.ValueString()
+		pipelines, err := d.client.ListPipelines(ctx)
+		if err != nil {
+			addClientErrorDiagnostic(&resp.Diagnostics, err,
+				"Error Reading Pipelines",
+				"Could not list pipelines, unexpected error: "+err.Error(),
+			)
+			return
+		}
+		matches := make([]client.Pipeline, 0)
+		for _, p := range pipelines {
+			if p.Title == title {
+				matches = append(matches, p)
+			}
+		}
+		if len(matches) == 0 {
+			resp.Diagnostics.AddError(
+				"Pipeline Not Found",
+				fmt.Sprintf("No pipeline was found with the title %q.", title),
+			)
+			return
+		}
+		if len(matches) > 1 {
+			resp.Diagnostics.AddError(
+				"Multiple Pipelines Found",
+				fmt.Sprintf(
+					"%d pipelines were found with the title %q. Use `id` to select a specific pipeline.",
+					len(matches), title,
+				),
+			)
+			return
+		}
+		pipeline = &matches[0]
+	}
+
+	PipelineToDataSourceModel(&config, pipeline)
+	diags := resp.State.Set(ctx, config)
+	resp.Diagnostics.Append(diags...)
+}
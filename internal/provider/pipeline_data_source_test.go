@@ -0,0 +1,61 @@
+package provider
+
+import (
+	"regexp"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-testing/helper/resource"
+	. "github.com/mezmo/terraform-provider-mezmo/v5/internal/provider/providertest"
+)
+
+func TestPipelineDataSource(t *testing.T) {
+	cacheKey := "pipeline_data_source_tests"
+	resource.Test(t, resource.TestCase{
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		PreCheck:                 func() { TestPreCheck(t) },
+		Steps: []resource.TestStep{
+			// Either id or title is required
+			{
+				Config: GetProviderConfig() + `
+					data "mezmo_pipeline" "lookup" {
+					}`,
+				ExpectError: regexp.MustCompile("Invalid Attribute Combination"),
+			},
+			// Look up by id
+			{
+				Config: SetCachedConfig(cacheKey, `
+					resource "mezmo_pipeline" "test" {
+						title = "data source lookup pipeline"
+					}`) + `
+					data "mezmo_pipeline" "lookup" {
+						id = mezmo_pipeline.test.id
+					}`,
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttrPair("data.mezmo_pipeline.lookup", "id", "mezmo_pipeline.test", "id"),
+					resource.TestCheckResourceAttr("data.mezmo_pipeline.lookup", "title", "data source lookup pipeline"),
+					resource.TestCheckResourceAttrSet("data.mezmo_pipeline.lookup", "created_at"),
+					resource.TestCheckResourceAttrSet("data.mezmo_pipeline.lookup", "updated_at"),
+				),
+			},
+			// Look up by title
+			{
+				Config: GetCachedConfig(cacheKey) + `
+					data "mezmo_pipeline" "lookup" {
+						title = mezmo_pipeline.test.title
+					}`,
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttrPair("data.mezmo_pipeline.lookup", "id", "mezmo_pipeline.test", "id"),
+					resource.TestCheckResourceAttr("data.mezmo_pipeline.lookup", "title", "data source lookup pipeline"),
+				),
+			},
+			// Unknown title
+			{
+				Config: GetCachedConfig(cacheKey) + `
+					data "mezmo_pipeline" "lookup" {
+						title = "this pipeline title does not exist"
+					}`,
+				ExpectError: regexp.MustCompile("No pipeline was found with the title"),
+			},
+		},
+	})
+}
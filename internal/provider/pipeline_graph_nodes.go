@@ -0,0 +1,211 @@
+package provider
+
+import (
+	"context"
+	"strings"
+
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/types/basetypes"
+	"github.com/mezmo/terraform-provider-mezmo/v5/internal/client"
+)
+
+// A type of component that can be a node of `mezmo_pipeline_graph`. Its functions work on
+// objects holding every attribute of the component's own resource.
+type graphNodeType struct {
+	name   string // The resource type name without the provider prefix, e.g. http_source
+	kind   string // source, processor or destination
+	schema schema.Schema
+	// Creates the component when there is no `state`, updates it otherwise
+	apply func(ctx context.Context, c client.Client, pipelineId string, plan basetypes.ObjectValue, state *basetypes.ObjectValue) (basetypes.ObjectValue, diag.Diagnostics)
+	// Returns false when the component does not exist anymore
+	read   func(ctx context.Context, c client.Client, pipelineId string, state basetypes.ObjectValue) (basetypes.ObjectValue, bool, diag.Diagnostics)
+	delete func(ctx context.Context, c client.Client, pipelineId string, id string) error
+	// Reads the component and sets its user_config and inputs back to those of `state`. Other
+	// fields, such as the generation id, are kept as they are now.
+	restore func(ctx context.Context, c client.Client, pipelineId string, state basetypes.ObjectValue) (basetypes.ObjectValue, diag.Diagnostics)
+}
+
+// Implemented by the component resources that can be used in `mezmo_pipeline_graph`
+type graphNodeResource interface {
+	graphNodeType() graphNodeType
+}
+
+type componentOps[C any] struct {
+	get    func(pipelineId string, id string, ctx context.Context) (*C, error)
+	create func(pipelineId string, component *C, ctx context.Context) (*C, error)
+	update func(pipelineId string, component *C, ctx context.Context) (*C, error)
+	delete func(pipelineId string, id string, ctx context.Context) error
+	base   func(component *C) *client.BaseNode
+}
+
+func newGraphNodeType[T ComponentModel, C any](
+	typeName string,
+	kind string,
+	s schema.Schema,
+	fromModel func(*T, *T) (*C, diag.Diagnostics),
+	toModel func(*T, *C) diag.Diagnostics,
+	ops func(client.Client) componentOps[C],
+) graphNodeType {
+	attrTypes := s.Type().(basetypes.ObjectType).AttrTypes
+	toObject := func(ctx context.Context, model *T) (basetypes.ObjectValue, diag.Diagnostics) {
+		return basetypes.NewObjectValueFrom(ctx, attrTypes, model)
+	}
+	fromObject := func(ctx context.Context, object basetypes.ObjectValue) (*T, diag.Diagnostics) {
+		var model T
+		diags := object.As(ctx, &model, basetypes.ObjectAsOptions{})
+		return &model, diags
+	}
+
+	return graphNodeType{
+		name:   strings.TrimPrefix(typeName, PROVIDER_TYPE_NAME+"_"),
+		kind:   kind,
+		schema: s,
+		apply: func(ctx context.Context, c client.Client, pipelineId string, plan basetypes.ObjectValue, state *basetypes.ObjectValue) (basetypes.ObjectValue, diag.Diagnostics) {
+			var diags diag.Diagnostics
+			model, dd := fromObject(ctx, plan)
+			if setDiagnosticsHasError(dd, &diags) {
+				return plan, diags
+			}
+			var previous *T
+			if state != nil {
+				if previous, dd = fromObject(ctx, *state); setDiagnosticsHasError(dd, &diags) {
+					return plan, diags
+				}
+			}
+			component, dd := fromModel(model, previous)
+			if setDiagnosticsHasError(dd, &diags) {
+				return plan, diags
+			}
+
+			var stored *C
+			var err error
+			if previous == nil {
+				stored, err = ops(c).create(pipelineId, component, ctx)
+			} else {
+				stored, err = ops(c).update(pipelineId, component, ctx)
+			}
+			if err != nil {
+				addClientErrorDiagnostic(&diags, err,
+					"Error Applying "+kind,
+					"Could not apply "+typeName+", unexpected error: "+err.Error(),
+				)
+				return plan, diags
+			}
+
+			NullifyPlanFields(model, s)
+			if dd := toModel(model, stored); setDiagnosticsHasError(dd, &diags) {
+				return plan, diags
+			}
+			object, dd := toObject(ctx, model)
+			diags.Append(dd...)
+			return object, diags
+		},
+		read: func(ctx context.Context, c client.Client, pipelineId string, state basetypes.ObjectValue) (basetypes.ObjectValue, bool, diag.Diagnostics) {
+			var diags diag.Diagnostics
+			model, dd := fromObject(ctx, state)
+			if setDiagnosticsHasError(dd, &diags) {
+				return state, true, diags
+			}
+			id := state.Attributes()["id"].(basetypes.StringValue).ValueString()
+			component, err := ops(c).get(pipelineId, id, ctx)
+			if client.IsNotFoundError(err) {
+				return state, false, diags
+			}
+			if err != nil {
+				addClientErrorDiagnostic(&diags, err,
+					"Error Reading "+kind,
+					"Could not read "+typeName+" with id "+id+": "+err.Error(),
+				)
+				return state, true, diags
+			}
+
+			NullifyPlanFields(model, s)
+			if dd := toModel(model, component); setDiagnosticsHasError(dd, &diags) {
+				return state, true, diags
+			}
+			object, dd := toObject(ctx, model)
+			diags.Append(dd...)
+			return object, true, diags
+		},
+		delete: func(ctx context.Context, c client.Client, pipelineId string, id string) error {
+			return ops(c).delete(pipelineId, id, ctx)
+		},
+		restore: func(ctx context.Context, c client.Client, pipelineId string, state basetypes.ObjectValue) (basetypes.ObjectValue, diag.Diagnostics) {
+			var diags diag.Diagnostics
+			model, dd := fromObject(ctx, state)
+			if setDiagnosticsHasError(dd, &diags) {
+				return state, diags
+			}
+			prior, dd := fromModel(model, nil)
+			if setDiagnosticsHasError(dd, &diags) {
+				return state, diags
+			}
+			id := state.Attributes()["id"].(basetypes.StringValue).ValueString()
+			current, err := ops(c).get(pipelineId, id, ctx)
+			if err != nil {
+				addClientErrorDiagnostic(&diags, err,
+					"Error Reading "+kind,
+					"Could not read "+typeName+" with id "+id+": "+err.Error(),
+				)
+				return state, diags
+			}
+
+			base := ops(c).base(current)
+			base.UserConfig = ops(c).base(prior).UserConfig
+			base.Inputs = ops(c).base(prior).Inputs
+			stored, err := ops(c).update(pipelineId, current, ctx)
+			if err != nil {
+				addClientErrorDiagnostic(&diags, err,
+					"Error Applying "+kind,
+					"Could not restore "+typeName+" with id "+id+", unexpected error: "+err.Error(),
+				)
+				return state, diags
+			}
+
+			NullifyPlanFields(model, s)
+			if dd := toModel(model, stored); setDiagnosticsHasError(dd, &diags) {
+				return state, diags
+			}
+			object, dd := toObject(ctx, model)
+			diags.Append(dd...)
+			return object, diags
+		},
+	}
+}
+
+func (r *SourceResource[T]) graphNodeType() graphNodeType {
+	return newGraphNodeType[T, client.Source](
+		r.TypeName(), "Source", r.schema, r.fromModelFunc, r.toModelFunc,
+		func(c client.Client) componentOps[client.Source] {
+			return componentOps[client.Source]{
+				c.Source, c.CreateSource, c.UpdateSource, c.DeleteSource,
+				func(component *client.Source) *client.BaseNode { return &component.BaseNode },
+			}
+		},
+	)
+}
+
+func (r *ProcessorResource[T]) graphNodeType() graphNodeType {
+	return newGraphNodeType[T, client.Processor](
+		r.TypeName(), "Processor", r.schema, r.fromModelFunc, r.toModelFunc,
+		func(c client.Client) componentOps[client.Processor] {
+			return componentOps[client.Processor]{
+				c.Processor, c.CreateProcessor, c.UpdateProcessor, c.DeleteProcessor,
+				func(component *client.Processor) *client.BaseNode { return &component.BaseNode },
+			}
+		},
+	)
+}
+
+func (r *DestinationResource[T]) graphNodeType() graphNodeType {
+	return newGraphNodeType[T, client.Destination](
+		r.TypeName(), "Destination", r.schema, r.fromModelFunc, r.toModelFunc,
+		func(c client.Client) componentOps[client.Destination] {
+			return componentOps[client.Destination]{
+				c.Destination, c.CreateDestination, c.UpdateDestination, c.DeleteDestination,
+				func(component *client.Destination) *client.BaseNode { return &component.BaseNode },
+			}
+		},
+	)
+}
@@ -0,0 +1,753 @@
+package provider
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"strings"
+	"sync"
+
+	"github.com/hashicorp/terraform-plugin-framework-validators/stringvalidator"
+	"github.com/hashicorp/terraform-plugin-framework/attr"
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/planmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringplanmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
+	"github.com/hashicorp/terraform-plugin-framework/tfsdk"
+	"github.com/hashicorp/terraform-plugin-framework/types/basetypes"
+	"github.com/mezmo/terraform-provider-mezmo/v5/internal/client"
+	. "github.com/mezmo/terraform-provider-mezmo/v5/internal/provider/models"
+)
+
+var (
+	_ resource.Resource                   = &PipelineGraphResource{}
+	_ resource.ResourceWithConfigure      = &PipelineGraphResource{}
+	_ resource.ResourceWithValidateConfig = &PipelineGraphResource{}
+	_ resource.ResourceWithModifyPlan     = &PipelineGraphResource{}
+)
+
+func NewPipelineGraphResource() resource.Resource {
+	return &PipelineGraphResource{}
+}
+
+type PipelineGraphResource struct {
+	client client.Client
+}
+
+// Attributes of the component resources that are set by the graph itself
+var graphManagedAttributes = []string{"id", "pipeline_id", "inputs"}
+
+var (
+	graphNodeTypesOnce sync.Once
+	graphNodeTypes     map[string]graphNodeType
+	graphSchema        schema.Schema
+	graphNodeAttrTypes map[string]attr.Type
+)
+
+// The node types are collected from the provider's resources, so they are loaded lazily
+func loadGraphNodeTypes() {
+	graphNodeTypesOnce.Do(func() {
+		graphNodeTypes = make(map[string]graphNodeType)
+		for _, newResource := range (&MezmoProvider{}).Resources(context.Background()) {
+			if r, ok := newResource().(graphNodeResource); ok {
+				nodeType := r.graphNodeType()
+				graphNodeTypes[nodeType.name] = nodeType
+			}
+		}
+		graphSchema = pipelineGraphResourceSchema(graphNodeTypes)
+		nodes := graphSchema.Attributes["nodes"].(schema.MapNestedAttribute)
+		graphNodeAttrTypes = nodes.NestedObject.Type().(basetypes.ObjectType).AttrTypes
+	})
+}
+
+func PipelineGraphResourceSchema() schema.Schema {
+	loadGraphNodeTypes()
+	return graphSchema
+}
+
+func pipelineGraphResourceSchema(nodeTypes map[string]graphNodeType) schema.Schema {
+	nodeAttributes := map[string]schema.Attribute{
+		"id": schema.StringAttribute{
+			Description: "The id of the component created for the node.",
+			Computed:    true,
+			PlanModifiers: []planmodifier.String{
+				stringplanmodifier.UseStateForUnknown(),
+			},
+		},
+		"inputs": schema.ListAttribute{
+			Description: "The keys of the nodes used as inputs. For processors with several outputs, such as " +
+				"`route`, use `<key>.<output>`, e.g. `my_route._unmatched`. Ids of components that are " +
+				"not part of the graph are used as they are.",
+			ElementType: basetypes.StringType{},
+			Optional:    true,
+		},
+	}
+	for name, nodeType := range nodeTypes {
+		attributes := make(map[string]schema.Attribute, len(nodeType.schema.Attributes))
+		for attributeName, attribute := range nodeType.schema.Attributes {
+			if !isGraphManagedAttribute(attributeName) {
+				attributes[attributeName] = attribute
+			}
+		}
+		nodeAttributes[name] = schema.SingleNestedAttribute{
+			Description: fmt.Sprintf(
+				"A `%s_%s` node. It accepts the attributes of that resource except `pipeline_id` and `inputs`.",
+				PROVIDER_TYPE_NAME, name,
+			),
+			Optional:   true,
+			Attributes: attributes,
+		}
+	}
+
+	return schema.Schema{
+		Description: "Manages the sources, processors and destinations of a pipeline as a single resource. " +
+			"Nodes are created, updated and deleted in the order of their inputs during one apply. If a " +
+			"change fails, the changes already made by that apply are rolled back.",
+		Attributes: map[string]schema.Attribute{
+			"id": schema.StringAttribute{
+				Description: "The id of the pipeline.",
+				Computed:    true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.UseStateForUnknown(),
+				},
+			},
+			"pipeline_id": schema.StringAttribute{
+				Description: "The id of the pipeline that the nodes belong to.",
+				Required:    true,
+				Validators: []validator.String{
+					stringvalidator.LengthAtLeast(1),
+				},
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.RequiresReplace(),
+				},
+			},
+			"nodes": schema.MapNestedAttribute{
+				Description: "The components of the pipeline, by a key that is unique within the graph. Each " +
+					"node sets exactly one component block, such as `http_source` or `route_processor`.",
+				Required: true,
+				NestedObject: schema.NestedAttributeObject{
+					Attributes: nodeAttributes,
+				},
+			},
+		},
+	}
+}
+
+func isGraphManagedAttribute(name string) bool {
+	for _, managed := range graphManagedAttributes {
+		if name == managed {
+			return true
+		}
+	}
+	return false
+}
+
+func (r *PipelineGraphResource) TypeName() string {
+	return PROVIDER_TYPE_NAME + "_pipeline_graph"
+}
+
+func (r *PipelineGraphResource) NodeType() string {
+	return "pipeline_graph"
+}
+
+func (r *PipelineGraphResource) TerraformSchema() schema.Schema {
+	return PipelineGraphResourceSchema()
+}
+
+func (r *PipelineGraphResource) NotConvertible() bool {
+	// Pipelines are exported with one resource per component
+	return true
+}
+
+func (r *PipelineGraphResource) Metadata(_ context.Context, _ resource.MetadataRequest, resp *resource.MetadataResponse) {
+	resp.TypeName = r.TypeName()
+}
+
+func (r *PipelineGraphResource) Schema(_ context.Context, _ resource.SchemaRequest, resp *resource.SchemaResponse) {
+	resp.Schema = PipelineGraphResourceSchema()
+}
+
+// Configure implements resource.ResourceWithConfigure.
+func (r *PipelineGraphResource) Configure(_ context.Context, req resource.ConfigureRequest, resp *resource.ConfigureResponse) {
+	if req.ProviderData == nil {
+		return
+	}
+
+	client, ok := req.ProviderData.(client.Client)
+	if !ok {
+		resp.Diagnostics.AddError(
+			"Unexpected Resource Configure Type",
+			fmt.Sprintf("Expected client.Client, got: %T. Please report this issue to Mezmo.", req.ProviderData),
+		)
+		return
+	}
+
+	r.client = client
+}
+
+// A node of the graph, with its component block
+type graphNode struct {
+	key      string
+	object   basetypes.ObjectValue // The node as stored in the graph's nodes
+	nodeType graphNodeType
+	block    basetypes.ObjectValue
+	inputs   basetypes.ListValue
+}
+
+func (n *graphNode) id() basetypes.StringValue {
+	return n.object.Attributes()["id"].(basetypes.StringValue)
+}
+
+func (n *graphNode) inputKeys() []string {
+	keys := []string{}
+	for _, input := range n.inputs.Elements() {
+		if value, ok := input.(basetypes.StringValue); ok && !value.IsUnknown() && !value.IsNull() {
+			keys = append(keys, value.ValueString())
+		}
+	}
+	return keys
+}
+
+// The names of the component blocks set on a node object
+func graphNodeBlocks(object basetypes.ObjectValue) []string {
+	names := []string{}
+	for name, value := range object.Attributes() {
+		if _, isType := graphNodeTypes[name]; isType && !value.IsNull() {
+			names = append(names, name)
+		}
+	}
+	sort.Strings(names)
+	return names
+}
+
+func parseGraphNodes(nodes basetypes.MapValue) (map[string]*graphNode, diag.Diagnostics) {
+	var diags diag.Diagnostics
+	parsed := make(map[string]*graphNode, len(nodes.Elements()))
+	for key, value := range nodes.Elements() {
+		object := value.(basetypes.ObjectValue)
+		blocks := graphNodeBlocks(object)
+		if len(blocks) != 1 {
+			diags.AddAttributeError(
+				path.Root("nodes").AtMapKey(key),
+				"Invalid Pipeline Graph Node",
+				fmt.Sprintf("Node %q must set exactly one component block, found %d.", key, len(blocks)),
+			)
+			continue
+		}
+		parsed[key] = &graphNode{
+			key:      key,
+			object:   object,
+			nodeType: graphNodeTypes[blocks[0]],
+			block:    object.Attributes()[blocks[0]].(basetypes.ObjectValue),
+			inputs:   object.Attributes()["inputs"].(basetypes.ListValue),
+		}
+	}
+	return parsed, diags
+}
+
+func graphInputs(nodes map[string]*graphNode) map[string][]string {
+	inputs := make(map[string][]string, len(nodes))
+	for key, node := range nodes {
+		inputs[key] = node.inputKeys()
+	}
+	return inputs
+}
+
+func graphIds(nodes map[string]*graphNode) map[string]string {
+	ids := make(map[string]string, len(nodes))
+	for key, node := range nodes {
+		if id := node.id(); !id.IsNull() && !id.IsUnknown() {
+			ids[key] = id.ValueString()
+		}
+	}
+	return ids
+}
+
+// Builds an object holding every attribute of the node's component resource
+func (n *graphNode) component(id basetypes.StringValue, pipelineId string, ids map[string]string) (basetypes.ObjectValue, diag.Diagnostics) {
+	attrTypes := n.nodeType.schema.Type().(basetypes.ObjectType).AttrTypes
+	values := make(map[string]attr.Value, len(attrTypes))
+	for name, value := range n.block.Attributes() {
+		values[name] = value
+	}
+	values["id"] = id
+	values["pipeline_id"] = basetypes.NewStringValue(pipelineId)
+	if _, hasInputs := attrTypes["inputs"]; hasInputs {
+		if n.inputs.IsNull() {
+			values["inputs"] = basetypes.NewListUnknown(basetypes.StringType{})
+		} else {
+			inputs := []attr.Value{}
+			for _, input := range ResolveGraphInputs(n.inputKeys(), ids) {
+				inputs = append(inputs, basetypes.NewStringValue(input))
+			}
+			values["inputs"] = basetypes.NewListValueMust(basetypes.StringType{}, inputs)
+		}
+	}
+	return basetypes.NewObjectValue(attrTypes, values)
+}
+
+// Builds the node object from the attributes of its component resource
+func (n *graphNode) withComponent(component basetypes.ObjectValue, inputs basetypes.ListValue) (basetypes.ObjectValue, diag.Diagnostics) {
+	componentValues := component.Attributes()
+	values := make(map[string]attr.Value, len(graphNodeAttrTypes))
+	for name, attrType := range graphNodeAttrTypes {
+		if objectType, isBlock := attrType.(basetypes.ObjectType); isBlock {
+			values[name] = basetypes.NewObjectNull(objectType.AttrTypes)
+		}
+	}
+	blockType := graphNodeAttrTypes[n.nodeType.name].(basetypes.ObjectType)
+	blockValues := make(map[string]attr.Value, len(blockType.AttrTypes))
+	for name := range blockType.AttrTypes {
+		blockValues[name] = componentValues[name]
+	}
+	block, diags := basetypes.NewObjectValue(blockType.AttrTypes, blockValues)
+	values[n.nodeType.name] = block
+	values["id"] = componentValues["id"]
+	values["inputs"] = inputs
+
+	object, dd := basetypes.NewObjectValue(graphNodeAttrTypes, values)
+	diags.Append(dd...)
+	return object, diags
+}
+
+func (r *PipelineGraphResource) ValidateConfig(ctx context.Context, req resource.ValidateConfigRequest, resp *resource.ValidateConfigResponse) {
+	loadGraphNodeTypes()
+	var nodes basetypes.MapValue
+	if diags := req.Config.GetAttribute(ctx, path.Root("nodes"), &nodes); setDiagnosticsHasError(diags, &resp.Diagnostics) {
+		return
+	}
+	if nodes.IsNull() || nodes.IsUnknown() {
+		return
+	}
+	// Nodes that are not known yet are validated by the plan
+	elements := map[string]attr.Value{}
+	for key, value := range nodes.Elements() {
+		if !value.IsUnknown() {
+			elements[key] = value
+		}
+	}
+	known := basetypes.NewMapValueMust(nodes.ElementType(ctx), elements)
+
+	parsed, diags := parseGraphNodes(known)
+	resp.Diagnostics.Append(diags...)
+	for key, node := range parsed {
+		if node.nodeType.kind == "Source" && !node.inputs.IsNull() {
+			resp.Diagnostics.AddAttributeError(
+				path.Root("nodes").AtMapKey(key).AtName("inputs"),
+				"Invalid Pipeline Graph Node",
+				fmt.Sprintf("Node %q is a source, which does not take inputs.", key),
+			)
+		}
+	}
+	orderGraphNodes(graphInputs(parsed), &resp.Diagnostics)
+}
+
+// Orders the node keys by their inputs, reporting an error when they form a cycle. The
+// configuration is checked for cycles, but values that were unknown then could form one.
+func orderGraphNodes(inputs map[string][]string, diags *diag.Diagnostics) ([]string, bool) {
+	order, cycle := PipelineGraphOrder(inputs)
+	if cycle != nil {
+		diags.AddAttributeError(
+			path.Root("nodes"),
+			"Invalid Pipeline Graph",
+			"The inputs of these nodes form a cycle: "+strings.Join(cycle, ", ")+".",
+		)
+		return nil, false
+	}
+	return order, true
+}
+
+// Plans a new component for nodes that change their component type
+func (r *PipelineGraphResource) ModifyPlan(ctx context.Context, req resource.ModifyPlanRequest, resp *resource.ModifyPlanResponse) {
+	if req.State.Raw.IsNull() || req.Plan.Raw.IsNull() {
+		return
+	}
+	loadGraphNodeTypes()
+	var plan, state PipelineGraphResourceModel
+	if diags := req.Plan.Get(ctx, &plan); setDiagnosticsHasError(diags, &resp.Diagnostics) {
+		return
+	}
+	if diags := req.State.Get(ctx, &state); setDiagnosticsHasError(diags, &resp.Diagnostics) {
+		return
+	}
+	if plan.Nodes.IsUnknown() {
+		return
+	}
+
+	changed := false
+	nodes := make(map[string]attr.Value, len(plan.Nodes.Elements()))
+	for key, value := range plan.Nodes.Elements() {
+		nodes[key] = value
+		prior, ok := state.Nodes.Elements()[key]
+		if !ok || value.IsUnknown() {
+			continue
+		}
+		object := value.(basetypes.ObjectValue)
+		if strings.Join(graphNodeBlocks(object), ",") == strings.Join(graphNodeBlocks(prior.(basetypes.ObjectValue)), ",") {
+			continue
+		}
+		values := object.Attributes()
+		values["id"] = basetypes.NewStringUnknown()
+		nodes[key] = basetypes.NewObjectValueMust(graphNodeAttrTypes, values)
+		changed = true
+	}
+	if changed {
+		plan.Nodes = basetypes.NewMapValueMust(plan.Nodes.ElementType(ctx), nodes)
+		diags := resp.Plan.Set(ctx, plan)
+		setDiagnosticsHasError(diags, &resp.Diagnostics)
+	}
+}
+
+func (r *PipelineGraphResource) Create(ctx context.Context, req resource.CreateRequest, resp *resource.CreateResponse) {
+	loadGraphNodeTypes()
+	var plan PipelineGraphResourceModel
+	if diags := req.Plan.Get(ctx, &plan); setDiagnosticsHasError(diags, &resp.Diagnostics) {
+		return
+	}
+	planNodes, diags := parseGraphNodes(plan.Nodes)
+	if setDiagnosticsHasError(diags, &resp.Diagnostics) {
+		return
+	}
+
+	nodes, ok := r.reconcile(ctx, plan.PipelineId.ValueString(), planNodes, map[string]*graphNode{}, &resp.Diagnostics)
+	if !ok && len(nodes) == 0 {
+		return
+	}
+	r.setState(ctx, &plan, nodes, &resp.State, &resp.Diagnostics)
+}
+
+func (r *PipelineGraphResource) Read(ctx context.Context, req resource.ReadRequest, resp *resource.ReadResponse) {
+	loadGraphNodeTypes()
+	var state PipelineGraphResourceModel
+	if diags := req.State.Get(ctx, &state); setDiagnosticsHasError(diags, &resp.Diagnostics) {
+		return
+	}
+	stateNodes, diags := parseGraphNodes(state.Nodes)
+	if setDiagnosticsHasError(diags, &resp.Diagnostics) {
+		return
+	}
+
+	pipelineId := state.PipelineId.ValueString()
+	ids := graphIds(stateNodes)
+	nodes := make(map[string]attr.Value, len(stateNodes))
+	for key, node := range stateNodes {
+		component, diags := node.component(node.id(), pipelineId, ids)
+		if setDiagnosticsHasError(diags, &resp.Diagnostics) {
+			return
+		}
+		refreshed, found, diags := node.nodeType.read(ctx, r.client, pipelineId, component)
+		if setDiagnosticsHasError(diags, &resp.Diagnostics) {
+			return
+		}
+		if !found {
+			// The component is re-created by the next apply
+			continue
+		}
+
+		// Changes to the inputs made outside of terraform are reported with the node keys
+		inputs := node.inputs
+		if refreshedInputs, ok := refreshed.Attributes()["inputs"].(basetypes.ListValue); ok && !inputs.IsNull() {
+			values := []attr.Value{}
+			for _, input := range refreshedInputs.Elements() {
+				apiInput := input.(basetypes.StringValue).ValueString()
+				values = append(values, basetypes.NewStringValue(GraphInputsFromIds([]string{apiInput}, ids)[0]))
+			}
+			inputs = basetypes.NewListValueMust(basetypes.StringType{}, values)
+		}
+		object, diags := node.withComponent(refreshed, inputs)
+		if setDiagnosticsHasError(diags, &resp.Diagnostics) {
+			return
+		}
+		nodes[key] = object
+	}
+
+	state.Nodes = basetypes.NewMapValueMust(basetypes.ObjectType{AttrTypes: graphNodeAttrTypes}, nodes)
+	diags = resp.State.Set(ctx, state)
+	setDiagnosticsHasError(diags, &resp.Diagnostics)
+}
+
+func (r *PipelineGraphResource) Update(ctx context.Context, req resource.UpdateRequest, resp *resource.UpdateResponse) {
+	loadGraphNodeTypes()
+	var plan, state PipelineGraphResourceModel
+	if diags := req.Plan.Get(ctx, &plan); setDiagnosticsHasError(diags, &resp.Diagnostics) {
+		return
+	}
+	if diags := req.State.Get(ctx, &state); setDiagnosticsHasError(diags, &resp.Diagnostics) {
+		return
+	}
+	planNodes, diags := parseGraphNodes(plan.Nodes)
+	if setDiagnosticsHasError(diags, &resp.Diagnostics) {
+		return
+	}
+	stateNodes, diags := parseGraphNodes(state.Nodes)
+	if setDiagnosticsHasError(diags, &resp.Diagnostics) {
+		return
+	}
+
+	nodes, ok := r.reconcile(ctx, plan.PipelineId.ValueString(), planNodes, stateNodes, &resp.Diagnostics)
+	if !ok && nodes == nil {
+		// Everything was rolled back, so the prior state still applies
+		return
+	}
+	r.setState(ctx, &plan, nodes, &resp.State, &resp.Diagnostics)
+}
+
+func (r *PipelineGraphResource) Delete(ctx context.Context, req resource.DeleteRequest, resp *resource.DeleteResponse) {
+	loadGraphNodeTypes()
+	var state PipelineGraphResourceModel
+	if diags := req.State.Get(ctx, &state); setDiagnosticsHasError(diags, &resp.Diagnostics) {
+		return
+	}
+	stateNodes, diags := parseGraphNodes(state.Nodes)
+	if setDiagnosticsHasError(diags, &resp.Diagnostics) {
+		return
+	}
+	order, ok := orderGraphNodes(graphInputs(stateNodes), &resp.Diagnostics)
+	if !ok {
+		return
+	}
+	for i := len(order) - 1; i >= 0; i-- {
+		node := stateNodes[order[i]]
+		err := node.nodeType.delete(ctx, r.client, state.PipelineId.ValueString(), node.id().ValueString())
+		if err != nil && !client.IsNotFoundError(err) {
+			addClientErrorDiagnostic(&resp.Diagnostics, err,
+				"Error Deleting Pipeline Graph Node",
+				fmt.Sprintf("Could not delete node %q, unexpected error: %s", node.key, err.Error()),
+			)
+		}
+	}
+}
+
+func (r *PipelineGraphResource) setState(ctx context.Context, plan *PipelineGraphResourceModel, nodes map[string]attr.Value, state *tfsdk.State, diags *diag.Diagnostics) {
+	plan.Id = plan.PipelineId
+	plan.Nodes = basetypes.NewMapValueMust(basetypes.ObjectType{AttrTypes: graphNodeAttrTypes}, nodes)
+	dd := state.Set(ctx, plan)
+	diags.Append(dd...)
+}
+
+// A change made while reconciling the graph, kept so that it can be rolled back
+type graphChange struct {
+	node     *graphNode
+	id       string                 // The id of a created component
+	previous *basetypes.ObjectValue // The component before it was updated
+}
+
+// Applies the planned nodes in the order of their inputs, then deletes the components that
+// are not part of the graph anymore. When a node fails, the changes made so far are rolled
+// back. Returns the nodes to store in state, which is nil when the prior state still applies.
+func (r *PipelineGraphResource) reconcile(
+	ctx context.Context, pipelineId string, planNodes map[string]*graphNode, stateNodes map[string]*graphNode, diags *diag.Diagnostics,
+) (map[string]attr.Value, bool) {
+	order, ok := orderGraphNodes(graphInputs(planNodes), diags)
+	if !ok {
+		return nil, false
+	}
+	priorIds := graphIds(stateNodes)
+	ids := make(map[string]string, len(planNodes))
+	results := make(map[string]attr.Value, len(planNodes))
+	changes := []graphChange{}
+	removed := []*graphNode{}
+
+	for _, key := range order {
+		node := planNodes[key]
+		prior, hasPrior := stateNodes[key]
+		if hasPrior && prior.nodeType.name != node.nodeType.name {
+			removed = append(removed, prior)
+			hasPrior = false
+		}
+
+		var previous *basetypes.ObjectValue
+		id := basetypes.NewStringUnknown()
+		if hasPrior {
+			priorComponent, dd := prior.component(prior.id(), pipelineId, priorIds)
+			if setDiagnosticsHasError(dd, diags) {
+				return r.rollback(ctx, pipelineId, changes, stateNodes, results, diags)
+			}
+			previous = &priorComponent
+			id = prior.id()
+		}
+		component, dd := node.component(id, pipelineId, ids)
+		if setDiagnosticsHasError(dd, diags) {
+			return r.rollback(ctx, pipelineId, changes, stateNodes, results, diags)
+		}
+
+		if previous != nil && sameKnownValues(component, *previous) {
+			ids[key] = id.ValueString()
+			results[key] = prior.object
+			continue
+		}
+
+		stored, dd := node.nodeType.apply(ctx, r.client, pipelineId, component, previous)
+		if dd.HasError() {
+			diags.AddAttributeError(
+				path.Root("nodes").AtMapKey(key),
+				"Error Applying Pipeline Graph Node",
+				fmt.Sprintf("Could not apply node %q: %s", key, diagnosticsDetail(dd)),
+			)
+			return r.rollback(ctx, pipelineId, changes, stateNodes, results, diags)
+		}
+		change := graphChange{node: node, previous: previous}
+		storedId := stored.Attributes()["id"].(basetypes.StringValue).ValueString()
+		if previous == nil {
+			change.id = storedId
+		}
+		changes = append(changes, change)
+		ids[key] = storedId
+
+		object, dd := node.withComponent(stored, node.inputs)
+		diags.Append(dd...)
+		results[key] = object
+	}
+
+	// Components are deleted after the nodes using them were updated
+	for key, prior := range stateNodes {
+		if _, kept := planNodes[key]; !kept {
+			removed = append(removed, prior)
+		}
+	}
+	removedInputs := make(map[string][]string, len(removed))
+	removedByKey := make(map[string]*graphNode, len(removed))
+	for _, node := range removed {
+		removedInputs[node.key] = node.inputKeys()
+		removedByKey[node.key] = node
+	}
+	removedOrder, cycle := PipelineGraphOrder(removedInputs)
+	if cycle != nil {
+		diags.AddError(
+			"Error Deleting Pipeline Graph Nodes",
+			"The graph was applied, but the inputs of the removed nodes form a cycle: "+strings.Join(cycle, ", ")+
+				". Their components were not deleted and have to be removed manually.",
+		)
+		return results, false
+	}
+	orphans := []string{}
+	for i := len(removedOrder) - 1; i >= 0; i-- {
+		node := removedByKey[removedOrder[i]]
+		err := node.nodeType.delete(ctx, r.client, pipelineId, node.id().ValueString())
+		if err != nil && !client.IsNotFoundError(err) {
+			orphans = append(orphans, fmt.Sprintf("%s (%s): %s", node.key, node.id().ValueString(), err.Error()))
+		}
+	}
+	if len(orphans) > 0 {
+		diags.AddError(
+			"Error Deleting Pipeline Graph Nodes",
+			"The graph was applied, but these components could not be deleted and have to be "+
+				"removed manually:\n"+strings.Join(orphans, "\n"),
+		)
+	}
+	return results, !diags.HasError()
+}
+
+// Reverts the changes made by a failed reconcile, most recent first. Updated components are
+// read again and get back their prior user_config and inputs, since updating them changed
+// their generation id. If everything was reverted, returns nil so that the prior state is
+// kept. Otherwise, returns the nodes that exist now, so that the state reflects them.
+func (r *PipelineGraphResource) rollback(
+	ctx context.Context, pipelineId string, changes []graphChange, stateNodes map[string]*graphNode, results map[string]attr.Value, diags *diag.Diagnostics,
+) (map[string]attr.Value, bool) {
+	failures := []string{}
+	for i := len(changes) - 1; i >= 0; i-- {
+		change := changes[i]
+		key := change.node.key
+		if change.previous == nil {
+			err := change.node.nodeType.delete(ctx, r.client, pipelineId, change.id)
+			if err != nil && !client.IsNotFoundError(err) {
+				failures = append(failures, fmt.Sprintf("%s: could not delete %s: %s", key, change.id, err.Error()))
+				continue
+			}
+			delete(results, key)
+			continue
+		}
+		prior := stateNodes[key]
+		restored, dd := prior.nodeType.restore(ctx, r.client, pipelineId, *change.previous)
+		if dd.HasError() {
+			failures = append(failures, fmt.Sprintf("%s: could not restore %s: %s", key, prior.id().ValueString(), diagnosticsDetail(dd)))
+			continue
+		}
+		object, dd := prior.withComponent(restored, prior.inputs)
+		diags.Append(dd...)
+		results[key] = object
+	}
+
+	if len(failures) == 0 {
+		diags.AddError(
+			"Pipeline Graph Rolled Back",
+			"The changes made to the pipeline by this apply were rolled back.",
+		)
+		return nil, false
+	}
+
+	// Keep track of what exists now, starting from the prior state
+	nodes := make(map[string]attr.Value, len(stateNodes)+len(results))
+	for key, node := range stateNodes {
+		nodes[key] = node.object
+	}
+	for key, object := range results {
+		nodes[key] = object
+	}
+	diags.AddError(
+		"Pipeline Graph Partially Applied",
+		"Rolling back the changes made by this apply failed, so the pipeline is left partially "+
+			"applied. The state records the components as they are now. Failures:\n"+strings.Join(failures, "\n"),
+	)
+	return nodes, false
+}
+
+func diagnosticsDetail(diags diag.Diagnostics) string {
+	details := []string{}
+	for _, d := range diags.Errors() {
+		details = append(details, d.Detail())
+	}
+	return strings.Join(details, " ")
+}
+
+// Whether every known value of `plan` matches `state`. Unknown values are computed, so
+// they do not make a node change by themselves.
+func sameKnownValues(plan attr.Value, state attr.Value) bool {
+	if plan.IsUnknown() {
+		return true
+	}
+	if plan.IsNull() || state == nil || state.IsNull() || state.IsUnknown() {
+		return plan.Equal(state)
+	}
+	switch planValue := plan.(type) {
+	case basetypes.ObjectValue:
+		stateValue, ok := state.(basetypes.ObjectValue)
+		if !ok {
+			return false
+		}
+		stateAttributes := stateValue.Attributes()
+		for name, value := range planValue.Attributes() {
+			if !sameKnownValues(value, stateAttributes[name]) {
+				return false
+			}
+		}
+		return true
+	case basetypes.ListValue:
+		stateValue, ok := state.(basetypes.ListValue)
+		if !ok || len(planValue.Elements()) != len(stateValue.Elements()) {
+			return false
+		}
+		for i, value := range planValue.Elements() {
+			if !sameKnownValues(value, stateValue.Elements()[i]) {
+				return false
+			}
+		}
+		return true
+	case basetypes.MapValue:
+		stateValue, ok := state.(basetypes.MapValue)
+		if !ok || len(planValue.Elements()) != len(stateValue.Elements()) {
+			return false
+		}
+		for key, value := range planValue.Elements() {
+			if !sameKnownValues(value, stateValue.Elements()[key]) {
+				return false
+			}
+		}
+		return true
+	}
+	return plan.Equal(state)
+}
@@ -0,0 +1,252 @@
+package provider
+
+import (
+	"context"
+	"regexp"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-framework/attr"
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/types/basetypes"
+	"github.com/hashicorp/terraform-plugin-testing/helper/resource"
+	"github.com/mezmo/terraform-provider-mezmo/v5/internal/client/clienttest"
+	. "github.com/mezmo/terraform-provider-mezmo/v5/internal/provider/models"
+	. "github.com/mezmo/terraform-provider-mezmo/v5/internal/provider/providertest"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestPipelineGraphOrder(t *testing.T) {
+	order, cycle := PipelineGraphOrder(map[string][]string{
+		"sink":   {"route.errors", "parse"},
+		"route":  {"source"},
+		"parse":  {"route._unmatched", "existing-processor-id"},
+		"source": {},
+	})
+	assert.Nil(t, cycle)
+	assert.Equal(t, []string{"source", "route", "parse", "sink"}, order)
+
+	order, cycle = PipelineGraphOrder(map[string][]string{
+		"source": {},
+		"a":      {"source", "c"},
+		"b":      {"a"},
+		"c":      {"b"},
+		"sink":   {"c"},
+	})
+	assert.Nil(t, order)
+	assert.Equal(t, []string{"a", "b", "c", "sink"}, cycle)
+
+	// Applying and deleting nodes report the cycle instead of skipping them
+	var diags diag.Diagnostics
+	order, ok := orderGraphNodes(map[string][]string{"a": {"b"}, "b": {"a"}}, &diags)
+	assert.False(t, ok)
+	assert.Nil(t, order)
+	assert.Equal(t, 1, diags.ErrorsCount())
+	assert.Equal(t, "The inputs of these nodes form a cycle: a, b.", diags[0].Detail())
+}
+
+func TestPipelineGraphInputs(t *testing.T) {
+	ids := map[string]string{"source": "id-1", "route": "id-2"}
+	inputs := []string{"source", "route.errors", "other-id"}
+
+	resolved := ResolveGraphInputs(inputs, ids)
+	assert.Equal(t, []string{"id-1", "id-2.errors", "other-id"}, resolved)
+	assert.Equal(t, inputs, GraphInputsFromIds(resolved, ids))
+}
+
+func TestPipelineGraphSchema(t *testing.T) {
+	s := PipelineGraphResourceSchema()
+	assert.Empty(t, s.ValidateImplementation(context.Background()))
+
+	for _, name := range []string{"http_source", "route_processor", "blackhole_destination"} {
+		assert.Contains(t, graphNodeAttrTypes, name)
+		assert.NotContains(t, graphNodeAttrTypes[name].(basetypes.ObjectType).AttrTypes, "pipeline_id")
+	}
+	assert.NotContains(t, graphNodeAttrTypes, "threshold_alert")
+}
+
+func TestSameKnownValues(t *testing.T) {
+	attrTypes := map[string]attr.Type{
+		"title":  basetypes.StringType{},
+		"inputs": basetypes.ListType{ElemType: basetypes.StringType{}},
+	}
+	state := basetypes.NewObjectValueMust(attrTypes, map[string]attr.Value{
+		"title":  basetypes.NewStringValue("title"),
+		"inputs": basetypes.NewListValueMust(basetypes.StringType{}, []attr.Value{basetypes.NewStringValue("a")}),
+	})
+
+	plan := basetypes.NewObjectValueMust(attrTypes, map[string]attr.Value{
+		"title":  basetypes.NewStringValue("title"),
+		"inputs": basetypes.NewListUnknown(basetypes.StringType{}),
+	})
+	assert.True(t, sameKnownValues(plan, state))
+
+	plan = basetypes.NewObjectValueMust(attrTypes, map[string]attr.Value{
+		"title":  basetypes.NewStringNull(),
+		"inputs": basetypes.NewListUnknown(basetypes.StringType{}),
+	})
+	assert.False(t, sameKnownValues(plan, state))
+}
+
+func TestPipelineGraphResource(t *testing.T) {
+	const cacheKey = "pipeline_graph_resource"
+	resource.Test(t, resource.TestCase{
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		PreCheck:                 func() { TestPreCheck(t) },
+		Steps: []resource.TestStep{
+			// A node sets exactly one component block
+			{
+				Config: GetProviderConfig() + `
+					resource "mezmo_pipeline_graph" "graph" {
+						pipeline_id = "pipeline-id"
+						nodes = {
+							source = {
+								demo_source = {}
+								http_source = {}
+							}
+						}
+					}`,
+				ExpectError: regexp.MustCompile(`Node "source" must set exactly one component block, found 2`),
+			},
+			// Inputs cannot form a cycle
+			{
+				Config: GetProviderConfig() + `
+					resource "mezmo_pipeline_graph" "graph" {
+						pipeline_id = "pipeline-id"
+						nodes = {
+							a = {
+								inputs                = ["b"]
+								drop_fields_processor = { fields = [".a"] }
+							}
+							b = {
+								inputs                = ["a"]
+								drop_fields_processor = { fields = [".b"] }
+							}
+						}
+					}`,
+				ExpectError: regexp.MustCompile(`The inputs of these nodes form a cycle: a, b`),
+			},
+			// Create and Read testing
+			{
+				Config: SetCachedConfig(cacheKey, `
+					resource "mezmo_pipeline" "graph" {
+						title = "pipeline graph"
+					}`) + `
+					resource "mezmo_pipeline_graph" "graph" {
+						pipeline_id = mezmo_pipeline.graph.id
+						nodes = {
+							source = {
+								demo_source = { format = "json" }
+							}
+							drop = {
+								inputs                = ["source"]
+								drop_fields_processor = { fields = [".secret"] }
+							}
+							sink = {
+								inputs                = ["drop"]
+								blackhole_destination = { title = "sink" }
+							}
+						}
+					}`,
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestMatchResourceAttr(
+						"mezmo_pipeline_graph.graph", "nodes.drop.id", regexp.MustCompile(`[\w-]{36}`)),
+					StateHasExpectedValues("mezmo_pipeline_graph.graph", map[string]any{
+						"id":                              "#mezmo_pipeline.graph.id",
+						"nodes.%":                         "3",
+						"nodes.source.demo_source.format": "json",
+						"nodes.drop.inputs.0":             "source",
+						"nodes.drop.drop_fields_processor.fields.0": ".secret",
+						"nodes.sink.inputs.0":                       "drop",
+						"nodes.sink.blackhole_destination.title":    "sink",
+					}),
+				),
+			},
+			// Update a node, replace another one with a different type and remove one
+			{
+				Config: GetCachedConfig(cacheKey) + `
+					resource "mezmo_pipeline_graph" "graph" {
+						pipeline_id = mezmo_pipeline.graph.id
+						nodes = {
+							source = {
+								demo_source = { format = "apache_common" }
+							}
+							sink = {
+								inputs           = ["source"]
+								http_destination = { uri = "https://example.org" }
+							}
+						}
+					}`,
+				Check: resource.ComposeTestCheckFunc(
+					StateHasExpectedValues("mezmo_pipeline_graph.graph", map[string]any{
+						"nodes.%":                            "2",
+						"nodes.source.demo_source.format":    "apache_common",
+						"nodes.sink.inputs.0":                "source",
+						"nodes.sink.http_destination.uri":    "https://example.org",
+						"nodes.sink.blackhole_destination.%": nil,
+					}),
+				),
+			},
+			// Delete testing automatically occurs in TestCase
+		},
+	})
+}
+
+func TestPipelineGraphRollback(t *testing.T) {
+	server := clienttest.NewServer(t)
+	server.AddValidator(clienttest.RejectUserConfig(clienttest.KIND_SINK, "/user_config/uri", "must match format \"uri\""))
+	config := server.ProviderConfig() + `
+		resource "mezmo_pipeline" "graph" {
+			title = "pipeline graph rollback"
+		}
+		resource "mezmo_pipeline_graph" "graph" {
+			pipeline_id = mezmo_pipeline.graph.id
+			nodes = {
+				source = {
+					demo_source = { format = "json" }
+				}
+				drop = {
+					inputs                = ["source"]
+					drop_fields_processor = { fields = [".secret"] }
+				}
+			}
+		}`
+
+	resource.UnitTest(t, resource.TestCase{
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		PreCheck:                 func() { UnitTestPreCheck(t) },
+		Steps: []resource.TestStep{
+			{
+				Config: config,
+			},
+			// The processor is updated before the new destination fails, and is restored afterwards
+			{
+				Config: server.ProviderConfig() + `
+					resource "mezmo_pipeline" "graph" {
+						title = "pipeline graph rollback"
+					}
+					resource "mezmo_pipeline_graph" "graph" {
+						pipeline_id = mezmo_pipeline.graph.id
+						nodes = {
+							source = {
+								demo_source = { format = "json" }
+							}
+							drop = {
+								inputs                = ["source"]
+								drop_fields_processor = { fields = [".secret", ".password"] }
+							}
+							sink = {
+								inputs           = ["drop"]
+								http_destination = { uri = "https://example.org" }
+							}
+						}
+					}`,
+				ExpectError: regexp.MustCompile(`Pipeline Graph Rolled Back`),
+			},
+			// The components match the configuration from before the failed apply
+			{
+				Config:   config,
+				PlanOnly: true,
+			},
+		},
+	})
+}
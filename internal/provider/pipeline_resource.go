@@ -5,6 +5,7 @@ import (
 	"errors"
 	"fmt"
 	"reflect"
+	"strings"
 
 	"github.com/hashicorp/terraform-plugin-framework/path"
 
@@ -12,6 +13,7 @@ import (
 
 	"github.com/hashicorp/terraform-plugin-framework/diag"
 	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/types"
 	"github.com/mezmo/terraform-provider-mezmo/v5/internal/client"
 	. "github.com/mezmo/terraform-provider-mezmo/v5/internal/provider/models"
 )
@@ -91,7 +93,7 @@ func (r *PipelineResource) Create(ctx context.Context, req resource.CreateReques
 	pipeline := PipelineFromModel(&plan)
 	stored, err := r.client.CreatePipeline(pipeline, ctx)
 	if err != nil {
-		resp.Diagnostics.AddError(
+		addApiErrorDiagnostics[PipelineResourceModel](&resp.Diagnostics, err, PipelineResourceSchema(), pipeline,
 			"Error creating pipeline",
 			"Could not create pipeline, unexpected error: "+err.Error(),
 		)
@@ -99,6 +101,8 @@ func (r *PipelineResource) Create(ctx context.Context, req resource.CreateReques
 	}
 
 	PipelineToModel(&plan, stored)
+	// A new pipeline has no components yet
+	r.setMembership(&plan, []PipelineComponent{})
 	diags = resp.State.Set(ctx, plan)
 	resp.Diagnostics.Append(diags...)
 }
@@ -115,7 +119,7 @@ func (r *PipelineResource) Delete(ctx context.Context, req resource.DeleteReques
 	// Delete existing order
 	err := r.client.DeletePipeline(state.Id.ValueString(), ctx)
 	if err != nil {
-		resp.Diagnostics.AddError(
+		addClientErrorDiagnostic(&resp.Diagnostics, err,
 			"Error Deleting Pipeline",
 			"Could not pipeline, unexpected error: "+err.Error(),
 		)
@@ -143,7 +147,7 @@ func (r *PipelineResource) Read(ctx context.Context, req resource.ReadRequest, r
 		return
 	}
 	if err != nil {
-		resp.Diagnostics.AddError(
+		addClientErrorDiagnostic(&resp.Diagnostics, err,
 			"Error Reading Pipeline",
 			"Could not read pipeline with id  "+state.Id.ValueString()+": "+err.Error(),
 		)
@@ -151,6 +155,32 @@ func (r *PipelineResource) Read(ctx context.Context, req resource.ReadRequest, r
 	}
 
 	PipelineToModel(&state, pipeline)
+	// Imported resources and state written by older versions have no value yet
+	if state.StrictMembership.IsNull() {
+		state.StrictMembership = types.BoolValue(false)
+	}
+	if state.StrictMembership.ValueBool() {
+		components, ok := r.pipelineComponents(ctx, state.Id.ValueString(), &resp.Diagnostics)
+		if !ok {
+			return
+		}
+		// Components missing from the last applied state were added since then, most likely outside
+		// of this configuration. The refreshed list is only saved on apply, so the warning shows until then.
+		if !state.ComponentIds.IsNull() {
+			if unmanaged := UnmanagedPipelineComponents(components, state.ComponentIds); len(unmanaged) > 0 {
+				resp.Diagnostics.AddWarning(
+					"Pipeline Has Unmanaged Components",
+					fmt.Sprintf("Pipeline %s contains components that were added since the last apply:\n\n%s\n\n"+
+						"Components that are not managed by this configuration can be imported or deleted "+
+						"from the pipeline. The next apply acknowledges them as members of the pipeline.",
+						state.Id.ValueString(), describeComponents(unmanaged)),
+				)
+			}
+		}
+		r.setMembership(&state, components)
+	} else {
+		r.setMembership(&state, nil)
+	}
 	resp.State.Set(ctx, state)
 }
 
@@ -176,7 +206,7 @@ func (r *PipelineResource) Update(ctx context.Context, req resource.UpdateReques
 	pipeline.Id = state.Id.ValueString()
 	stored, err := r.client.UpdatePipeline(pipeline, ctx)
 	if err != nil {
-		resp.Diagnostics.AddError(
+		addApiErrorDiagnostics[PipelineResourceModel](&resp.Diagnostics, err, PipelineResourceSchema(), pipeline,
 			"Error Updating Pipeline",
 			"Could not update pipeline, unexpected error: "+err.Error(),
 		)
@@ -184,10 +214,48 @@ func (r *PipelineResource) Update(ctx context.Context, req resource.UpdateReques
 	}
 
 	PipelineToModel(&plan, stored)
+	if plan.StrictMembership.ValueBool() {
+		components, ok := r.pipelineComponents(ctx, pipeline.Id, &resp.Diagnostics)
+		if !ok {
+			return
+		}
+		r.setMembership(&plan, components)
+	} else {
+		r.setMembership(&plan, nil)
+	}
 	diags := resp.State.Set(ctx, plan)
 	resp.Diagnostics.Append(diags...)
 }
 
+func (r *PipelineResource) pipelineComponents(ctx context.Context, id string, diags *diag.Diagnostics) ([]PipelineComponent, bool) {
+	graph, err := r.client.PipelineGraph(id, ctx)
+	if err != nil {
+		addClientErrorDiagnostic(diags, err,
+			"Error Listing Pipeline Components",
+			"Could not list the components of pipeline "+id+": "+err.Error(),
+		)
+		return nil, false
+	}
+	return PipelineGraphComponents(graph), true
+}
+
+// Records the components of a pipeline. `component_ids` is null unless strict membership is enabled.
+func (r *PipelineResource) setMembership(model *PipelineResourceModel, components []PipelineComponent) {
+	if !model.StrictMembership.ValueBool() {
+		model.ComponentIds = types.SetNull(types.StringType)
+		return
+	}
+	model.ComponentIds = PipelineComponentIds(components)
+}
+
+func describeComponents(components []PipelineComponent) string {
+	lines := make([]string, 0, len(components))
+	for _, component := range components {
+		lines = append(lines, "  - "+component.String())
+	}
+	return strings.Join(lines, "\n")
+}
+
 func setDiagnosticsHasError(source diag.Diagnostics, target *diag.Diagnostics) bool {
 	target.Append(source...)
 	return target.HasError()
@@ -94,3 +94,104 @@ func TestPipelineResource(t *testing.T) {
 		},
 	})
 }
+
+func TestPipelineResourceStrictMembership(t *testing.T) {
+	resource.Test(t, resource.TestCase{
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		PreCheck:                 func() { TestPreCheck(t) },
+		Steps: []resource.TestStep{
+			// A new pipeline has no components
+			{
+				Config: GetProviderConfig() + `
+					resource "mezmo_pipeline" "strict" {
+						title             = "strict membership"
+						strict_membership = true
+					}`,
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("mezmo_pipeline.strict", "strict_membership", "true"),
+					resource.TestCheckResourceAttr("mezmo_pipeline.strict", "component_ids.#", "0"),
+				),
+			},
+			// Components added outside of Terraform are listed after a refresh
+			{
+				Config: GetProviderConfig() + `
+					resource "mezmo_pipeline" "strict" {
+						title             = "strict membership"
+						strict_membership = true
+					}`,
+				PreConfig: func() {
+					c := NewTestClient()
+					ctx := context.Background()
+					pipelines, err := c.ListPipelines(ctx)
+					if err != nil {
+						t.Fatalf("could not list pipelines: %s", err)
+					}
+					for _, pipeline := range pipelines {
+						if pipeline.Title != "strict membership" {
+							continue
+						}
+						source := client.Source{BaseNode: client.BaseNode{
+							Type:       "demo-logs",
+							Title:      "added in the UI",
+							UserConfig: map[string]any{"format": "json"},
+						}}
+						if _, err := c.CreateSource(pipeline.Id, &source, ctx); err != nil {
+							t.Fatalf("could not create source: %s", err)
+						}
+					}
+				},
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("mezmo_pipeline.strict", "component_ids.#", "1"),
+				),
+			},
+			// Turning the mode off clears the list
+			{
+				Config: GetProviderConfig() + `
+					resource "mezmo_pipeline" "strict" {
+						title = "strict membership"
+					}`,
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("mezmo_pipeline.strict", "strict_membership", "false"),
+					resource.TestCheckNoResourceAttr("mezmo_pipeline.strict", "component_ids"),
+				),
+			},
+		},
+	})
+}
+
+// Runs from testdata/cassettes without the services, see `CassettePreCheck`
+func TestPipelineResourceCassette(t *testing.T) {
+	resource.UnitTest(t, resource.TestCase{
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		PreCheck:                 func() { CassettePreCheck(t) },
+		Steps: []resource.TestStep{
+			{
+				Config: GetProviderConfig() + `
+					resource "mezmo_pipeline" "recorded" {
+						title = "recorded pipeline"
+					}
+					resource "mezmo_http_source" "source" {
+						pipeline_id = mezmo_pipeline.recorded.id
+					}
+					resource "mezmo_http_destination" "destination" {
+						pipeline_id = mezmo_pipeline.recorded.id
+						inputs      = [mezmo_http_source.source.id]
+						uri         = "https://example.org"
+						auth = {
+							strategy = "basic"
+							user     = "recorded"
+							password = "not-in-the-cassette"
+						}
+					}`,
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr("mezmo_pipeline.recorded", "title", "recorded pipeline"),
+					resource.TestMatchResourceAttr("mezmo_pipeline.recorded", "id", IDRegex),
+					resource.TestCheckResourceAttrPair(
+						"mezmo_http_destination.destination", "inputs.0", "mezmo_http_source.source", "id"),
+					// The password is redacted in the cassette, and restored from the request
+					resource.TestCheckResourceAttr("mezmo_http_destination.destination", "auth.password", "not-in-the-cassette"),
+				),
+			},
+		},
+	})
+}
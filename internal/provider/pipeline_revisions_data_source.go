@@ -0,0 +1,87 @@
+package provider
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/hashicorp/terraform-plugin-framework/datasource"
+	"github.com/mezmo/terraform-provider-mezmo/v5/internal/client"
+	. "github.com/mezmo/terraform-provider-mezmo/v5/internal/provider/models"
+)
+
+var (
+	_ datasource.DataSource              = &PipelineRevisionsDataSource{}
+	_ datasource.DataSourceWithConfigure = &PipelineRevisionsDataSource{}
+)
+
+func NewPipelineRevisionsDataSource() datasource.DataSource {
+	return &PipelineRevisionsDataSource{}
+}
+
+type PipelineRevisionsDataSource struct {
+	client client.Client
+}
+
+func (d *PipelineRevisionsDataSource) TypeName() string {
+	return PROVIDER_TYPE_NAME + "_pipeline_revisions"
+}
+
+// Configure implements datasource.DataSourceWithConfigure.
+func (d *PipelineRevisionsDataSource) Configure(_ context.Context, req datasource.ConfigureRequest, resp *datasource.ConfigureResponse) {
+	if req.ProviderData == nil {
+		return
+	}
+
+	client, ok := req.ProviderData.(client.Client)
+
+	if !ok {
+		resp.Diagnostics.AddError(
+			"Unexpected Data Source Configure Type",
+			fmt.Sprintf("Expected client.Client, got: %T. Please report this issue to Mezmo.", req.ProviderData),
+		)
+
+		return
+	}
+
+	d.client = client
+}
+
+// Metadata implements datasource.DataSource.
+func (d *PipelineRevisionsDataSource) Metadata(_ context.Context, _ datasource.MetadataRequest, resp *datasource.MetadataResponse) {
+	resp.TypeName = d.TypeName()
+}
+
+// Schema implements datasource.DataSource.
+func (d *PipelineRevisionsDataSource) Schema(_ context.Context, _ datasource.SchemaRequest, resp *datasource.SchemaResponse) {
+	resp.Schema = PipelineRevisionsDataSourceSchema()
+}
+
+// Read implements datasource.DataSource.
+func (d *PipelineRevisionsDataSource) Read(ctx context.Context, req datasource.ReadRequest, resp *datasource.ReadResponse) {
+	var config PipelineRevisionsDataSourceModel
+	if diags := req.Config.Get(ctx, &config); setDiagnosticsHasError(diags, &resp.Diagnostics) {
+		return
+	}
+	pipelineId := config.PipelineId.ValueString()
+
+	pipeline, err := d.client.Pipeline(pipelineId, ctx)
+	if err != nil {
+		addClientErrorDiagnostic(&resp.Diagnostics, err,
+			"Error Reading Pipeline",
+			"Could not read pipeline with id "+pipelineId+": "+err.Error(),
+		)
+		return
+	}
+	revisions, err := d.client.ListPipelineRevisions(pipelineId, ctx)
+	if err != nil {
+		addClientErrorDiagnostic(&resp.Diagnostics, err,
+			"Error Reading Pipeline Revisions",
+			"Could not read the revisions of pipeline "+pipelineId+": "+err.Error(),
+		)
+		return
+	}
+
+	PipelineRevisionsToModel(&config, pipeline, revisions)
+	diags := resp.State.Set(ctx, config)
+	resp.Diagnostics.Append(diags...)
+}
@@ -0,0 +1,74 @@
+package provider
+
+import (
+	"regexp"
+	"testing"
+	"time"
+
+	"github.com/hashicorp/terraform-plugin-framework/types/basetypes"
+	"github.com/hashicorp/terraform-plugin-testing/helper/resource"
+	"github.com/mezmo/terraform-provider-mezmo/v5/internal/client"
+	. "github.com/mezmo/terraform-provider-mezmo/v5/internal/provider/models"
+	. "github.com/mezmo/terraform-provider-mezmo/v5/internal/provider/providertest"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestPipelineRevisionsToModel(t *testing.T) {
+	older := time.Date(2024, 1, 2, 3, 4, 5, 0, time.UTC)
+	newer := older.AddDate(0, 1, 0)
+	var model PipelineRevisionsDataSourceModel
+	PipelineRevisionsToModel(&model, &client.Pipeline{PublishedRevisionId: "rev-2"}, []client.PipelineRevision{
+		{Id: "rev-1", PublishedAt: &older, PublishedBy: "someone@example.org"},
+		{Id: "rev-3"},
+		{Id: "rev-2", PublishedAt: &newer},
+	})
+
+	assert.Equal(t, "rev-2", model.PublishedRevision.ValueString())
+	ids := []string{}
+	for _, revision := range model.Revisions.Elements() {
+		ids = append(ids, revision.(basetypes.ObjectValue).Attributes()["id"].(basetypes.StringValue).ValueString())
+	}
+	assert.Equal(t, []string{"rev-2", "rev-1", "rev-3"}, ids)
+	rev1 := model.Revisions.Elements()[1].(basetypes.ObjectValue).Attributes()
+	assert.Equal(t, "2024-01-02T03:04:05Z", rev1["published_at"].(basetypes.StringValue).ValueString())
+	assert.Equal(t, "someone@example.org", rev1["published_by"].(basetypes.StringValue).ValueString())
+}
+
+func TestPipelineRevisionsDataSource(t *testing.T) {
+	resource.Test(t, resource.TestCase{
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		PreCheck:                 func() { TestPreCheck(t) },
+		Steps: []resource.TestStep{
+			// Required fields test
+			{
+				Config: GetProviderConfig() + `
+					data "mezmo_pipeline_revisions" "lookup" {
+					}`,
+				ExpectError: regexp.MustCompile("Missing required argument"),
+			},
+			// Read testing
+			{
+				Config: GetProviderConfig() + `
+					resource "mezmo_pipeline" "revisions" {
+						title = "pipeline with revisions"
+					}
+					resource "mezmo_publish_pipeline" "revisions" {
+						pipeline_id = mezmo_pipeline.revisions.id
+					}
+					data "mezmo_pipeline_revisions" "lookup" {
+						pipeline_id = mezmo_publish_pipeline.revisions.pipeline_id
+					}`,
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttrPair(
+						"data.mezmo_pipeline_revisions.lookup", "published_revision",
+						"mezmo_publish_pipeline.revisions", "published_revision",
+					),
+					resource.TestCheckResourceAttrPair(
+						"data.mezmo_pipeline_revisions.lookup", "revisions.0.id",
+						"mezmo_publish_pipeline.revisions", "published_revision",
+					),
+				),
+			},
+		},
+	})
+}
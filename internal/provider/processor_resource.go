@@ -7,6 +7,7 @@ import (
 	"reflect"
 	"strings"
 
+	"github.com/hashicorp/terraform-plugin-framework/diag"
 	"github.com/hashicorp/terraform-plugin-framework/path"
 
 	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
@@ -14,6 +15,7 @@ import (
 	"github.com/hashicorp/terraform-plugin-framework/resource"
 	"github.com/mezmo/terraform-provider-mezmo/v5/internal/client"
 	. "github.com/mezmo/terraform-provider-mezmo/v5/internal/client"
+	"github.com/mezmo/terraform-provider-mezmo/v5/internal/provider/models"
 	. "github.com/mezmo/terraform-provider-mezmo/v5/internal/provider/models/processors"
 )
 
@@ -81,7 +83,9 @@ func (r *ProcessorResource[T]) ConvertToTerraformModel(component *reflect.Value)
 	}
 
 	var model T
-	r.toModelFunc(&model, &processor)
+	if diags := r.toModelFunc(&model, &processor); diags.HasError() {
+		return nil, errors.New(diagnosticsDetail(diags))
+	}
 	res := reflect.ValueOf(model)
 	return &res, nil
 }
@@ -121,7 +125,7 @@ func (r *ProcessorResource[T]) Create(ctx context.Context, req resource.CreateRe
 
 	stored, err := r.client.CreateProcessor(r.getPipelineIdFunc(&plan).ValueString(), component, ctx)
 	if err != nil {
-		resp.Diagnostics.AddError(
+		addApiErrorDiagnostics[T](&resp.Diagnostics, err, r.schema, component,
 			"Error creating processor",
 			"Could not create processor, unexpected error: "+err.Error(),
 		)
@@ -130,7 +134,9 @@ func (r *ProcessorResource[T]) Create(ctx context.Context, req resource.CreateRe
 
 	NullifyPlanFields(&plan, r.schema)
 
-	r.toModelFunc(&plan, stored)
+	if diags := r.toModelFunc(&plan, stored); setDiagnosticsHasError(diags, &resp.Diagnostics) {
+		return
+	}
 	diags = resp.State.Set(ctx, plan)
 	resp.Diagnostics.Append(diags...)
 }
@@ -147,7 +153,7 @@ func (r *ProcessorResource[T]) Delete(ctx context.Context, req resource.DeleteRe
 	// Delete existing order
 	err := r.client.DeleteProcessor(r.getPipelineIdFunc(&state).ValueString(), r.getIdFunc(&state).ValueString(), ctx)
 	if err != nil {
-		resp.Diagnostics.AddError(
+		addClientErrorDiagnostic(&resp.Diagnostics, err,
 			"Error deleting processor",
 			"Could not delete processor, unexpected error: "+err.Error(),
 		)
@@ -175,7 +181,7 @@ func (r *ProcessorResource[T]) Read(ctx context.Context, req resource.ReadReques
 		return
 	}
 	if err != nil {
-		resp.Diagnostics.AddError(
+		addClientErrorDiagnostic(&resp.Diagnostics, err,
 			"Error reading processor",
 			fmt.Sprintf("Could not read processor with id %s and pipeline_id %s: %s",
 				r.getIdFunc(&state), r.getPipelineIdFunc(&state), err.Error()),
@@ -185,7 +191,9 @@ func (r *ProcessorResource[T]) Read(ctx context.Context, req resource.ReadReques
 
 	NullifyPlanFields(&state, r.schema)
 
-	r.toModelFunc(&state, component)
+	if diags := r.toModelFunc(&state, component); setDiagnosticsHasError(diags, &resp.Diagnostics) {
+		return
+	}
 	diags := resp.State.Set(ctx, state)
 	resp.Diagnostics.Append(diags...)
 }
@@ -207,9 +215,13 @@ func (r *ProcessorResource[T]) Update(ctx context.Context, req resource.UpdateRe
 		return
 	}
 
-	stored, err := r.client.UpdateProcessor(r.getPipelineIdFunc(&state).ValueString(), component, ctx)
+	pipelineId := r.getPipelineIdFunc(&state).ValueString()
+	stored, err := r.client.UpdateProcessor(pipelineId, component, ctx)
 	if err != nil {
-		resp.Diagnostics.AddError(
+		if client.IsConflictError(err) && r.reportConflict(ctx, pipelineId, &state, &resp.Diagnostics) {
+			return
+		}
+		addApiErrorDiagnostics[T](&resp.Diagnostics, err, r.schema, component,
 			"Error updating processor",
 			"Could not update processor, unexpected error: "+err.Error(),
 		)
@@ -218,11 +230,28 @@ func (r *ProcessorResource[T]) Update(ctx context.Context, req resource.UpdateRe
 
 	NullifyPlanFields(&plan, r.schema)
 
-	r.toModelFunc(&plan, stored)
+	if diags := r.toModelFunc(&plan, stored); setDiagnosticsHasError(diags, &resp.Diagnostics) {
+		return
+	}
 	diags := resp.State.Set(ctx, plan)
 	resp.Diagnostics.Append(diags...)
 }
 
+// Reports a processor that the API could not update because it was modified outside of terraform.
+// Errors reading it are left to the update to report.
+func (r *ProcessorResource[T]) reportConflict(ctx context.Context, pipelineId string, state *T, diags *diag.Diagnostics) bool {
+	remote, err := r.client.Processor(pipelineId, r.getIdFunc(state).ValueString(), ctx)
+	if err != nil {
+		return false
+	}
+	return reportNodeConflict("processor", state, remote, r.fromModelFunc, r.toModelFunc, processorNode, diags)
+}
+
+// ModifyPlan implements resource.ResourceWithModifyPlan.
+func (r *ProcessorResource[T]) ModifyPlan(ctx context.Context, req resource.ModifyPlanRequest, resp *resource.ModifyPlanResponse) {
+	validatePlannedInputs(ctx, r.client, models.INPUT_CONSUMER_PROCESSOR, req, resp)
+}
+
 // Schema implements resource.Resource.
 func (r *ProcessorResource[T]) Schema(_ context.Context, _ resource.SchemaRequest, resp *resource.SchemaResponse) {
 	resp.Schema = r.schema
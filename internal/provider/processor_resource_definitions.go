@@ -12,7 +12,7 @@ func NewAggregateProcessorResource() resource.Resource {
 		typeName:          AGGREGATE_PROCESSOR_TYPE_NAME,
 		nodeName:          AGGREGATE_PROCESSOR_NODE_NAME,
 		fromModelFunc:     AggregateProcessorFromModel,
-		toModelFunc:       AggregateProcessorToModel,
+		toModelFunc:       infallibleToModel(AggregateProcessorToModel),
 		getIdFunc:         func(m *AggregateProcessorModel) basetypes.StringValue { return m.Id },
 		getPipelineIdFunc: func(m *AggregateProcessorModel) basetypes.StringValue { return m.PipelineId },
 		schema:            AggregateProcessorResourceSchema,
@@ -48,7 +48,7 @@ func NewFlattenFieldsProcessorResource() resource.Resource {
 		typeName:          FLATTEN_FIELDS_PROCESSOR_TYPE_NAME,
 		nodeName:          FLATTEN_FIELDS_PROCESSOR_NODE_NAME,
 		fromModelFunc:     FlattenFieldsProcessorFromModel,
-		toModelFunc:       FlattenFieldsProcessorToModel,
+		toModelFunc:       infallibleToModel(FlattenFieldsProcessorToModel),
 		getIdFunc:         func(m *FlattenFieldsProcessorModel) basetypes.StringValue { return m.Id },
 		getPipelineIdFunc: func(m *FlattenFieldsProcessorModel) basetypes.StringValue { return m.PipelineId },
 		schema:            FlattenFieldsProcessorResourceSchema,
@@ -60,7 +60,7 @@ func NewMapFieldsProcessorResource() resource.Resource {
 		typeName:          MAP_FIELDS_PROCESSOR_TYPE_NAME,
 		nodeName:          MAP_FIELDS_PROCESSOR_NODE_NAME,
 		fromModelFunc:     MapFieldsProcessorFromModel,
-		toModelFunc:       MapFieldsProcessorToModel,
+		toModelFunc:       infallibleToModel(MapFieldsProcessorToModel),
 		getIdFunc:         func(m *MapFieldsProcessorModel) basetypes.StringValue { return m.Id },
 		getPipelineIdFunc: func(m *MapFieldsProcessorModel) basetypes.StringValue { return m.PipelineId },
 		schema:            MapFieldsProcessorResourceSchema,
@@ -72,7 +72,7 @@ func NewSampleProcessorResource() resource.Resource {
 		typeName:          SAMPLE_PROCESSOR_TYPE_NAME,
 		nodeName:          SAMPLE_PROCESSOR_NODE_NAME,
 		fromModelFunc:     SampleProcessorFromModel,
-		toModelFunc:       SampleProcessorToModel,
+		toModelFunc:       infallibleToModel(SampleProcessorToModel),
 		getIdFunc:         func(m *SampleProcessorModel) basetypes.StringValue { return m.Id },
 		getPipelineIdFunc: func(m *SampleProcessorModel) basetypes.StringValue { return m.PipelineId },
 		schema:            SampleProcessorResourceSchema,
@@ -84,7 +84,7 @@ func NewStringifyProcessorResource() resource.Resource {
 		typeName:          STRINGIFY_PROCESSOR_TYPE_NAME,
 		nodeName:          STRINGIFY_PROCESSOR_NODE_NAME,
 		fromModelFunc:     StringifyProcessorFromModel,
-		toModelFunc:       StringifyProcessorToModel,
+		toModelFunc:       infallibleToModel(StringifyProcessorToModel),
 		getIdFunc:         func(m *StringifyProcessorModel) basetypes.StringValue { return m.Id },
 		getPipelineIdFunc: func(m *StringifyProcessorModel) basetypes.StringValue { return m.PipelineId },
 		schema:            StringifyProcessorResourceSchema,
@@ -120,7 +120,7 @@ func NewCompactFieldsProcessorResource() resource.Resource {
 		typeName:          COMPACT_FIELDS_PROCESSOR_TYPE_NAME,
 		nodeName:          COMPACT_FIELDS_PROCESSOR_NODE_NAME,
 		fromModelFunc:     CompactFieldsProcessorFromModel,
-		toModelFunc:       CompactFieldsProcessorToModel,
+		toModelFunc:       infallibleToModel(CompactFieldsProcessorToModel),
 		getIdFunc:         func(m *CompactFieldsProcessorModel) basetypes.StringValue { return m.Id },
 		getPipelineIdFunc: func(m *CompactFieldsProcessorModel) basetypes.StringValue { return m.PipelineId },
 		schema:            CompactFieldsProcessorResourceSchema,
@@ -132,7 +132,7 @@ func NewDecryptFieldsProcessorResource() resource.Resource {
 		typeName:          DECRYPT_FIELDS_PROCESSOR_TYPE_NAME,
 		nodeName:          DECRYPT_FIELDS_PROCESSOR_NODE_NAME,
 		fromModelFunc:     DecryptFieldsProcessorFromModel,
-		toModelFunc:       DecryptFieldsProcessorToModel,
+		toModelFunc:       infallibleToModel(DecryptFieldsProcessorToModel),
 		getIdFunc:         func(m *DecryptFieldsProcessorModel) basetypes.StringValue { return m.Id },
 		getPipelineIdFunc: func(m *DecryptFieldsProcessorModel) basetypes.StringValue { return m.PipelineId },
 		schema:            DecryptFieldsProcessorResourceSchema,
@@ -144,7 +144,7 @@ func NewEncryptFieldsProcessorResource() resource.Resource {
 		typeName:          ENCRYPT_FIELDS_PROCESSOR_TYPE_NAME,
 		nodeName:          ENCRYPT_FIELDS_PROCESSOR_NODE_NAME,
 		fromModelFunc:     EncryptFieldsProcessorFromModel,
-		toModelFunc:       EncryptFieldsProcessorToModel,
+		toModelFunc:       infallibleToModel(EncryptFieldsProcessorToModel),
 		getIdFunc:         func(m *EncryptFieldsProcessorModel) basetypes.StringValue { return m.Id },
 		getPipelineIdFunc: func(m *EncryptFieldsProcessorModel) basetypes.StringValue { return m.PipelineId },
 		schema:            EncryptFieldsProcessorResourceSchema,
@@ -156,7 +156,7 @@ func NewParseProcessorResource() resource.Resource {
 		typeName:          PARSE_PROCESSOR_TYPE_NAME,
 		nodeName:          PARSE_PROCESSOR_NODE_NAME,
 		fromModelFunc:     ParseProcessorFromModel,
-		toModelFunc:       ParseProcessorToModel,
+		toModelFunc:       infallibleToModel(ParseProcessorToModel),
 		getIdFunc:         func(m *ParseProcessorModel) basetypes.StringValue { return m.Id },
 		getPipelineIdFunc: func(m *ParseProcessorModel) basetypes.StringValue { return m.PipelineId },
 		schema:            ParseProcessorResourceSchema,
@@ -168,7 +168,7 @@ func NewReduceProcessorResource() resource.Resource {
 		typeName:          REDUCE_PROCESSOR_TYPE_NAME,
 		nodeName:          REDUCE_PROCESSOR_NODE_NAME,
 		fromModelFunc:     ReduceProcessorFromModel,
-		toModelFunc:       ReduceProcessorToModel,
+		toModelFunc:       infallibleToModel(ReduceProcessorToModel),
 		getIdFunc:         func(m *ReduceProcessorModel) basetypes.StringValue { return m.Id },
 		getPipelineIdFunc: func(m *ReduceProcessorModel) basetypes.StringValue { return m.PipelineId },
 		schema:            ReduceProcessorResourceSchema,
@@ -180,7 +180,7 @@ func NewRouteProcessorResource() resource.Resource {
 		typeName:          ROUTE_PROCESSOR_TYPE_NAME,
 		nodeName:          ROUTE_PROCESSOR_NODE_NAME,
 		fromModelFunc:     RouteProcessorFromModel,
-		toModelFunc:       RouteProcessorToModel,
+		toModelFunc:       infallibleToModel(RouteProcessorToModel),
 		getIdFunc:         func(m *RouteProcessorModel) basetypes.StringValue { return m.Id },
 		getPipelineIdFunc: func(m *RouteProcessorModel) basetypes.StringValue { return m.PipelineId },
 		schema:            RouteProcessorResourceSchema,
@@ -192,7 +192,7 @@ func NewParseSequentiallyProcessorResource() resource.Resource {
 		typeName:          PARSE_SEQUENTIALLY_PROCESSOR_TYPE_NAME,
 		nodeName:          PARSE_SEQUENTIALLY_PROCESSOR_NODE_NAME,
 		fromModelFunc:     ParseSequentiallyProcessorFromModel,
-		toModelFunc:       ParseSequentiallyProcessorToModel,
+		toModelFunc:       infallibleToModel(ParseSequentiallyProcessorToModel),
 		getIdFunc:         func(m *ParseSequentiallyProcessorModel) basetypes.StringValue { return m.Id },
 		getPipelineIdFunc: func(m *ParseSequentiallyProcessorModel) basetypes.StringValue { return m.PipelineId },
 		schema:            ParseSequentiallyProcessorResourceSchema,
@@ -204,7 +204,7 @@ func NewMetricsTagCardinalityLimitProcessorResource() resource.Resource {
 		typeName:          METRICS_TAG_CARDINALITY_LIMIT_PROCESSOR_TYPE_NAME,
 		nodeName:          METRICS_TAG_LIMIT_PROCESSOR_NODE_NAME,
 		fromModelFunc:     MetricsTagCardinalityLimitProcessorFromModel,
-		toModelFunc:       MetricsTagCardinalityLimitProcessorToModel,
+		toModelFunc:       infallibleToModel(MetricsTagCardinalityLimitProcessorToModel),
 		getIdFunc:         func(m *MetricsTagCardinalityLimitProcessorModel) basetypes.StringValue { return m.Id },
 		getPipelineIdFunc: func(m *MetricsTagCardinalityLimitProcessorModel) basetypes.StringValue { return m.PipelineId },
 		schema:            MetricsTagCardinalityLimitProcessorResourceSchema,
@@ -216,7 +216,7 @@ func NewEventToMetricProcessorResource() resource.Resource {
 		typeName:          EVENT_TO_METRIC_PROCESSOR_TYPE_NAME,
 		nodeName:          EVENT_TO_METRIC_PROCESSOR_NODE_NAME,
 		fromModelFunc:     EventToMetricProcessorFromModel,
-		toModelFunc:       EventToMetricProcessorToModel,
+		toModelFunc:       infallibleToModel(EventToMetricProcessorToModel),
 		getIdFunc:         func(m *EventToMetricProcessorModel) basetypes.StringValue { return m.Id },
 		getPipelineIdFunc: func(m *EventToMetricProcessorModel) basetypes.StringValue { return m.PipelineId },
 		schema:            EventToMetricProcessorResourceSchema,
@@ -240,7 +240,7 @@ func NewSetTimestampProcessorResource() resource.Resource {
 		typeName:          SET_TIMESTAMP_PROCESSOR_TYPE_NAME,
 		nodeName:          SET_TIMESTAMP_PROCESSOR_NODE_NAME,
 		fromModelFunc:     SetTimestampProcessorFromModel,
-		toModelFunc:       SetTimestampProcessorToModel,
+		toModelFunc:       infallibleToModel(SetTimestampProcessorToModel),
 		getIdFunc:         func(m *SetTimestampProcessorModel) basetypes.StringValue { return m.Id },
 		getPipelineIdFunc: func(m *SetTimestampProcessorModel) basetypes.StringValue { return m.PipelineId },
 		schema:            SetTimestampProcessorResourceSchema,
@@ -264,7 +264,7 @@ func NewTraceSamplingProcessorResource() resource.Resource {
 		typeName:          TRACE_SAMPLING_PROCESSOR_TYPE_NAME,
 		nodeName:          TRACE_SAMPLING_PROCESSOR_NODE_NAME,
 		fromModelFunc:     TraceSamplingProcessorFromModel,
-		toModelFunc:       TraceSamplingProcessorToModel,
+		toModelFunc:       infallibleToModel(TraceSamplingProcessorToModel),
 		getIdFunc:         func(m *TraceSamplingProcessorModel) basetypes.StringValue { return m.Id },
 		getPipelineIdFunc: func(m *TraceSamplingProcessorModel) basetypes.StringValue { return m.PipelineId },
 		schema:            TraceSamplingProcessorResourceSchema,
@@ -3,12 +3,17 @@ package provider
 import (
 	"context"
 	"fmt"
+	"net/http"
 	"os"
 	"reflect"
+	"time"
 
+	"github.com/hashicorp/terraform-plugin-framework-validators/int64validator"
 	"github.com/hashicorp/terraform-plugin-framework-validators/mapvalidator"
 	"github.com/hashicorp/terraform-plugin-framework-validators/stringvalidator"
 	"github.com/hashicorp/terraform-plugin-framework/datasource"
+	"github.com/hashicorp/terraform-plugin-framework/ephemeral"
+	"github.com/hashicorp/terraform-plugin-framework/function"
 	"github.com/hashicorp/terraform-plugin-framework/path"
 	"github.com/hashicorp/terraform-plugin-framework/provider"
 	"github.com/hashicorp/terraform-plugin-framework/provider/schema"
@@ -17,16 +22,32 @@ import (
 	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
 	. "github.com/hashicorp/terraform-plugin-framework/types"
 	"github.com/hashicorp/terraform-plugin-framework/types/basetypes"
+	"github.com/hashicorp/terraform-plugin-log/tflog"
 	"github.com/mezmo/terraform-provider-mezmo/v5/internal/client"
 )
 
 const PROVIDER_TYPE_NAME = "mezmo"
 
-var _ provider.Provider = &MezmoProvider{}
+// Environment variables used when the provider block does not set a value
+const (
+	ENV_AUTH_KEY       = "MEZMO_AUTH_KEY"
+	ENV_ENDPOINT       = "MEZMO_ENDPOINT"
+	ENV_HEADERS_PREFIX = "MEZMO_HEADERS_"
+)
+
+const DEFAULT_ENDPOINT = "https://api.mezmo.com"
+
+var (
+	_ provider.Provider                       = &MezmoProvider{}
+	_ provider.ProviderWithEphemeralResources = &MezmoProvider{}
+	_ provider.ProviderWithFunctions          = &MezmoProvider{}
+)
 
 // MezmoProvider defines the provider implementation.
 type MezmoProvider struct {
 	version string
+	// Only set by the test provider factories, to record or replay the requests of the client
+	wrapTransport func(http.RoundTripper) (http.RoundTripper, error)
 }
 
 // MezmoProviderModel describes the provider data model.
@@ -34,6 +55,17 @@ type MezmoProviderModel struct {
 	Endpoint String `tfsdk:"endpoint"`
 	AuthKey  String `tfsdk:"auth_key"`
 	Headers  Map    `tfsdk:"headers"`
+
+	MaxRetries         Int64 `tfsdk:"max_retries"`
+	RetryWaitMax       Int64 `tfsdk:"retry_wait_max"`
+	RetryNonIdempotent Bool  `tfsdk:"retry_non_idempotent"`
+	RequestTimeout     Int64 `tfsdk:"request_timeout"`
+
+	CaCertPem          String `tfsdk:"ca_cert_pem"`
+	ClientCertPem      String `tfsdk:"client_cert_pem"`
+	ClientKeyPem       String `tfsdk:"client_key_pem"`
+	InsecureSkipVerify Bool   `tfsdk:"insecure_skip_verify"`
+	ProxyUrl           String `tfsdk:"proxy_url"`
 }
 
 func (p *MezmoProvider) Metadata(ctx context.Context, req provider.MetadataRequest, resp *provider.MetadataResponse) {
@@ -47,17 +79,22 @@ func (p *MezmoProvider) Schema(ctx context.Context, req provider.SchemaRequest,
 			" (sources, processors and destinations) programmatically via Terraform.",
 		Attributes: map[string]schema.Attribute{
 			"endpoint": schema.StringAttribute{
-				Description: "Mezmo API endpoint containing the url scheme, host and port",
-				Optional:    true,
-				Validators:  []validator.String{stringvalidator.LengthAtLeast(1)},
+				Description: "Mezmo API endpoint containing the url scheme, host and port. " +
+					"May also be set with the `" + ENV_ENDPOINT + "` environment variable.",
+				Optional:   true,
+				Validators: []validator.String{stringvalidator.LengthAtLeast(1)},
 			},
 			"auth_key": schema.StringAttribute{
-				Description: "The authentication key",
-				Required:    true,
-				Sensitive:   true,
+				Description: "The authentication key. Required unless the `" + ENV_AUTH_KEY + "` environment " +
+					"variable is set. A value set here takes precedence over the environment variable.",
+				Optional:  true,
+				Sensitive: true,
 			},
 			"headers": schema.MapAttribute{
-				Description: "Optional map of headers to send in each request",
+				Description: "Optional map of headers to send in each request. Headers may also be set with " +
+					"`" + ENV_HEADERS_PREFIX + "<NAME>` environment variables, where underscores in the name become " +
+					"dashes, e.g. `" + ENV_HEADERS_PREFIX + "X_REQUEST_SOURCE` sets `x-request-source`. " +
+					"Headers set here take precedence.",
 				Optional:    true,
 				ElementType: StringType,
 				Validators: []validator.Map{
@@ -67,6 +104,68 @@ func (p *MezmoProvider) Schema(ctx context.Context, req provider.SchemaRequest,
 					),
 				},
 			},
+			"max_retries": schema.Int64Attribute{
+				Description: fmt.Sprintf("The maximum number of times a request is retried after a transient "+
+					"failure such as a 429, 502, 503 or 504 response (default: %d). Set to 0 to disable retries.",
+					client.DefaultMaxRetries),
+				Optional:   true,
+				Validators: []validator.Int64{int64validator.AtLeast(0)},
+			},
+			"retry_wait_max": schema.Int64Attribute{
+				Description: fmt.Sprintf("The maximum number of seconds to wait between retries (default: %d). "+
+					"Waits grow exponentially between attempts, and a `Retry-After` header from the server is "+
+					"honored up to this value.", int64(client.DefaultRetryWaitMax.Seconds())),
+				Optional:   true,
+				Validators: []validator.Int64{int64validator.AtLeast(1)},
+			},
+			"retry_non_idempotent": schema.BoolAttribute{
+				Description: "Also retry POST requests. By default, only idempotent requests (GET, PUT, DELETE) " +
+					"are retried because a failed POST may still have created a component.",
+				Optional: true,
+			},
+			"request_timeout": schema.Int64Attribute{
+				Description: fmt.Sprintf("The number of seconds to wait for each API request, including reading "+
+					"the response, before aborting it (default: %d). Each retry gets its own timeout.",
+					int64(client.DefaultRequestTimeout.Seconds())),
+				Optional:   true,
+				Validators: []validator.Int64{int64validator.AtLeast(1)},
+			},
+			"ca_cert_pem": schema.StringAttribute{
+				Description: "PEM-encoded CA certificates that are trusted to verify the certificate of the API, " +
+					"in addition to the system's CAs. Useful when requests go through a proxy that uses a private CA.",
+				Optional:   true,
+				Validators: []validator.String{stringvalidator.LengthAtLeast(1)},
+			},
+			"client_cert_pem": schema.StringAttribute{
+				Description: "PEM-encoded client certificate to present to the API for mutual TLS. " +
+					"Requires `client_key_pem`.",
+				Optional: true,
+				Validators: []validator.String{
+					stringvalidator.LengthAtLeast(1),
+					stringvalidator.AlsoRequires(path.MatchRoot("client_key_pem")),
+				},
+			},
+			"client_key_pem": schema.StringAttribute{
+				Description: "PEM-encoded private key of `client_cert_pem`.",
+				Optional:    true,
+				Sensitive:   true,
+				Validators: []validator.String{
+					stringvalidator.LengthAtLeast(1),
+					stringvalidator.AlsoRequires(path.MatchRoot("client_cert_pem")),
+				},
+			},
+			"insecure_skip_verify": schema.BoolAttribute{
+				Description: "Do not verify the certificate of the API. This makes the connection vulnerable " +
+					"to interception, and should only be used for testing.",
+				Optional: true,
+			},
+			"proxy_url": schema.StringAttribute{
+				Description: "URL of the proxy to send requests through, e.g. `http://proxy.example.com:3128`. " +
+					"The `http`, `https` and `socks5` schemes are supported. When not set, the `HTTPS_PROXY`, " +
+					"`HTTP_PROXY` and `NO_PROXY` environment variables are used.",
+				Optional:   true,
+				Validators: []validator.String{stringvalidator.LengthAtLeast(1)},
+			},
 		},
 	}
 }
@@ -80,26 +179,58 @@ func (p *MezmoProvider) Configure(ctx context.Context, req provider.ConfigureReq
 		return
 	}
 
-	endpoint := "https://api.mezmo.com"
-	headers := make(map[string]string)
+	settings, diags := resolveProviderSettings(&data, os.Environ())
+	if setDiagnosticsHasError(diags, &resp.Diagnostics) {
+		return
+	}
+	tflog.Info(ctx, "Configuring the Mezmo provider", settings.sources)
+	endpoint := settings.endpoint
+	headers := settings.headers
+
+	retryOptions := client.DefaultRetryOptions()
+	if !data.MaxRetries.IsNull() {
+		retryOptions.MaxRetries = int(data.MaxRetries.ValueInt64())
+	}
+	if !data.RetryWaitMax.IsNull() {
+		retryOptions.WaitMax = time.Duration(data.RetryWaitMax.ValueInt64()) * time.Second
+		retryOptions.WaitMin = min(retryOptions.WaitMin, retryOptions.WaitMax)
+	}
+	if !data.RetryNonIdempotent.IsNull() {
+		retryOptions.RetryNonIdempotent = data.RetryNonIdempotent.ValueBool()
+	}
 
-	if !data.Endpoint.IsNull() {
-		endpoint = data.Endpoint.ValueString()
+	options := []client.ClientOption{client.WithRetryOptions(retryOptions)}
+	if !data.RequestTimeout.IsNull() {
+		options = append(options, client.WithRequestTimeout(time.Duration(data.RequestTimeout.ValueInt64())*time.Second))
 	}
-	if !data.Headers.IsNull() {
-		for k, v := range data.Headers.Elements() {
-			headers[k] = v.(String).ValueString()
+	transport, diags := buildTransport(&data)
+	if setDiagnosticsHasError(diags, &resp.Diagnostics) {
+		return
+	}
+	if p.wrapTransport != nil {
+		if transport == nil {
+			transport = http.DefaultTransport
+		}
+		var err error
+		if transport, err = p.wrapTransport(transport); err != nil {
+			resp.Diagnostics.AddError("Cannot Wrap the Client Transport", err.Error())
+			return
 		}
 	}
+	if transport != nil {
+		options = append(options, client.WithTransport(transport))
+	}
 
-	c := client.NewClient(endpoint, data.AuthKey.ValueString(), headers)
+	c := newPlannedClient(client.NewClient(endpoint, settings.authKey, headers, options...))
 	resp.DataSourceData = c
 	resp.ResourceData = c
+	resp.EphemeralResourceData = c
 }
 
 func (p *MezmoProvider) Resources(ctx context.Context) []func() resource.Resource {
 	return []func() resource.Resource{
 		NewPipelineResource,
+		NewPipelineGraphResource,
 
 		// Sources
 		NewAgentSourceResource,
@@ -172,6 +303,9 @@ func (p *MezmoProvider) Resources(ctx context.Context) []func() resource.Resourc
 		NewChangeAlertResource,
 		NewAbsenceAlertResource,
 
+		// Notification Channels
+		NewNotificationChannelResource,
+
 		// Access Keys
 		NewAccessKeyResource,
 
@@ -184,7 +318,23 @@ func (p *MezmoProvider) Resources(ctx context.Context) []func() resource.Resourc
 }
 
 func (p *MezmoProvider) DataSources(ctx context.Context) []func() datasource.DataSource {
-	return []func() datasource.DataSource{}
+	return []func() datasource.DataSource{
+		NewPipelineDataSource,
+		NewSharedSourceDataSource,
+		NewPipelineRevisionsDataSource,
+	}
+}
+
+func (p *MezmoProvider) EphemeralResources(ctx context.Context) []func() ephemeral.EphemeralResource {
+	return []func() ephemeral.EphemeralResource{
+		NewAccessKeyEphemeralResource,
+	}
+}
+
+func (p *MezmoProvider) Functions(ctx context.Context) []func() function.Function {
+	return []func() function.Function{
+		NewConditionFunction,
+	}
 }
 
 func New(version string) func() provider.Provider {
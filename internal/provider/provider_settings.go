@@ -0,0 +1,162 @@
+package provider
+
+import (
+	"fmt"
+	"net/http"
+	"net/url"
+	"sort"
+	"strings"
+
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	. "github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+const (
+	sourceConfig  = "provider configuration"
+	sourceDefault = "default"
+)
+
+// The connection settings of the provider after merging the provider block with the environment
+type providerSettings struct {
+	endpoint string
+	authKey  string
+	headers  map[string]string
+	// Which source supplied each value, for logging and diagnostics
+	sources map[string]any
+}
+
+func envSource(name string) string {
+	return "environment variable " + name
+}
+
+// Resolves the endpoint, auth key and headers from the provider block, falling back to
+// environment variables. Values in the provider block always take precedence.
+func resolveProviderSettings(data *MezmoProviderModel, environ []string) (*providerSettings, diag.Diagnostics) {
+	dd := diag.Diagnostics{}
+	env := make(map[string]string, len(environ))
+	for _, kv := range environ {
+		if k, v, ok := strings.Cut(kv, "="); ok {
+			env[k] = v
+		}
+	}
+
+	settings := providerSettings{
+		endpoint: DEFAULT_ENDPOINT,
+		headers:  make(map[string]string),
+		sources:  make(map[string]any),
+	}
+	settings.sources["endpoint"] = sourceDefault
+	// Environment variables that are set to a different value than the provider configuration
+	overridden := []string{}
+	override := func(name string, envName string, value string) {
+		if envValue, ok := env[envName]; ok && envValue != "" && envValue != value {
+			overridden = append(overridden, fmt.Sprintf("- %s overrides %s", name, envSource(envName)))
+		}
+	}
+
+	if data.Endpoint.IsUnknown() {
+		dd.AddAttributeError(
+			path.Root("endpoint"),
+			"Unknown Mezmo API Endpoint",
+			"The provider cannot be configured with an endpoint that is not known until apply. "+
+				"Set `endpoint` to a static value, or use the "+ENV_ENDPOINT+" environment variable.",
+		)
+	} else if !data.Endpoint.IsNull() {
+		settings.endpoint = data.Endpoint.ValueString()
+		settings.sources["endpoint"] = sourceConfig
+		override("endpoint", ENV_ENDPOINT, settings.endpoint)
+	} else if value := env[ENV_ENDPOINT]; value != "" {
+		settings.endpoint = value
+		settings.sources["endpoint"] = envSource(ENV_ENDPOINT)
+	}
+	if u, err := url.Parse(settings.endpoint); err != nil || (u.Scheme != "http" && u.Scheme != "https") || u.Host == "" {
+		dd.AddAttributeError(
+			path.Root("endpoint"),
+			"Invalid Mezmo API Endpoint",
+			fmt.Sprintf(
+				"The endpoint %q supplied by the %s is not a valid URL. It must contain an http or https "+
+					"scheme and a host, e.g. %q.",
+				settings.endpoint, settings.sources["endpoint"], DEFAULT_ENDPOINT,
+			),
+		)
+	}
+
+	if data.AuthKey.IsUnknown() {
+		dd.AddAttributeError(
+			path.Root("auth_key"),
+			"Unknown Mezmo Auth Key",
+			"The provider cannot be configured with an auth key that is not known until apply. "+
+				"Set `auth_key` to a static value, or use the "+ENV_AUTH_KEY+" environment variable.",
+		)
+	} else if !data.AuthKey.IsNull() {
+		settings.authKey = data.AuthKey.ValueString()
+		settings.sources["auth_key"] = sourceConfig
+		override("auth_key", ENV_AUTH_KEY, settings.authKey)
+	} else if value := env[ENV_AUTH_KEY]; value != "" {
+		settings.authKey = value
+		settings.sources["auth_key"] = envSource(ENV_AUTH_KEY)
+	} else {
+		dd.AddAttributeError(
+			path.Root("auth_key"),
+			"Missing Mezmo Auth Key",
+			"An auth key is required. Set `auth_key` in the provider configuration, "+
+				"or set the "+ENV_AUTH_KEY+" environment variable.",
+		)
+	}
+
+	headerSources := make(map[string]string)
+	// canonical header name -> name as it was given
+	names := make(map[string]string)
+	// canonical header name -> environment variable
+	headerEnvNames := make(map[string]string)
+	for k, v := range env {
+		suffix, found := strings.CutPrefix(k, ENV_HEADERS_PREFIX)
+		if !found || suffix == "" {
+			continue
+		}
+		name := strings.ToLower(strings.ReplaceAll(suffix, "_", "-"))
+		settings.headers[name] = v
+		headerSources[name] = envSource(k)
+		names[http.CanonicalHeaderKey(name)] = name
+		headerEnvNames[http.CanonicalHeaderKey(name)] = k
+	}
+	if data.Headers.IsUnknown() {
+		dd.AddAttributeError(
+			path.Root("headers"),
+			"Unknown Mezmo Provider Headers",
+			"The provider cannot be configured with headers that are not known until apply. "+
+				"Set `headers` to static values, or use "+ENV_HEADERS_PREFIX+"<NAME> environment variables.",
+		)
+	} else if !data.Headers.IsNull() {
+		for k, v := range data.Headers.Elements() {
+			// Header names are case-insensitive, so replace the environment's value for the same header
+			if previous, ok := names[http.CanonicalHeaderKey(k)]; ok {
+				delete(settings.headers, previous)
+				delete(headerSources, previous)
+			}
+			settings.headers[k] = v.(String).ValueString()
+			headerSources[k] = sourceConfig
+			if envName, ok := headerEnvNames[http.CanonicalHeaderKey(k)]; ok {
+				override("header "+k, envName, settings.headers[k])
+			}
+		}
+	}
+	if len(headerSources) > 0 {
+		settings.sources["headers"] = headerSources
+	}
+
+	// Settings that only come from the environment are expected, e.g. in CI, and are only logged.
+	// A provider block that disagrees with the environment is more likely to be a mistake.
+	if len(overridden) > 0 && !dd.HasError() {
+		sort.Strings(overridden)
+		dd.AddWarning(
+			"Mezmo Provider Configuration Overrides the Environment",
+			"Some settings of the provider configuration differ from the environment variables that "+
+				"are also set. Values set in the provider configuration take precedence.\n\n"+
+				strings.Join(overridden, "\n"),
+		)
+	}
+
+	return &settings, dd
+}
@@ -0,0 +1,103 @@
+package provider
+
+import (
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-framework/attr"
+	. "github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestResolveProviderSettings(t *testing.T) {
+	environ := []string{
+		"MEZMO_AUTH_KEY=env-key",
+		"MEZMO_ENDPOINT=https://env.example.com",
+		"MEZMO_HEADERS_X_AUTH_ACCOUNT_ID=env-account",
+		"MEZMO_HEADERS_X_REQUEST_SOURCE=ci",
+		"UNRELATED=value",
+	}
+
+	t.Run("uses defaults and environment variables", func(t *testing.T) {
+		data := MezmoProviderModel{
+			Endpoint: StringNull(),
+			AuthKey:  StringNull(),
+			Headers:  MapNull(StringType),
+		}
+		settings, dd := resolveProviderSettings(&data, environ)
+		assert.False(t, dd.HasError(), dd)
+		assert.Equal(t, "https://env.example.com", settings.endpoint)
+		assert.Equal(t, "env-key", settings.authKey)
+		assert.Equal(t, map[string]string{
+			"x-auth-account-id": "env-account",
+			"x-request-source":  "ci",
+		}, settings.headers)
+		assert.Equal(t, "environment variable MEZMO_AUTH_KEY", settings.sources["auth_key"])
+		assert.Equal(t, "environment variable MEZMO_ENDPOINT", settings.sources["endpoint"])
+		// Configuring the provider from the environment only is not worth a warning
+		assert.Empty(t, dd)
+	})
+
+	t.Run("does not warn when the configuration matches the environment", func(t *testing.T) {
+		data := MezmoProviderModel{
+			Endpoint: StringValue("https://env.example.com"),
+			AuthKey:  StringValue("env-key"),
+			Headers: MapValueMust(StringType, map[string]attr.Value{
+				"x-request-source": StringValue("ci"),
+			}),
+		}
+		_, dd := resolveProviderSettings(&data, environ)
+		assert.Empty(t, dd)
+	})
+
+	t.Run("provider configuration takes precedence", func(t *testing.T) {
+		data := MezmoProviderModel{
+			Endpoint: StringValue("http://localhost:19095"),
+			AuthKey:  StringValue(""),
+			Headers: MapValueMust(StringType, map[string]attr.Value{
+				"X-Auth-Account-Id": StringValue("hcl-account"),
+			}),
+		}
+		settings, dd := resolveProviderSettings(&data, environ)
+		assert.False(t, dd.HasError(), dd)
+		assert.Equal(t, "http://localhost:19095", settings.endpoint)
+		assert.Equal(t, "", settings.authKey)
+		assert.Equal(t, map[string]string{
+			"X-Auth-Account-Id": "hcl-account",
+			"x-request-source":  "ci",
+		}, settings.headers)
+		assert.Equal(t, map[string]string{
+			"X-Auth-Account-Id": "provider configuration",
+			"x-request-source":  "environment variable MEZMO_HEADERS_X_REQUEST_SOURCE",
+		}, settings.sources["headers"])
+		assert.Equal(t, 1, dd.WarningsCount())
+		assert.Equal(t, "Mezmo Provider Configuration Overrides the Environment", dd[0].Summary())
+		assert.Contains(t, dd[0].Detail(), "- auth_key overrides environment variable MEZMO_AUTH_KEY\n"+
+			"- endpoint overrides environment variable MEZMO_ENDPOINT\n"+
+			"- header X-Auth-Account-Id overrides environment variable MEZMO_HEADERS_X_AUTH_ACCOUNT_ID")
+	})
+
+	t.Run("requires an auth key", func(t *testing.T) {
+		data := MezmoProviderModel{
+			Endpoint: StringNull(),
+			AuthKey:  StringNull(),
+			Headers:  MapNull(StringType),
+		}
+		settings, dd := resolveProviderSettings(&data, []string{})
+		assert.Equal(t, DEFAULT_ENDPOINT, settings.endpoint)
+		assert.Equal(t, "default", settings.sources["endpoint"])
+		assert.True(t, dd.HasError())
+		assert.Equal(t, "Missing Mezmo Auth Key", dd.Errors()[0].Summary())
+	})
+
+	t.Run("reports the source of an invalid endpoint", func(t *testing.T) {
+		data := MezmoProviderModel{
+			Endpoint: StringNull(),
+			AuthKey:  StringValue("key"),
+			Headers:  MapNull(StringType),
+		}
+		_, dd := resolveProviderSettings(&data, []string{"MEZMO_ENDPOINT=api.mezmo.com"})
+		assert.True(t, dd.HasError())
+		assert.Equal(t, "Invalid Mezmo API Endpoint", dd.Errors()[0].Summary())
+		assert.Contains(t, dd.Errors()[0].Detail(), "environment variable MEZMO_ENDPOINT")
+	})
+}
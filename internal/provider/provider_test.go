@@ -1,8 +1,17 @@
 package provider
 
 import (
+	"context"
+	"net/http"
+	"sort"
+	"testing"
+
 	"github.com/hashicorp/terraform-plugin-framework/providerserver"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
 	"github.com/hashicorp/terraform-plugin-go/tfprotov6"
+	"github.com/mezmo/terraform-provider-mezmo/v5/internal/provider/providertest"
+	"github.com/stretchr/testify/assert"
 )
 
 // testAccProtoV6ProviderFactories are used to instantiate a provider during
@@ -10,5 +19,61 @@ import (
 // CLI command executed to create a provider server to which the CLI can
 // reattach.
 var testAccProtoV6ProviderFactories = map[string]func() (tfprotov6.ProviderServer, error){
-	"mezmo": providerserver.NewProtocol6WithError(New("test")()),
+	"mezmo": providerserver.NewProtocol6WithError(&MezmoProvider{
+		version: "test",
+		// Records or replays the requests when the test uses a cassette
+		wrapTransport: func(transport http.RoundTripper) (http.RoundTripper, error) {
+			ctx := context.Background()
+			return providertest.CassetteTransport(transport, sensitiveAttributeNames(ctx, (&MezmoProvider{}).Resources(ctx)))
+		},
+	}),
+}
+
+// The names of the sensitive attributes of all resources, which are redacted in the cassettes
+func sensitiveAttributeNames(ctx context.Context, resources []func() resource.Resource) []string {
+	names := map[string]bool{}
+	for _, newResource := range resources {
+		resp := resource.SchemaResponse{}
+		newResource().Schema(ctx, resource.SchemaRequest{}, &resp)
+		for name, attribute := range resp.Schema.Attributes {
+			addSensitiveAttributeNames(name, attribute, names)
+		}
+	}
+
+	result := make([]string, 0, len(names))
+	for name := range names {
+		result = append(result, name)
+	}
+	sort.Strings(result)
+	return result
+}
+
+func addSensitiveAttributeNames(name string, attribute schema.Attribute, names map[string]bool) {
+	if attribute.IsSensitive() {
+		names[name] = true
+	}
+	var nested map[string]schema.Attribute
+	switch attribute := attribute.(type) {
+	case schema.SingleNestedAttribute:
+		nested = attribute.Attributes
+	case schema.ListNestedAttribute:
+		nested = attribute.NestedObject.Attributes
+	case schema.SetNestedAttribute:
+		nested = attribute.NestedObject.Attributes
+	case schema.MapNestedAttribute:
+		nested = attribute.NestedObject.Attributes
+	}
+	for nestedName, nestedAttribute := range nested {
+		addSensitiveAttributeNames(nestedName, nestedAttribute, names)
+	}
+}
+
+func TestSensitiveAttributeNames(t *testing.T) {
+	ctx := context.Background()
+	p := MezmoProvider{}
+	names := sensitiveAttributeNames(ctx, p.Resources(ctx))
+
+	// Top-level and nested attributes, e.g. `auth.password` of the http destination
+	assert.Subset(t, names, []string{"api_key", "password", "secret_access_key", "key"})
+	assert.NotContains(t, names, "title")
 }
@@ -7,6 +7,8 @@ import (
 	"io"
 	"net/http"
 	"os"
+	"os/exec"
+	"path/filepath"
 	"regexp"
 	"strings"
 	"sync"
@@ -18,6 +20,8 @@ import (
 	"github.com/hashicorp/terraform-plugin-testing/helper/resource"
 	"github.com/hashicorp/terraform-plugin-testing/terraform"
 	"github.com/mezmo/terraform-provider-mezmo/v5/internal/client"
+	"github.com/mezmo/terraform-provider-mezmo/v5/internal/client/cassette"
+	"github.com/mezmo/terraform-provider-mezmo/v5/internal/client/clienttest"
 	"github.com/mezmo/terraform-provider-mezmo/v5/internal/provider/models/modelutils"
 )
 
@@ -28,6 +32,8 @@ var setupMutex sync.Mutex
 var pipelineAccountCreated bool
 var accountServiceAccountCreated bool
 var testConfigCache = make(map[string]string, 0)
+var fakeServer *clienttest.Server
+var fakeServerOnce sync.Once
 
 // IDRegex expression for Pipeline IDs
 var IDRegex = regexp.MustCompile(`[\w-]{36}`)
@@ -56,7 +62,20 @@ func GetCachedConfig(key string) string {
 	panic(fmt.Sprintf("GetCachedConfig cannot find key %s", key))
 }
 
+// With TEST_FAKE_API=1, the tests use an in-memory fake of the pipeline service instead of the
+// services started by docker compose. The fake does not implement the schemas of the components.
+func useFakeApi() bool {
+	return os.Getenv("TEST_FAKE_API") == "1"
+}
+
 func GetTestEndpoint() string {
+	if useFakeApi() {
+		fakeServerOnce.Do(func() {
+			fakeServer = clienttest.NewUnstartedServer()
+			fakeServer.Start()
+		})
+		return fakeServer.URL
+	}
 	endpoint := os.Getenv("TEST_ENDPOINT")
 	if endpoint == "" {
 		// Use port exposed in docker compose service
@@ -93,11 +112,67 @@ func NewTestClient() client.Client {
 		"x-auth-account-id": authAccountId,
 		"x-auth-user-email": authUserEmail,
 	}
-	return client.NewClient(GetTestEndpoint(), "", headers)
+	transport, err := CassetteTransport(http.DefaultTransport, nil)
+	if err != nil {
+		panic(err)
+	}
+	return client.NewClient(GetTestEndpoint(), "", headers, client.WithTransport(transport))
+}
 
+// With TEST_CASSETTE=record, the requests made by the provider and the test helpers are saved to
+// `testdata/cassettes/<test name>.json`. With TEST_CASSETTE=replay, they are answered from it
+// without the services. Sensitive values and the `Authorization` header are redacted.
+func useCassette(t *testing.T) cassette.Mode {
+	mode := cassette.Mode(os.Getenv("TEST_CASSETTE"))
+	if mode == "" {
+		return ""
+	}
+	name := strings.NewReplacer("/", "_", " ", "_").Replace(t.Name())
+	cassettePath, err := filepath.Abs(filepath.Join("testdata", "cassettes", name+".json"))
+	if err != nil {
+		t.Fatalf("Cannot resolve the path of the cassette: %s", err.Error())
+	}
+	t.Setenv(cassette.ENV_PATH, cassettePath)
+	t.Setenv(cassette.ENV_MODE, string(mode))
+	t.Cleanup(func() {
+		if err := cassette.Close(cassettePath); err != nil {
+			t.Errorf("Cannot save the cassette: %s", err.Error())
+		}
+	})
+	return mode
+}
+
+// Sends the requests through the recorder of the current test's cassette, which records them with
+// `transport`. Returns `transport` when the test does not record or replay its requests.
+// `sensitiveKeys` are the names of the fields that are redacted in the cassette.
+func CassetteTransport(transport http.RoundTripper, sensitiveKeys []string) (http.RoundTripper, error) {
+	cassettePath := os.Getenv(cassette.ENV_PATH)
+	if cassettePath == "" {
+		return transport, nil
+	}
+	recorder, err := cassette.Open(cassettePath, cassette.Mode(os.Getenv(cassette.ENV_MODE)), sensitiveKeys)
+	if err != nil {
+		return nil, err
+	}
+	return recorder.Through(transport), nil
+}
+
+// Replays the requests of a test from its cassette, so that it runs with `resource.UnitTest`
+// without TF_ACC or the services. TEST_CASSETTE=record records the cassette again.
+func CassettePreCheck(t *testing.T) {
+	UnitTestPreCheck(t)
+	if os.Getenv("TEST_CASSETTE") == "" {
+		t.Setenv("TEST_CASSETTE", string(cassette.MODE_REPLAY))
+	}
+	TestPreCheck(t)
 }
 
 func TestPreCheck(t *testing.T) {
+	if useCassette(t) == cassette.MODE_REPLAY {
+		// The accounts were created when recording
+		return
+	}
+
 	defer setupMutex.Unlock()
 	setupMutex.Lock()
 
@@ -128,7 +203,7 @@ func TestPreCheck(t *testing.T) {
 		}
 	}
 
-	if !accountServiceAccountCreated {
+	if !accountServiceAccountCreated && !useFakeApi() {
 		account_content := fmt.Sprintf(`{
 				"account": %q,
 				"owneremail": %q,
@@ -164,6 +239,17 @@ func TestPreCheck(t *testing.T) {
 	}
 }
 
+// Skips tests that use `resource.UnitTest` when the terraform CLI is not installed, instead of
+// letting the test framework try to download it.
+func UnitTestPreCheck(t *testing.T) {
+	if os.Getenv("TF_ACC_TERRAFORM_PATH") != "" {
+		return
+	}
+	if _, err := exec.LookPath("terraform"); err != nil {
+		t.Skip("The terraform CLI was not found in PATH, and TF_ACC_TERRAFORM_PATH is not set")
+	}
+}
+
 var lookupRegex = regexp.MustCompile("^#(.+)\\.(.+)$")
 
 // Given a resource name, look up its properties in state and return the requested value.
@@ -332,7 +418,11 @@ func pipelineNodeType(resourceType string) (string, error) {
 }
 
 func makeDeleteRequest(urlPath string) error {
-	client := http.Client{Timeout: 5 * time.Second}
+	transport, err := CassetteTransport(http.DefaultTransport, nil)
+	if err != nil {
+		return err
+	}
+	client := http.Client{Timeout: 5 * time.Second, Transport: transport}
 	req, err := http.NewRequest(
 		http.MethodDelete,
 		GetTestEndpoint()+urlPath,
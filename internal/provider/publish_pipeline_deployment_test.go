@@ -0,0 +1,96 @@
+package provider
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/mezmo/terraform-provider-mezmo/v5/internal/client"
+	"github.com/stretchr/testify/assert"
+)
+
+// Serves the given deployment responses in order, repeating the last one
+func deploymentServer(t *testing.T, status int, responses ...string) (client.Client, *atomic.Int32) {
+	t.Helper()
+	var calls atomic.Int32
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, "/v3/pipeline/pid/deployment", r.URL.Path)
+		i := int(calls.Add(1)) - 1
+		if i >= len(responses) {
+			i = len(responses) - 1
+		}
+		w.Header().Set("Content-Type", "application/json")
+		w.WriteHeader(status)
+		w.Write([]byte(responses[i]))
+	}))
+	t.Cleanup(server.Close)
+
+	interval := deploymentPollInterval
+	deploymentPollInterval = time.Millisecond
+	t.Cleanup(func() { deploymentPollInterval = interval })
+
+	return client.NewClient(server.URL, "", nil), &calls
+}
+
+func TestWaitForDeploymentRunning(t *testing.T) {
+	c, calls := deploymentServer(t, http.StatusOK,
+		`{"data": {"status": "pending"}}`,
+		`{"data": {"status": "deploying"}}`,
+		`{"data": {"status": "running", "revision_id": "rev"}}`,
+	)
+	var diags diag.Diagnostics
+	waitForDeployment(context.Background(), c, "pid", time.Minute, &diags)
+	assert.False(t, diags.HasError(), diags)
+	assert.Equal(t, int32(3), calls.Load())
+}
+
+func TestWaitForDeploymentFailed(t *testing.T) {
+	c, _ := deploymentServer(t, http.StatusOK,
+		`{"data": {"status": "deploying"}}`,
+		`{"data": {"status": "failed", "error": {"message": "Sink unreachable.", "details": ["http: connection refused"]}}}`,
+	)
+	var diags diag.Diagnostics
+	waitForDeployment(context.Background(), c, "pid", time.Minute, &diags)
+	assert.True(t, diags.HasError())
+	assert.Equal(t, "Pipeline Deployment Failed", diags[0].Summary())
+	assert.True(t, strings.Contains(diags[0].Detail(), "Sink unreachable."), diags[0].Detail())
+	assert.True(t, strings.Contains(diags[0].Detail(), "http: connection refused"), diags[0].Detail())
+}
+
+func TestWaitForDeploymentTimeout(t *testing.T) {
+	c, _ := deploymentServer(t, http.StatusOK, `{"data": {"status": "deploying"}}`)
+	var diags diag.Diagnostics
+	waitForDeployment(context.Background(), c, "pid", 20*time.Millisecond, &diags)
+	assert.True(t, diags.HasError())
+	assert.Equal(t, "Timed Out Waiting For Pipeline Deployment", diags[0].Summary())
+	assert.True(t, strings.Contains(diags[0].Detail(), `"deploying"`), diags[0].Detail())
+}
+
+func TestWaitForDeploymentWithoutStatusEndpoint(t *testing.T) {
+	for _, status := range []int{http.StatusNotFound, http.StatusMethodNotAllowed, http.StatusNotImplemented} {
+		c, calls := deploymentServer(t, status, `{"message": "not supported", "code": "ENOTSUPPORTED"}`)
+		var diags diag.Diagnostics
+		waitForDeployment(context.Background(), c, "pid", time.Minute, &diags)
+		assert.False(t, diags.HasError(), diags)
+		assert.Equal(t, 1, diags.WarningsCount(), status)
+		assert.Equal(t, int32(1), calls.Load(), status)
+	}
+}
+
+func TestWaitForDeploymentUnknownStatus(t *testing.T) {
+	// Without a status, or with one that is not known, the deployment is not waited on
+	for _, response := range []string{`{"data": {}}`, `{"data": {"status": "rolling-out"}}`} {
+		c, calls := deploymentServer(t, http.StatusOK, response)
+		var diags diag.Diagnostics
+		waitForDeployment(context.Background(), c, "pid", time.Minute, &diags)
+		assert.False(t, diags.HasError(), diags)
+		assert.Equal(t, 1, diags.WarningsCount(), response)
+		assert.Equal(t, "Pipeline Deployment Status Not Available", diags[0].Summary())
+		assert.Equal(t, int32(1), calls.Load(), response)
+	}
+}
@@ -2,20 +2,34 @@ package provider
 
 import (
 	"context"
+	"errors"
 	"fmt"
+	"net/http"
+	"strings"
+	"time"
 
+	"github.com/hashicorp/terraform-plugin-framework/diag"
 	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-log/tflog"
 
 	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/types"
 	"github.com/mezmo/terraform-provider-mezmo/v5/internal/client"
 	. "github.com/mezmo/terraform-provider-mezmo/v5/internal/provider/models"
 )
 
 var (
-	_ resource.Resource              = &PublishPipelineResource{}
-	_ resource.ResourceWithConfigure = &PublishPipelineResource{}
+	_ resource.Resource               = &PublishPipelineResource{}
+	_ resource.ResourceWithConfigure  = &PublishPipelineResource{}
+	_ resource.ResourceWithModifyPlan = &PublishPipelineResource{}
 )
 
+// Private state key set by `Read` to whether the pipeline has changes that were not published yet.
+// It is "unknown" when the API does not report them, which plans a publish like `always_publish`.
+const privateKeyHasChanges = "has_changes"
+
+const hasChangesUnknown = "unknown"
+
 func NewPublishPipelineResource() resource.Resource {
 	return &PublishPipelineResource{}
 }
@@ -66,24 +80,129 @@ func (r *PublishPipelineResource) Create(ctx context.Context, req resource.Creat
 	if diags := req.Plan.Get(ctx, &plan); setDiagnosticsHasError(diags, &resp.Diagnostics) {
 		return
 	}
-	publish := PublishPipelineFromModel(&plan)
-	// Result doesn't matter, in fact it would cause data inconsistencies if we used it.
-	_, err := r.client.PublishPipeline(publish.PipelineId, ctx)
+	if !r.publish(ctx, &plan, &resp.Diagnostics) {
+		return
+	}
+	diags := resp.State.Set(ctx, plan)
+	setDiagnosticsHasError(diags, &resp.Diagnostics)
+}
 
-	if err != nil && err.(client.ApiResponseError).Code != "ENOCHANGES" {
-		resp.Diagnostics.AddError(
+// Publishes the pipeline, optionally waits for the deployment, and records the published revision
+func (r *PublishPipelineResource) publish(ctx context.Context, plan *PublishPipelineResourceModel, diags *diag.Diagnostics) bool {
+	publish := PublishPipelineFromModel(plan)
+	// Only the error matters. The published revision is read from the pipeline afterwards.
+	var err error
+	if plan.Revision.IsNull() {
+		_, err = r.client.PublishPipeline(publish.PipelineId, ctx)
+	} else {
+		_, err = r.client.PublishPipelineRevision(publish.PipelineId, plan.Revision.ValueString(), ctx)
+	}
+
+	if apiErr, ok := err.(client.ApiResponseError); err != nil && (!ok || apiErr.Code != "ENOCHANGES") {
+		addClientErrorDiagnostic(diags, err,
 			"Error publishing pipeline",
 			"Could not publish pipeline, unexpected error: "+err.Error(),
 		)
-		return
+		return false
 	}
 
-	// Set the state to satisfy the requirement that the response state be set in this method.
-	// The value of state is not used because it's removed on every `Read()`, but we need to prevent
-	// data inconsistecies between the plan and result. There's no need to instantiate
-	// a model here because it's only `pipeline_id`, so we can just use the `plan` as-is.
-	diags := resp.State.Set(ctx, plan)
-	setDiagnosticsHasError(diags, &resp.Diagnostics)
+	if plan.WaitForDeployment.ValueBool() {
+		timeout := time.Duration(plan.DeploymentTimeout.ValueInt64()) * time.Second
+		if waitForDeployment(ctx, r.client, publish.PipelineId, timeout, diags); diags.HasError() {
+			return false
+		}
+	}
+
+	pipeline, err := r.client.Pipeline(publish.PipelineId, ctx)
+	if err != nil {
+		addClientErrorDiagnostic(diags, err,
+			"Error Reading Pipeline",
+			"Could not read published pipeline with id "+publish.PipelineId+": "+err.Error(),
+		)
+		return false
+	}
+	PublishedPipelineToModel(plan, pipeline)
+	return true
+}
+
+// How often the deployment status is checked while waiting
+var deploymentPollInterval = 5 * time.Second
+
+// Polls the deployment status of a pipeline until it is running, failed or the timeout expires.
+// Servers without a deployment status endpoint, or with statuses it does not know, are not waited on.
+func waitForDeployment(ctx context.Context, c client.Client, pipelineId string, timeout time.Duration, diags *diag.Diagnostics) {
+	ctx, cancel := context.WithTimeout(ctx, timeout)
+	defer cancel()
+
+	var status client.DeploymentStatus
+	for {
+		deployment, err := c.PipelineDeployment(pipelineId, ctx)
+		if errors.Is(ctx.Err(), context.DeadlineExceeded) {
+			diags.AddError(
+				"Timed Out Waiting For Pipeline Deployment",
+				fmt.Sprintf("Pipeline %s was published, but its deployment did not finish within %s. The last "+
+					"known status was %q. Increase `deployment_timeout` to wait longer.", pipelineId, timeout, status),
+			)
+			return
+		}
+		if isUnsupportedEndpointError(err) {
+			diags.AddWarning(
+				"Pipeline Deployment Status Not Available",
+				fmt.Sprintf("The deployment status of pipeline %s could not be found, so the apply did not "+
+					"wait for the deployment to finish.", pipelineId),
+			)
+			return
+		}
+		if err != nil {
+			addClientErrorDiagnostic(diags, err,
+				"Error Reading Pipeline Deployment",
+				"Could not read the deployment status of pipeline "+pipelineId+": "+err.Error(),
+			)
+			return
+		}
+
+		status = deployment.Status
+		switch status {
+		case client.DEPLOYMENT_STATUS_PENDING, client.DEPLOYMENT_STATUS_DEPLOYING:
+			// Still deploying
+		case client.DEPLOYMENT_STATUS_RUNNING:
+			return
+		case client.DEPLOYMENT_STATUS_FAILED:
+			detail := fmt.Sprintf("The deployment of pipeline %s failed.", pipelineId)
+			if deployment.Error != nil {
+				detail += " " + deployment.Error.Message
+				for _, d := range deployment.Error.Details {
+					detail += "\n  - " + d
+				}
+			}
+			diags.AddError("Pipeline Deployment Failed", strings.TrimSpace(detail))
+			return
+		default:
+			diags.AddWarning(
+				"Pipeline Deployment Status Not Available",
+				fmt.Sprintf("The deployment of pipeline %s has the unexpected status %q, so the apply did not "+
+					"wait for the deployment to finish.", pipelineId, status),
+			)
+			return
+		}
+
+		tflog.Debug(ctx, "Waiting for pipeline deployment", map[string]any{
+			"pipeline_id": pipelineId,
+			"status":      string(status),
+		})
+		// A done context is reported by the next status request
+		select {
+		case <-ctx.Done():
+		case <-time.After(deploymentPollInterval):
+		}
+	}
+}
+
+// Returned by servers that do not implement an endpoint
+func isUnsupportedEndpointError(err error) bool {
+	apiErr, ok := err.(client.ApiResponseError)
+	return ok && (apiErr.Status == http.StatusNotFound || apiErr.Status == http.StatusMethodNotAllowed ||
+		apiErr.Status == http.StatusNotImplemented)
 }
 
 func (r *PublishPipelineResource) Delete(ctx context.Context, req resource.DeleteRequest, resp *resource.DeleteResponse) {
@@ -95,10 +214,75 @@ func (r *PublishPipelineResource) Metadata(_ context.Context, req resource.Metad
 }
 
 func (r *PublishPipelineResource) Read(ctx context.Context, req resource.ReadRequest, resp *resource.ReadResponse) {
-	// This is the magic. We *always* want the publish to fire, so force it to be re-created on every plan
-	// by removing it from state. Without doing this, the resource might not be selected for a "change" and would
-	// skip publishing most times.
-	resp.State.RemoveResource(ctx)
+	var state PublishPipelineResourceModel
+	if diags := req.State.Get(ctx, &state); setDiagnosticsHasError(diags, &resp.Diagnostics) {
+		return
+	}
+
+	// With `always_publish`, force the resource to be re-created on every plan by removing it from state.
+	// Without doing this, the resource might not be selected for a "change" and would skip publishing.
+	if state.AlwaysPublish.ValueBool() {
+		resp.State.RemoveResource(ctx)
+		return
+	}
+
+	pipeline, err := r.client.Pipeline(state.PipelineId.ValueString(), ctx)
+	if client.IsNotFoundError(err) {
+		resp.State.RemoveResource(ctx)
+		return
+	}
+	if err != nil {
+		addClientErrorDiagnostic(&resp.Diagnostics, err,
+			"Error Reading Pipeline",
+			"Could not read pipeline with id "+state.PipelineId.ValueString()+": "+err.Error(),
+		)
+		return
+	}
+
+	hasChanges := hasChangesUnknown
+	if pipeline.HasChanges != nil {
+		hasChanges = fmt.Sprint(*pipeline.HasChanges)
+	} else {
+		tflog.Debug(ctx, "The API does not report unpublished changes, a publish will be planned", map[string]any{
+			"pipeline_id": pipeline.Id,
+		})
+	}
+	resp.Diagnostics.Append(resp.Private.SetKey(ctx, privateKeyHasChanges, []byte(hasChanges))...)
+
+	// State written by older versions, which always published, has no value yet
+	if state.AlwaysPublish.IsNull() {
+		state.AlwaysPublish = types.BoolValue(false)
+	}
+	PublishedPipelineToModel(&state, pipeline)
+	resp.Diagnostics.Append(resp.State.Set(ctx, state)...)
+}
+
+// ModifyPlan implements resource.ResourceWithModifyPlan.
+func (r *PublishPipelineResource) ModifyPlan(ctx context.Context, req resource.ModifyPlanRequest, resp *resource.ModifyPlanResponse) {
+	// Creating always publishes, and there is nothing to publish on destroy
+	if req.State.Raw.IsNull() || req.Plan.Raw.IsNull() {
+		return
+	}
+	var plan, state PublishPipelineResourceModel
+	if diags := req.Plan.Get(ctx, &plan); setDiagnosticsHasError(diags, &resp.Diagnostics) {
+		return
+	}
+	if diags := req.State.Get(ctx, &state); setDiagnosticsHasError(diags, &resp.Diagnostics) {
+		return
+	}
+
+	hasChanges, diags := req.Private.GetKey(ctx, privateKeyHasChanges)
+	if setDiagnosticsHasError(diags, &resp.Diagnostics) {
+		return
+	}
+	// Changes to the components are not published while an earlier revision is pinned
+	hasUnpublishedChanges := (string(hasChanges) == "true" || string(hasChanges) == hasChangesUnknown) &&
+		plan.Revision.IsNull()
+	// An unknown published revision plans a publish
+	if !plan.Triggers.Equal(state.Triggers) || !plan.Revision.Equal(state.Revision) || hasUnpublishedChanges {
+		plan.PublishedRevision = types.StringUnknown()
+		resp.Diagnostics.Append(resp.Plan.Set(ctx, plan)...)
+	}
 }
 
 func (*PublishPipelineResource) Schema(_ context.Context, _ resource.SchemaRequest, resp *resource.SchemaResponse) {
@@ -106,5 +290,14 @@ func (*PublishPipelineResource) Schema(_ context.Context, _ resource.SchemaReque
 }
 
 func (r *PublishPipelineResource) Update(ctx context.Context, req resource.UpdateRequest, resp *resource.UpdateResponse) {
-	// There is no update for this resource since changed values will trigger a destroy/create.
+	var plan PublishPipelineResourceModel
+	if diags := req.Plan.Get(ctx, &plan); setDiagnosticsHasError(diags, &resp.Diagnostics) {
+		return
+	}
+
+	// Other changes, such as the deployment settings, do not need a publish
+	if plan.PublishedRevision.IsUnknown() && !r.publish(ctx, &plan, &resp.Diagnostics) {
+		return
+	}
+	resp.Diagnostics.Append(resp.State.Set(ctx, plan)...)
 }
@@ -15,7 +15,7 @@ func TestPublishPipelineResource(t *testing.T) {
 		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
 		PreCheck:                 func() { TestPreCheck(t) },
 		Steps: []resource.TestStep{
-			// Cache base resources of pipeline and source
+			// Publishing only happens on create when nothing changes
 			{
 				Config: GetProviderConfig() + `
 					resource "mezmo_pipeline" "my_pipeline" {
@@ -23,17 +23,18 @@ func TestPublishPipelineResource(t *testing.T) {
 					}
 					resource "mezmo_publish_pipeline" "my_publish_pipeline" {
 						pipeline_id = mezmo_pipeline.my_pipeline.id
-          }
+					}
 					`,
-				ExpectNonEmptyPlan: true, // We always re-create which causes a non-empty plan.
 				Check: resource.ComposeTestCheckFunc(
 					resource.TestCheckResourceAttr("mezmo_pipeline.my_pipeline", "title", "pipeline"),
 					StateHasExpectedValues("mezmo_publish_pipeline.my_publish_pipeline", map[string]any{
-						"pipeline_id": "#mezmo_pipeline.my_pipeline.id",
+						"pipeline_id":    "#mezmo_pipeline.my_pipeline.id",
+						"always_publish": "false",
 					}),
+					resource.TestCheckResourceAttrSet("mezmo_publish_pipeline.my_publish_pipeline", "published_revision"),
 				),
 			},
-			// Update
+			// Changing the triggers publishes again
 			{
 				Config: GetProviderConfig() + `
 					resource "mezmo_pipeline" "my_pipeline" {
@@ -41,13 +42,35 @@ func TestPublishPipelineResource(t *testing.T) {
 					}
 					resource "mezmo_publish_pipeline" "my_publish_pipeline" {
 						pipeline_id = mezmo_pipeline.my_pipeline.id
-          }
+						triggers = {
+							title = mezmo_pipeline.my_pipeline.title
+						}
+					}
 					`,
-				ExpectNonEmptyPlan: true,
 				Check: resource.ComposeTestCheckFunc(
 					resource.TestCheckResourceAttr("mezmo_pipeline.my_pipeline", "title", "Updated Pipeline"),
 					StateHasExpectedValues("mezmo_publish_pipeline.my_publish_pipeline", map[string]any{
-						"pipeline_id": "#mezmo_pipeline.my_pipeline.id",
+						"pipeline_id":    "#mezmo_pipeline.my_pipeline.id",
+						"triggers.title": "Updated Pipeline",
+					}),
+				),
+			},
+			// The previous behavior of always publishing
+			{
+				Config: GetProviderConfig() + `
+					resource "mezmo_pipeline" "my_pipeline" {
+						title = "Updated Pipeline"
+					}
+					resource "mezmo_publish_pipeline" "my_publish_pipeline" {
+						pipeline_id    = mezmo_pipeline.my_pipeline.id
+						always_publish = true
+					}
+					`,
+				ExpectNonEmptyPlan: true, // We always re-create which causes a non-empty plan.
+				Check: resource.ComposeTestCheckFunc(
+					StateHasExpectedValues("mezmo_publish_pipeline.my_publish_pipeline", map[string]any{
+						"pipeline_id":    "#mezmo_pipeline.my_pipeline.id",
+						"always_publish": "true",
 					}),
 				),
 			},
@@ -0,0 +1,78 @@
+package provider
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/hashicorp/terraform-plugin-framework/datasource"
+	"github.com/mezmo/terraform-provider-mezmo/v5/internal/client"
+	. "github.com/mezmo/terraform-provider-mezmo/v5/internal/provider/models"
+)
+
+var (
+	_ datasource.DataSource              = &SharedSourceDataSource{}
+	_ datasource.DataSourceWithConfigure = &SharedSourceDataSource{}
+)
+
+func NewSharedSourceDataSource() datasource.DataSource {
+	return &SharedSourceDataSource{}
+}
+
+type SharedSourceDataSource struct {
+	client client.Client
+}
+
+func (d *SharedSourceDataSource) TypeName() string {
+	return PROVIDER_TYPE_NAME + "_shared_source"
+}
+
+// Configure implements datasource.DataSourceWithConfigure.
+func (d *SharedSourceDataSource) Configure(_ context.Context, req datasource.ConfigureRequest, resp *datasource.ConfigureResponse) {
+	if req.ProviderData == nil {
+		return
+	}
+
+	client, ok := req.ProviderData.(client.Client)
+
+	if !ok {
+		resp.Diagnostics.AddError(
+			"Unexpected Data Source Configure Type",
+			fmt.Sprintf("Expected client.Client, got: %T. Please report this issue to Mezmo.", req.ProviderData),
+		)
+
+		return
+	}
+
+	d.client = client
+}
+
+// Metadata implements datasource.DataSource.
+func (d *SharedSourceDataSource) Metadata(_ context.Context, _ datasource.MetadataRequest, resp *datasource.MetadataResponse) {
+	resp.TypeName = d.TypeName()
+}
+
+// Schema implements datasource.DataSource.
+func (d *SharedSourceDataSource) Schema(_ context.Context, _ datasource.SchemaRequest, resp *datasource.SchemaResponse) {
+	resp.Schema = SharedSourceDataSourceSchema()
+}
+
+// Read implements datasource.DataSource.
+func (d *SharedSourceDataSource) Read(ctx context.Context, req datasource.ReadRequest, resp *datasource.ReadResponse) {
+	var config SharedSourceResourceModel
+	if diags := req.Config.Get(ctx, &config); setDiagnosticsHasError(diags, &resp.Diagnostics) {
+		return
+	}
+
+	source, err := d.client.SharedSource(config.Id.ValueString(), ctx)
+	if err != nil {
+		addClientErrorDiagnostic(&resp.Diagnostics, err,
+			"Error Reading Shared Source",
+			"Could not read shared source with id "+config.Id.ValueString()+": "+err.Error(),
+		)
+		return
+	}
+
+	SharedSourceToModel(&config, source)
+	diags := resp.State.Set(ctx, config)
+	resp.Diagnostics.Append(diags...)
+}
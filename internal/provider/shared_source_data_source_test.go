@@ -0,0 +1,46 @@
+package provider
+
+import (
+	"regexp"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-testing/helper/resource"
+	. "github.com/mezmo/terraform-provider-mezmo/v5/internal/provider/providertest"
+)
+
+func TestSharedSourceDataSource(t *testing.T) {
+	resource.Test(t, resource.TestCase{
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		PreCheck:                 func() { TestPreCheck(t) },
+		Steps: []resource.TestStep{
+			// Required fields test
+			{
+				Config: GetProviderConfig() + `
+					data "mezmo_shared_source" "lookup" {
+					}`,
+				ExpectError: regexp.MustCompile("Missing required argument"),
+			},
+			// Read testing
+			{
+				Config: GetProviderConfig() + `
+					resource "mezmo_shared_source" "my_source" {
+						title = "HTTP Shared"
+						description = "A shared source to look up"
+						type = "http"
+					}
+					data "mezmo_shared_source" "lookup" {
+						id = mezmo_shared_source.my_source.id
+					}`,
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttrPair("data.mezmo_shared_source.lookup", "id", "mezmo_shared_source.my_source", "id"),
+					resource.TestCheckResourceAttrPair("data.mezmo_shared_source.lookup", "consumer_id", "mezmo_shared_source.my_source", "consumer_id"),
+					StateHasExpectedValues("data.mezmo_shared_source.lookup", map[string]any{
+						"title":       "HTTP Shared",
+						"description": "A shared source to look up",
+						"type":        "http",
+					}),
+				),
+			},
+		},
+	})
+}
@@ -108,7 +108,7 @@ func (r *SharedSourceResource) Create(ctx context.Context, req resource.CreateRe
 	}
 
 	if err != nil {
-		resp.Diagnostics.AddError(
+		addClientErrorDiagnostic(&resp.Diagnostics, err,
 			"Error creating shared source",
 			"Could not create shared source, unexpected error: "+err.Error(),
 		)
@@ -134,7 +134,7 @@ func (r *SharedSourceResource) Read(ctx context.Context, req resource.ReadReques
 		return
 	}
 	if err != nil {
-		resp.Diagnostics.AddError(
+		addClientErrorDiagnostic(&resp.Diagnostics, err,
 			"Error Reading SharedSource",
 			"Could not read shared source with id  "+state.Id.ValueString()+": "+err.Error(),
 		)
@@ -142,6 +142,8 @@ func (r *SharedSourceResource) Read(ctx context.Context, req resource.ReadReques
 	}
 
 	SharedSourceToModel(&state, source)
+	diags := resp.State.Set(ctx, state)
+	setDiagnosticsHasError(diags, &resp.Diagnostics)
 }
 
 func (r *SharedSourceResource) Update(ctx context.Context, req resource.UpdateRequest, resp *resource.UpdateResponse) {
@@ -169,7 +171,7 @@ func (r *SharedSourceResource) Update(ctx context.Context, req resource.UpdateRe
 		fmt.Println(Json("----- Shared Source FROM Update api ---", stored))
 	}
 	if err != nil {
-		resp.Diagnostics.AddError(
+		addClientErrorDiagnostic(&resp.Diagnostics, err,
 			"Error Updating Shared Source",
 			"Could not update shared source, unexpected error: "+err.Error(),
 		)
@@ -196,7 +198,7 @@ func (r *SharedSourceResource) Delete(ctx context.Context, req resource.DeleteRe
 		return
 	}
 	if err != nil {
-		resp.Diagnostics.AddError(
+		addClientErrorDiagnostic(&resp.Diagnostics, err,
 			"Error Deleting Shared Source",
 			"Could not delete shared source, unexpected error: "+err.Error(),
 		)
@@ -1,15 +1,19 @@
 package provider
 
 import (
+	"context"
 	"regexp"
 	"testing"
 
 	"github.com/hashicorp/terraform-plugin-testing/helper/resource"
+	"github.com/hashicorp/terraform-plugin-testing/terraform"
+	"github.com/mezmo/terraform-provider-mezmo/v5/internal/client"
 	. "github.com/mezmo/terraform-provider-mezmo/v5/internal/provider/providertest"
 )
 
 func TestSharedSourceResource(t *testing.T) {
 	cacheKey := "shared_source_tests"
+	var sharedSourceId string
 	resource.Test(t, resource.TestCase{
 		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
 		PreCheck:                 func() { TestPreCheck(t) },
@@ -54,11 +58,47 @@ func TestSharedSourceResource(t *testing.T) {
 					resource.TestMatchResourceAttr("mezmo_shared_source.my_source", "id", IDRegex),
 					resource.TestMatchResourceAttr("mezmo_shared_source.my_source", "consumer_id", IDRegex),
 					StateHasExpectedValues("mezmo_shared_source.my_source", map[string]any{
-						"title":       "updated title",
-						"description": "updated description",
+						"title":                "updated title",
+						"description":          "updated description",
+						"attached_pipelines.#": "0",
 					}),
+					func(s *terraform.State) error {
+						sharedSourceId = s.RootModule().Resources["mezmo_shared_source.my_source"].Primary.ID
+						return nil
+					},
 				),
 			},
+			// Changes made outside of Terraform are refreshed
+			{
+				PreConfig: func() {
+					source := client.SharedSource{Id: sharedSourceId, Title: "changed in the UI", Type: "http"}
+					if _, err := NewTestClient().UpdateSharedSource(&source, context.Background()); err != nil {
+						t.Fatalf("could not update shared source: %s", err)
+					}
+				},
+				Config: GetCachedConfig(cacheKey) + `
+					resource "mezmo_shared_source" "my_source" {
+						title = "updated title"
+						description = "updated description"
+						type = "http"
+					}
+				`,
+				PlanOnly:           true,
+				ExpectNonEmptyPlan: true,
+			},
+			// Import
+			{
+				Config: GetCachedConfig(cacheKey) + `
+					resource "mezmo_shared_source" "my_source" {
+						title = "updated title"
+						description = "updated description"
+						type = "http"
+					}
+				`,
+				ImportState:       true,
+				ResourceName:      "mezmo_shared_source.my_source",
+				ImportStateVerify: true,
+			},
 			// Updating `type` causes the whole resource to be re-created
 			{
 				Config: GetCachedConfig(cacheKey) + `
@@ -78,6 +118,44 @@ func TestSharedSourceResource(t *testing.T) {
 					}),
 				),
 			},
+			// Pipeline sources using the shared source are listed after a refresh
+			{
+				Config: GetCachedConfig(cacheKey) + `
+					resource "mezmo_shared_source" "my_source" {
+						title = "updated title"
+						type = "kinesis-firehose"
+					}
+					resource "mezmo_pipeline" "consumer" {
+						title = "shared source consumer"
+					}
+					resource "mezmo_kinesis_firehose_source" "consumer" {
+						pipeline_id = mezmo_pipeline.consumer.id
+						shared_source_id = mezmo_shared_source.my_source.id
+					}
+				`,
+			},
+			{
+				Config: GetCachedConfig(cacheKey) + `
+					resource "mezmo_shared_source" "my_source" {
+						title = "updated title"
+						type = "kinesis-firehose"
+					}
+					resource "mezmo_pipeline" "consumer" {
+						title = "shared source consumer"
+					}
+					resource "mezmo_kinesis_firehose_source" "consumer" {
+						pipeline_id = mezmo_pipeline.consumer.id
+						shared_source_id = mezmo_shared_source.my_source.id
+					}
+				`,
+				Check: resource.ComposeTestCheckFunc(
+					StateHasExpectedValues("mezmo_shared_source.my_source", map[string]any{
+						"attached_pipelines.#":             "1",
+						"attached_pipelines.0.pipeline_id": "#mezmo_pipeline.consumer.id",
+						"attached_pipelines.0.source_id":   "#mezmo_kinesis_firehose_source.consumer.id",
+					}),
+				),
+			},
 			// Delete testing automatically occurs in TestCase
 		},
 	})
@@ -7,6 +7,7 @@ import (
 	"reflect"
 	"strings"
 
+	"github.com/hashicorp/terraform-plugin-framework/diag"
 	"github.com/hashicorp/terraform-plugin-framework/path"
 
 	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
@@ -76,7 +77,9 @@ func (r *SourceResource[T]) ConvertToTerraformModel(component *reflect.Value) (*
 	}
 
 	var model T
-	r.toModelFunc(&model, &source)
+	if diags := r.toModelFunc(&model, &source); diags.HasError() {
+		return nil, errors.New(diagnosticsDetail(diags))
+	}
 	res := reflect.ValueOf(model)
 	return &res, nil
 }
@@ -116,7 +119,7 @@ func (r *SourceResource[T]) Create(ctx context.Context, req resource.CreateReque
 
 	stored, err := r.client.CreateSource(r.getPipelineIdFunc(&plan).ValueString(), component, ctx)
 	if err != nil {
-		resp.Diagnostics.AddError(
+		addApiErrorDiagnostics[T](&resp.Diagnostics, err, r.schema, component,
 			"Error creating source",
 			"Could not create source, unexpected error: "+err.Error(),
 		)
@@ -125,7 +128,9 @@ func (r *SourceResource[T]) Create(ctx context.Context, req resource.CreateReque
 
 	NullifyPlanFields(&plan, r.schema)
 
-	r.toModelFunc(&plan, stored)
+	if diags := r.toModelFunc(&plan, stored); setDiagnosticsHasError(diags, &resp.Diagnostics) {
+		return
+	}
 	diags = resp.State.Set(ctx, plan)
 	resp.Diagnostics.Append(diags...)
 }
@@ -142,7 +147,7 @@ func (r *SourceResource[T]) Delete(ctx context.Context, req resource.DeleteReque
 	// Delete existing order
 	err := r.client.DeleteSource(r.getPipelineIdFunc(&state).ValueString(), r.getIdFunc(&state).ValueString(), ctx)
 	if err != nil {
-		resp.Diagnostics.AddError(
+		addClientErrorDiagnostic(&resp.Diagnostics, err,
 			"Error Deleting Source",
 			"Could not delete source, unexpected error: "+err.Error(),
 		)
@@ -170,7 +175,7 @@ func (r *SourceResource[T]) Read(ctx context.Context, req resource.ReadRequest,
 		return
 	}
 	if err != nil {
-		resp.Diagnostics.AddError(
+		addClientErrorDiagnostic(&resp.Diagnostics, err,
 			"Error Reading Source",
 			fmt.Sprintf("Could not read source with id %s and pipeline_id %s: %s",
 				r.getIdFunc(&state), r.getPipelineIdFunc(&state), err.Error()),
@@ -180,7 +185,9 @@ func (r *SourceResource[T]) Read(ctx context.Context, req resource.ReadRequest,
 
 	NullifyPlanFields(&state, r.schema)
 
-	r.toModelFunc(&state, component)
+	if diags := r.toModelFunc(&state, component); setDiagnosticsHasError(diags, &resp.Diagnostics) {
+		return
+	}
 	diags := resp.State.Set(ctx, state)
 	resp.Diagnostics.Append(diags...)
 }
@@ -202,9 +209,13 @@ func (r *SourceResource[T]) Update(ctx context.Context, req resource.UpdateReque
 		return
 	}
 
-	stored, err := r.client.UpdateSource(r.getPipelineIdFunc(&state).ValueString(), component, ctx)
+	pipelineId := r.getPipelineIdFunc(&state).ValueString()
+	stored, err := r.client.UpdateSource(pipelineId, component, ctx)
 	if err != nil {
-		resp.Diagnostics.AddError(
+		if client.IsConflictError(err) && r.reportConflict(ctx, pipelineId, &state, &resp.Diagnostics) {
+			return
+		}
+		addApiErrorDiagnostics[T](&resp.Diagnostics, err, r.schema, component,
 			"Error Updating Source",
 			"Could not update source, unexpected error: "+err.Error(),
 		)
@@ -213,11 +224,23 @@ func (r *SourceResource[T]) Update(ctx context.Context, req resource.UpdateReque
 
 	NullifyPlanFields(&plan, r.schema)
 
-	r.toModelFunc(&plan, stored)
+	if diags := r.toModelFunc(&plan, stored); setDiagnosticsHasError(diags, &resp.Diagnostics) {
+		return
+	}
 	diags := resp.State.Set(ctx, plan)
 	resp.Diagnostics.Append(diags...)
 }
 
+// Reports a source that the API could not update because it was modified outside of terraform.
+// Errors reading it are left to the update to report.
+func (r *SourceResource[T]) reportConflict(ctx context.Context, pipelineId string, state *T, diags *diag.Diagnostics) bool {
+	remote, err := r.client.Source(pipelineId, r.getIdFunc(state).ValueString(), ctx)
+	if err != nil {
+		return false
+	}
+	return reportNodeConflict("source", state, remote, r.fromModelFunc, r.toModelFunc, sourceNode, diags)
+}
+
 // Schema implements resource.Resource.
 func (r *SourceResource[T]) Schema(_ context.Context, _ resource.SchemaRequest, resp *resource.SchemaResponse) {
 	resp.Schema = r.schema
@@ -12,7 +12,7 @@ func NewDemoSourceResource() resource.Resource {
 		typeName:          DEMO_SOURCE_TYPE_NAME,
 		nodeName:          DEMO_SOURCE_NODE_NAME,
 		fromModelFunc:     DemoSourceFromModel,
-		toModelFunc:       DemoSourceToModel,
+		toModelFunc:       infallibleToModel(DemoSourceToModel),
 		getIdFunc:         func(m *DemoSourceModel) basetypes.StringValue { return m.Id },
 		getPipelineIdFunc: func(m *DemoSourceModel) basetypes.StringValue { return m.PipelineId },
 		schema:            DemoSourceResourceSchema,
@@ -24,7 +24,7 @@ func NewAgentSourceResource() resource.Resource {
 		typeName:          AGENT_SOURCE_TYPE_NAME,
 		nodeName:          AGENT_SOURCE_NODE_NAME,
 		fromModelFunc:     AgentSourceFromModel,
-		toModelFunc:       AgentSourceToModel,
+		toModelFunc:       infallibleToModel(AgentSourceToModel),
 		getIdFunc:         func(m *AgentSourceModel) basetypes.StringValue { return m.Id },
 		getPipelineIdFunc: func(m *AgentSourceModel) basetypes.StringValue { return m.PipelineId },
 		schema:            AgentSourceResourceSchema,
@@ -36,7 +36,7 @@ func NewKafkaSourceResource() resource.Resource {
 		typeName:          KAFKA_SOURCE_TYPE_NAME,
 		nodeName:          KAFKA_SOURCE_NODE_NAME,
 		fromModelFunc:     KafkaSourceFromModel,
-		toModelFunc:       KafkaSourceToModel,
+		toModelFunc:       infallibleToModel(KafkaSourceToModel),
 		getIdFunc:         func(m *KafkaSourceModel) basetypes.StringValue { return m.Id },
 		getPipelineIdFunc: func(m *KafkaSourceModel) basetypes.StringValue { return m.PipelineId },
 		schema:            KafkaSourceResourceSchema,
@@ -48,7 +48,7 @@ func NewPrometheusRemoteWriteSourceResource() resource.Resource {
 		typeName:          PROMETHEUS_REMOTE_WRITE_SOURCE_TYPE_NAME,
 		nodeName:          PROMETHEUS_REMOTE_WRITE_SOURCE_NODE_NAME,
 		fromModelFunc:     PrometheusRemoteWriteSourceFromModel,
-		toModelFunc:       PrometheusRemoteWriteSourceToModel,
+		toModelFunc:       infallibleToModel(PrometheusRemoteWriteSourceToModel),
 		getIdFunc:         func(m *PrometheusRemoteWriteSourceModel) basetypes.StringValue { return m.Id },
 		getPipelineIdFunc: func(m *PrometheusRemoteWriteSourceModel) basetypes.StringValue { return m.PipelineId },
 		schema:            PrometheusRemoteWriteSourceResourceSchema,
@@ -60,7 +60,7 @@ func NewS3SourceResource() resource.Resource {
 		typeName:          S3_SOURCE_TYPE_NAME,
 		nodeName:          S3_SOURCE_NODE_NAME,
 		fromModelFunc:     S3SourceFromModel,
-		toModelFunc:       S3SourceToModel,
+		toModelFunc:       infallibleToModel(S3SourceToModel),
 		getIdFunc:         func(m *S3SourceModel) basetypes.StringValue { return m.Id },
 		getPipelineIdFunc: func(m *S3SourceModel) basetypes.StringValue { return m.PipelineId },
 		schema:            S3SourceResourceSchema,
@@ -72,7 +72,7 @@ func NewHttpSourceResource() resource.Resource {
 		typeName:          HTTP_SOURCE_TYPE_NAME,
 		nodeName:          HTTP_SOURCE_NODE_NAME,
 		fromModelFunc:     HttpSourceFromModel,
-		toModelFunc:       HttpSourceToModel,
+		toModelFunc:       infallibleToModel(HttpSourceToModel),
 		getIdFunc:         func(m *HttpSourceModel) basetypes.StringValue { return m.Id },
 		getPipelineIdFunc: func(m *HttpSourceModel) basetypes.StringValue { return m.PipelineId },
 		schema:            HttpSourceResourceSchema,
@@ -84,7 +84,7 @@ func NewSQSSourceResource() resource.Resource {
 		typeName:          SQS_SOURCE_TYPE_NAME,
 		nodeName:          SQS_SOURCE_NODE_NAME,
 		fromModelFunc:     SQSSourceFromModel,
-		toModelFunc:       SQSSourceToModel,
+		toModelFunc:       infallibleToModel(SQSSourceToModel),
 		getIdFunc:         func(m *SQSSourceModel) basetypes.StringValue { return m.Id },
 		getPipelineIdFunc: func(m *SQSSourceModel) basetypes.StringValue { return m.PipelineId },
 		schema:            SQSSourceResourceSchema,
@@ -96,7 +96,7 @@ func NewSplunkHecSourceResource() resource.Resource {
 		typeName:          SPLUNK_HEC_SOURCE_TYPE_NAME,
 		nodeName:          SPLUNK_HEC_SOURCE_NODE_NAME,
 		fromModelFunc:     SplunkHecSourceFromModel,
-		toModelFunc:       SplunkHecSourceToModel,
+		toModelFunc:       infallibleToModel(SplunkHecSourceToModel),
 		getIdFunc:         func(m *SplunkHecSourceModel) basetypes.StringValue { return m.Id },
 		getPipelineIdFunc: func(m *SplunkHecSourceModel) basetypes.StringValue { return m.PipelineId },
 		schema:            SplunkHecSourceResourceSchema,
@@ -108,7 +108,7 @@ func NewLogStashSourceResource() resource.Resource {
 		typeName:          LOGSTASH_SOURCE_TYPE_NAME,
 		nodeName:          LOGSTASH_SOURCE_NODE_NAME,
 		fromModelFunc:     LogStashSourceFromModel,
-		toModelFunc:       LogStashSourceToModel,
+		toModelFunc:       infallibleToModel(LogStashSourceToModel),
 		getIdFunc:         func(m *LogStashSourceModel) basetypes.StringValue { return m.Id },
 		getPipelineIdFunc: func(m *LogStashSourceModel) basetypes.StringValue { return m.PipelineId },
 		schema:            LogStashSourceResourceSchema,
@@ -120,7 +120,7 @@ func NewFluentSourceResource() resource.Resource {
 		typeName:          FLUENT_SOURCE_TYPE_NAME,
 		nodeName:          FLUENT_SOURCE_NODE_NAME,
 		fromModelFunc:     FluentSourceFromModel,
-		toModelFunc:       FluentSourceToModel,
+		toModelFunc:       infallibleToModel(FluentSourceToModel),
 		getIdFunc:         func(m *FluentSourceModel) basetypes.StringValue { return m.Id },
 		getPipelineIdFunc: func(m *FluentSourceModel) basetypes.StringValue { return m.PipelineId },
 		schema:            FluentSourceResourceSchema,
@@ -132,7 +132,7 @@ func NewAzureEventHubSourceResource() resource.Resource {
 		typeName:          AZURE_EVENT_HUB_SOURCE_TYPE_NAME,
 		nodeName:          AZURE_EVENT_HUB_SOURCE_NODE_NAME,
 		fromModelFunc:     AzureEventHubSourceFromModel,
-		toModelFunc:       AzureEventHubSourceToModel,
+		toModelFunc:       infallibleToModel(AzureEventHubSourceToModel),
 		getIdFunc:         func(m *AzureEventHubSourceModel) basetypes.StringValue { return m.Id },
 		getPipelineIdFunc: func(m *AzureEventHubSourceModel) basetypes.StringValue { return m.PipelineId },
 		schema:            AzureEventHubSourceResourceSchema,
@@ -144,7 +144,7 @@ func NewKinesisFirehoseSourceResource() resource.Resource {
 		typeName:          KINESIS_FIREHOSE_SOURCE_TYPE_NAME,
 		nodeName:          KINESIS_FIREHOSE_SOURCE_NODE_NAME,
 		fromModelFunc:     KinesisFirehoseSourceFromModel,
-		toModelFunc:       KinesisFirehoseSourceToModel,
+		toModelFunc:       infallibleToModel(KinesisFirehoseSourceToModel),
 		getIdFunc:         func(m *KinesisFirehoseSourceModel) basetypes.StringValue { return m.Id },
 		getPipelineIdFunc: func(m *KinesisFirehoseSourceModel) basetypes.StringValue { return m.PipelineId },
 		schema:            KinesisFirehoseSourceResourceSchema,
@@ -156,7 +156,7 @@ func NewLogAnalysisSourceResource() resource.Resource {
 		typeName:          LOG_ANALYSIS_SOURCE_TYPE_NAME,
 		nodeName:          LOG_ANALYSIS_SOURCE_NODE_NAME,
 		fromModelFunc:     LogAnalysisSourceFromModel,
-		toModelFunc:       LogAnalysisSourceToModel,
+		toModelFunc:       infallibleToModel(LogAnalysisSourceToModel),
 		getIdFunc:         func(m *LogAnalysisSourceModel) basetypes.StringValue { return m.Id },
 		getPipelineIdFunc: func(m *LogAnalysisSourceModel) basetypes.StringValue { return m.PipelineId },
 		schema:            LogAnalysisSourceResourceSchema,
@@ -168,7 +168,7 @@ func NewLogAnalysisIngestionSourceResource() resource.Resource {
 		typeName:          LOG_ANALYSIS_INGESTION_SOURCE_TYPE_NAME,
 		nodeName:          LOG_ANALYSIS_INGESTION_SOURCE_NODE_NAME,
 		fromModelFunc:     LogAnalysisIngestionSourceFromModel,
-		toModelFunc:       LogAnalysisIngestionSourceToModel,
+		toModelFunc:       infallibleToModel(LogAnalysisIngestionSourceToModel),
 		getIdFunc:         func(m *LogAnalysisIngestionSourceModel) basetypes.StringValue { return m.Id },
 		getPipelineIdFunc: func(m *LogAnalysisIngestionSourceModel) basetypes.StringValue { return m.PipelineId },
 		schema:            LogAnalysisIngestionSourceResourceSchema,
@@ -180,7 +180,7 @@ func NewWebhookSourceResource() resource.Resource {
 		typeName:          WEBHOOK_SOURCE_TYPE_NAME,
 		nodeName:          WEBHOOK_SOURCE_NODE_NAME,
 		fromModelFunc:     WebhookSourceFromModel,
-		toModelFunc:       WebhookSourceToModel,
+		toModelFunc:       infallibleToModel(WebhookSourceToModel),
 		getIdFunc:         func(m *WebhookSourceModel) basetypes.StringValue { return m.Id },
 		getPipelineIdFunc: func(m *WebhookSourceModel) basetypes.StringValue { return m.PipelineId },
 		schema:            WebhookSourceResourceSchema,
@@ -192,7 +192,7 @@ func NewDatadogSourceResource() resource.Resource {
 		typeName:          DATADOG_SOURCE_TYPE_NAME,
 		nodeName:          DATADOG_SOURCE_NODE_NAME,
 		fromModelFunc:     DatadogSourceFromModel,
-		toModelFunc:       DatadogSourceToModel,
+		toModelFunc:       infallibleToModel(DatadogSourceToModel),
 		getIdFunc:         func(m *DatadogSourceModel) basetypes.StringValue { return m.Id },
 		getPipelineIdFunc: func(m *DatadogSourceModel) basetypes.StringValue { return m.PipelineId },
 		schema:            DatadogSourceResourceSchema,
@@ -204,7 +204,7 @@ func NewOpenTelemetryLogsSourceResource() resource.Resource {
 		typeName:          OPEN_TELEMETRY_LOGS_SOURCE_TYPE_NAME,
 		nodeName:          OPEN_TELEMETRY_LOGS_SOURCE_NODE_NAME,
 		fromModelFunc:     OpenTelemetryLogsSourceFromModel,
-		toModelFunc:       OpenTelemetryLogsSourceToModel,
+		toModelFunc:       infallibleToModel(OpenTelemetryLogsSourceToModel),
 		getIdFunc:         func(m *OpenTelemetryLogsSourceModel) basetypes.StringValue { return m.Id },
 		getPipelineIdFunc: func(m *OpenTelemetryLogsSourceModel) basetypes.StringValue { return m.PipelineId },
 		schema:            OpenTelemetryLogsSourceResourceSchema,
@@ -216,7 +216,7 @@ func NewOpenTelemetryMetricsSourceResource() resource.Resource {
 		typeName:          OPEN_TELEMETRY_METRICS_SOURCE_TYPE_NAME,
 		nodeName:          OPEN_TELEMETRY_METRICS_SOURCE_NODE_NAME,
 		fromModelFunc:     OpenTelemetryMetricsSourceFromModel,
-		toModelFunc:       OpenTelemetryMetricsSourceToModel,
+		toModelFunc:       infallibleToModel(OpenTelemetryMetricsSourceToModel),
 		getIdFunc:         func(m *OpenTelemetryMetricsSourceModel) basetypes.StringValue { return m.Id },
 		getPipelineIdFunc: func(m *OpenTelemetryMetricsSourceModel) basetypes.StringValue { return m.PipelineId },
 		schema:            OpenTelemetryMetricsSourceResourceSchema,
@@ -228,7 +228,7 @@ func NewOpenTelemetryTracesSourceResource() resource.Resource {
 		typeName:          OPEN_TELEMETRY_TRACES_SOURCE_TYPE_NAME,
 		nodeName:          OPEN_TELEMETRY_TRACES_SOURCE_NODE_NAME,
 		fromModelFunc:     OpenTelemetryTracesSourceFromModel,
-		toModelFunc:       OpenTelemetryTracesSourceToModel,
+		toModelFunc:       infallibleToModel(OpenTelemetryTracesSourceToModel),
 		getIdFunc:         func(m *OpenTelemetryTracesSourceModel) basetypes.StringValue { return m.Id },
 		getPipelineIdFunc: func(m *OpenTelemetryTracesSourceModel) basetypes.StringValue { return m.PipelineId },
 		schema:            OpenTelemetryTracesSourceResourceSchema,
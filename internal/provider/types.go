@@ -15,14 +15,22 @@ type ComponentModel interface {
 type idGetterFunc[T ComponentModel] func(*T) basetypes.StringValue
 type getSchemaFunc func() schema.Schema
 
-type sourceToModelFunc[T ComponentModel] func(model *T, component *Source)
+type sourceToModelFunc[T ComponentModel] func(model *T, component *Source) diag.Diagnostics
 type sourceFromModelFunc[T ComponentModel] func(model *T, previousState *T) (*Source, diag.Diagnostics)
 
-type processorToModelFunc[T ComponentModel] func(model *T, component *Processor)
+type processorToModelFunc[T ComponentModel] func(model *T, component *Processor) diag.Diagnostics
 type processorFromModelFunc[T ComponentModel] func(model *T, previousState *T) (*Processor, diag.Diagnostics)
 
-type destinationToModelFunc[T ComponentModel] func(model *T, component *Destination)
+type destinationToModelFunc[T ComponentModel] func(model *T, component *Destination) diag.Diagnostics
 type destinationFromModelFunc[T ComponentModel] func(model *T, previousState *T) (*Destination, diag.Diagnostics)
 
 type alertToModelFunc[T AlertModel] func(model *T, component *Alert)
 type alertFromModelFunc[T AlertModel] func(model *T, previousState *T) (*Alert, diag.Diagnostics)
+
+// Adapts a conversion to a model that cannot fail to the conversions of the resources
+func infallibleToModel[T any, C any](toModel func(model *T, component *C)) func(model *T, component *C) diag.Diagnostics {
+	return func(model *T, component *C) diag.Diagnostics {
+		toModel(model, component)
+		return nil
+	}
+}
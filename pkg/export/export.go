@@ -0,0 +1,308 @@
+// Package export turns an existing pipeline into Terraform configuration, using the
+// resource definitions from `pkg/resources` to convert each component into its model.
+package export
+
+import (
+	"context"
+	"fmt"
+	"reflect"
+	"strings"
+
+	"github.com/hashicorp/hcl/v2/hclwrite"
+	"github.com/hashicorp/terraform-plugin-framework/attr"
+	"github.com/hashicorp/terraform-plugin-framework/types/basetypes"
+	"github.com/mezmo/terraform-provider-mezmo/v5/internal/client"
+	"github.com/mezmo/terraform-provider-mezmo/v5/pkg/resources"
+	"github.com/zclconf/go-cty/cty"
+)
+
+// The subset of the API client needed to export a pipeline
+type Reader interface {
+	PipelineGraph(id string, ctx context.Context) (*client.PipelineGraph, error)
+}
+
+// A generated configuration file
+type File struct {
+	Name    string
+	Content []byte
+}
+
+type Result struct {
+	Files []File
+	// Things that were exported on a best-effort basis and need a look before applying
+	Warnings []string
+}
+
+// The kinds of exported resources, in the order their files are written
+const (
+	kindPipeline    = "pipeline"
+	kindSource      = "sources"
+	kindProcessor   = "processors"
+	kindDestination = "destinations"
+	kindAlert       = "alerts"
+)
+
+var kinds = []string{kindPipeline, kindSource, kindProcessor, kindDestination, kindAlert}
+
+type exportedResource struct {
+	kind       string
+	definition resources.ConvertibleResourceDef
+	name       string
+	importId   string
+	attributes map[string]attr.Value
+}
+
+func (r *exportedResource) address() string {
+	return r.definition.TypeName() + "." + r.name
+}
+
+func (r *exportedResource) reference(path ...any) reference {
+	return reference{resourceType: r.definition.TypeName(), name: r.name, path: path}
+}
+
+type exporter struct {
+	definitions map[string]resources.ConvertibleResourceDef
+	names       map[string]bool
+	// Maps component ids and output ids to the attribute that holds them
+	references map[string]reference
+	exported   []*exportedResource
+	warnings   []string
+}
+
+// Fetches a pipeline with all of its components and alerts, and renders them as Terraform
+// configuration along with the `import` blocks needed to adopt them.
+func Export(ctx context.Context, reader Reader, pipelineId string) (*Result, error) {
+	definitions, err := resources.ConvertibleResources()
+	if err != nil {
+		return nil, err
+	}
+	e := &exporter{
+		definitions: make(map[string]resources.ConvertibleResourceDef, len(definitions)),
+		names:       make(map[string]bool),
+		references:  make(map[string]reference),
+	}
+	for _, definition := range definitions {
+		e.definitions[definition.NodeType()] = definition
+	}
+
+	graph, err := reader.PipelineGraph(pipelineId, ctx)
+	if err != nil {
+		return nil, fmt.Errorf("could not read pipeline %s: %w", pipelineId, err)
+	}
+	pipeline := graph.Pipeline
+
+	pipelineResource, err := e.add(kindPipeline, "pipeline", pipeline.Id, pipeline.Title, pipeline)
+	if err != nil {
+		return nil, err
+	}
+	if pipelineResource == nil {
+		return nil, fmt.Errorf("the pipeline resource is not convertible")
+	}
+	e.references[pipeline.Id] = pipelineResource.reference("id")
+
+	for _, source := range graph.Sources {
+		nodeType := strings.ReplaceAll(source.Type, "-", "_") + "_source"
+		e.addComponent(kindSource, nodeType, pipelineId, source.Id, source.Title, source)
+	}
+	for _, processor := range graph.Processors {
+		nodeType := strings.ReplaceAll(processor.Type, "-", "_") + "_processor"
+		e.addComponent(kindProcessor, nodeType, pipelineId, processor.Id, processor.Title, processor)
+	}
+	for _, destination := range graph.Destinations {
+		nodeType := strings.ReplaceAll(destination.Type, "-", "_") + "_destination"
+		e.addComponent(kindDestination, nodeType, pipelineId, destination.Id, destination.Title, destination)
+	}
+	for _, alert := range graph.Alerts {
+		alertType, _ := nestedString(alert.AlertConfig, "evaluation", "alert_type")
+		name, _ := nestedString(alert.AlertConfig, "general", "name")
+		e.addComponent(kindAlert, alertType+"_alert", pipelineId, alert.Id, name, alert)
+	}
+
+	return e.render(pipelineResource)
+}
+
+func (e *exporter) addComponent(kind string, nodeType string, pipelineId string, id string, title string, component any) {
+	exported, err := e.add(kind, nodeType, pipelineId+"/"+id, title, component)
+	if err != nil {
+		e.warn("Skipping %s %s: %s", nodeType, id, err)
+		return
+	}
+	if exported == nil {
+		e.warn("Skipping %s %s: there is no Terraform resource for this type", nodeType, id)
+		return
+	}
+	if kind == kindAlert {
+		return
+	}
+	e.references[id] = exported.reference("id")
+	e.addOutputReferences(exported)
+}
+
+// Converts an API object into a resource model. Returns nil when the node type has no resource.
+func (e *exporter) add(kind string, nodeType string, importId string, title string, component any) (*exportedResource, error) {
+	definition, ok := e.definitions[nodeType]
+	if !ok {
+		return nil, nil
+	}
+	value := reflect.ValueOf(component)
+	model, err := definition.ConvertToTerraformModel(&value)
+	if err != nil {
+		return nil, err
+	}
+	attributes, err := modelAttributes(*model)
+	if err != nil {
+		return nil, err
+	}
+	exported := &exportedResource{
+		kind:       kind,
+		definition: definition,
+		name:       resourceName(title, nodeType, e.names),
+		importId:   importId,
+		attributes: attributes,
+	}
+	e.exported = append(e.exported, exported)
+	return exported, nil
+}
+
+// Processors with multiple outputs (e.g. route) expose the output ids as `unmatched` and as
+// `output_name` inside list items such as `conditionals` or `parsers`.
+func (e *exporter) addOutputReferences(exported *exportedResource) {
+	for name, value := range exported.attributes {
+		switch v := value.(type) {
+		case basetypes.StringValue:
+			if name == "unmatched" && !v.IsNull() && v.ValueString() != "" {
+				e.references[v.ValueString()] = exported.reference(name)
+			}
+		case basetypes.ListValue:
+			for i, element := range v.Elements() {
+				object, ok := element.(basetypes.ObjectValue)
+				if !ok {
+					continue
+				}
+				output, ok := object.Attributes()["output_name"].(basetypes.StringValue)
+				if ok && !output.IsNull() && output.ValueString() != "" {
+					e.references[output.ValueString()] = exported.reference(name, i, "output_name")
+				}
+			}
+		}
+	}
+}
+
+func (e *exporter) render(pipeline *exportedResource) (*Result, error) {
+	files := make(map[string]*hclwrite.File, len(kinds))
+	imports := hclwrite.NewEmptyFile()
+	for _, exported := range e.exported {
+		file, ok := files[exported.kind]
+		if !ok {
+			file = hclwrite.NewEmptyFile()
+			files[exported.kind] = file
+		} else {
+			file.Body().AppendNewline()
+		}
+		if err := e.renderResource(file.Body(), exported, pipeline); err != nil {
+			return nil, fmt.Errorf("could not render %s: %w", exported.address(), err)
+		}
+
+		if len(imports.Body().Blocks()) > 0 {
+			imports.Body().AppendNewline()
+		}
+		block := imports.Body().AppendNewBlock("import", nil).Body()
+		block.SetAttributeTraversal("to", exported.reference().traversal())
+		block.SetAttributeValue("id", cty.StringVal(exported.importId))
+	}
+
+	result := &Result{Warnings: e.warnings}
+	for _, kind := range kinds {
+		if file, ok := files[kind]; ok {
+			result.Files = append(result.Files, File{Name: kind + ".tf", Content: file.Bytes()})
+		}
+	}
+	result.Files = append(result.Files, File{Name: "imports.tf", Content: imports.Bytes()})
+	return result, nil
+}
+
+func (e *exporter) renderResource(body *hclwrite.Body, exported *exportedResource, pipeline *exportedResource) error {
+	block := body.AppendNewBlock("resource", []string{exported.definition.TypeName(), exported.name}).Body()
+	attributes := exported.definition.TerraformSchema().Attributes
+	var sensitive []string
+
+	for _, name := range orderedNames(attributes) {
+		attribute := attributes[name]
+		if isComputedOnly(attribute) {
+			continue
+		}
+		if name == "pipeline_id" && exported != pipeline {
+			block.SetAttributeTraversal(name, pipeline.reference("id").traversal())
+			continue
+		}
+		value, ok := exported.attributes[name]
+		if !ok || value.IsNull() || value.IsUnknown() {
+			continue
+		}
+		if attribute.IsSensitive() {
+			sensitive = append(sensitive, name)
+		}
+
+		switch {
+		case name == "inputs":
+			block.SetAttributeRaw(name, e.inputTokens(exported, value))
+		case name == "component_id":
+			block.SetAttributeRaw(name, e.referenceTokens(exported, value.(basetypes.StringValue).ValueString()))
+		default:
+			tokens, err := valueTokens(value, nestedAttributes(attribute))
+			if err != nil {
+				return fmt.Errorf("attribute %s: %w", name, err)
+			}
+			block.SetAttributeRaw(name, tokens)
+		}
+	}
+
+	if len(sensitive) > 0 {
+		e.warn("%s contains sensitive values (%s) in plain text. Consider moving them to variables.",
+			exported.address(), strings.Join(sensitive, ", "))
+	}
+	return nil
+}
+
+func (e *exporter) inputTokens(exported *exportedResource, value attr.Value) hclwrite.Tokens {
+	list, ok := value.(basetypes.ListValue)
+	if !ok {
+		return nil
+	}
+	items := make([]hclwrite.Tokens, 0, len(list.Elements()))
+	for _, element := range list.Elements() {
+		input, ok := element.(basetypes.StringValue)
+		if !ok {
+			continue
+		}
+		items = append(items, e.referenceTokens(exported, input.ValueString()))
+	}
+	return hclwrite.TokensForTuple(items)
+}
+
+// Renders an id as a reference to the exported resource that holds it, or as a literal
+// when it does not belong to anything that was exported.
+func (e *exporter) referenceTokens(exported *exportedResource, id string) hclwrite.Tokens {
+	if ref, ok := e.references[id]; ok {
+		return hclwrite.TokensForTraversal(ref.traversal())
+	}
+	e.warn("%s references %s, which is not part of the export. It was kept as a literal id.", exported.address(), id)
+	return hclwrite.TokensForValue(cty.StringVal(id))
+}
+
+func (e *exporter) warn(format string, args ...any) {
+	e.warnings = append(e.warnings, fmt.Sprintf(format, args...))
+}
+
+func nestedString(values map[string]any, keys ...string) (string, bool) {
+	var current any = values
+	for _, key := range keys {
+		m, ok := current.(map[string]any)
+		if !ok {
+			return "", false
+		}
+		current = m[key]
+	}
+	s, ok := current.(string)
+	return s, ok
+}
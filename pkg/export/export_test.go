@@ -0,0 +1,175 @@
+package export
+
+import (
+	"context"
+	"encoding/json"
+	"os"
+	"path"
+	"path/filepath"
+	"runtime"
+	"strings"
+	"testing"
+
+	"github.com/hashicorp/hcl/v2"
+	"github.com/hashicorp/hcl/v2/hclwrite"
+	"github.com/mezmo/terraform-provider-mezmo/v5/internal/client"
+)
+
+var (
+	_, b, _, _   = runtime.Caller(0)
+	testdataPath = path.Join(filepath.Dir(b), "..", "resources", "testdata")
+)
+
+const (
+	pipelineId  = "0bf994e6-5c7e-11ee-b816-26dab184329f"
+	sourceId    = "0bf994e6-5c7e-11ee-b816-26dab111111f"
+	routeId     = "563d2374-63ae-11ee-abe2-26dab184329f"
+	unknownId   = "7b212506-23cb-11ed-b300-4ef12c27e273"
+	routeOutput = routeId + ".805821a7"
+)
+
+type fakeReader struct {
+	graph client.PipelineGraph
+}
+
+func (r *fakeReader) PipelineGraph(id string, ctx context.Context) (*client.PipelineGraph, error) {
+	return &r.graph, nil
+}
+
+func loadJsonFile[T any](t *testing.T, filename string) T {
+	t.Helper()
+	bytes, err := os.ReadFile(path.Join(testdataPath, filename))
+	if err != nil {
+		t.Fatalf("Could not read %s file. Reason: %s", filename, err)
+	}
+	var into T
+	if err := json.Unmarshal(bytes, &into); err != nil {
+		t.Fatalf("Could not encode %s to json. Reason: %s", filename, err)
+	}
+	return into
+}
+
+func newFakeReader(t *testing.T) *fakeReader {
+	source := loadJsonFile[client.Source](t, "sources/http.json")
+	route := loadJsonFile[client.Processor](t, "processors/route.json")
+	route.Inputs = []string{sourceId, unknownId}
+	destination := loadJsonFile[client.Destination](t, "destinations/http.json")
+	destination.Inputs = []string{routeOutput, routeId + "._unmatched"}
+	alert := loadJsonFile[client.Alert](t, "alerts/threshold.json")
+	alert.ComponentKind = "transform"
+	alert.ComponentId = routeId
+	alert.Inputs = []string{routeId}
+
+	return &fakeReader{graph: client.PipelineGraph{
+		Pipeline:     loadJsonFile[client.Pipeline](t, "pipeline.json"),
+		Sources:      []client.Source{source},
+		Processors:   []client.Processor{route},
+		Destinations: []client.Destination{destination},
+		Alerts:       []client.Alert{alert},
+	}}
+}
+
+func TestExport(t *testing.T) {
+	result, err := Export(context.Background(), newFakeReader(t), pipelineId)
+	if err != nil {
+		t.Fatalf("export failed. reason: %s", err)
+	}
+
+	files := make(map[string]string)
+	for _, file := range result.Files {
+		if _, diags := hclwrite.ParseConfig(file.Content, file.Name, hcl.InitialPos); diags.HasErrors() {
+			t.Fatalf("%s is not valid HCL: %s\n%s", file.Name, diags, file.Content)
+		}
+		files[file.Name] = string(file.Content)
+	}
+
+	expectations := map[string][]string{
+		"pipeline.tf": {
+			`resource "mezmo_pipeline" "new_pipeline" {`,
+			`title = "New Pipeline"`,
+		},
+		"sources.tf": {
+			`resource "mezmo_http_source" "http_source" {`,
+			`pipeline_id      = mezmo_pipeline.new_pipeline.id`,
+			`decoding         = "bytes"`,
+		},
+		"processors.tf": {
+			`resource "mezmo_route_processor" "route_processor_title" {`,
+			`inputs      = [mezmo_http_source.http_source.id, "` + unknownId + `"]`,
+		},
+		"destinations.tf": {
+			`resource "mezmo_http_destination" "http_sink_title" {`,
+			`inputs      = [mezmo_route_processor.route_processor_title.conditionals[0].output_name, mezmo_route_processor.route_processor_title.unmatched]`,
+		},
+		"alerts.tf": {
+			`resource "mezmo_threshold_alert" "my_threshold_alert" {`,
+			`component_id   = mezmo_route_processor.route_processor_title.id`,
+			`inputs         = [mezmo_route_processor.route_processor_title.id]`,
+		},
+		"imports.tf": {
+			`to = mezmo_pipeline.new_pipeline`,
+			`id = "` + pipelineId + `"`,
+			`to = mezmo_route_processor.route_processor_title`,
+			`id = "` + pipelineId + "/" + routeId + `"`,
+		},
+	}
+	for name, expected := range expectations {
+		content, ok := files[name]
+		if !ok {
+			t.Fatalf("%s was not generated", name)
+		}
+		for _, line := range expected {
+			if !strings.Contains(content, line) {
+				t.Errorf("%s does not contain %q:\n%s", name, line, content)
+			}
+		}
+	}
+
+	if strings.Contains(files["sources.tf"], "generation_id") {
+		t.Errorf("computed attributes should not be exported:\n%s", files["sources.tf"])
+	}
+
+	found := false
+	for _, warning := range result.Warnings {
+		if strings.Contains(warning, unknownId) {
+			found = true
+		}
+	}
+	if !found {
+		t.Errorf("expected a warning about the unknown input, got %v", result.Warnings)
+	}
+}
+
+func TestExportSkipsUnknownTypes(t *testing.T) {
+	reader := newFakeReader(t)
+	reader.graph.Sources[0].Type = "not-a-real-type"
+
+	result, err := Export(context.Background(), reader, pipelineId)
+	if err != nil {
+		t.Fatalf("export failed. reason: %s", err)
+	}
+	for _, file := range result.Files {
+		if file.Name == "sources.tf" {
+			t.Fatalf("unexpected sources.tf:\n%s", file.Content)
+		}
+	}
+	if len(result.Warnings) == 0 || !strings.Contains(result.Warnings[0], "not_a_real_type_source") {
+		t.Errorf("expected a warning about the skipped source, got %v", result.Warnings)
+	}
+}
+
+func TestResourceName(t *testing.T) {
+	taken := map[string]bool{}
+	cases := []struct{ title, expected string }{
+		{"My HTTP Source", "my_http_source"},
+		{"My HTTP Source", "my_http_source_2"},
+		{"", "http_source"},
+		{"!!!", "http_source_2"},
+		{"1st source", "_1st_source"},
+	}
+	for _, c := range cases {
+		if name := resourceName(c.title, "http_source", taken); name != c.expected {
+			t.Errorf("resourceName(%q) = %q, expected %q", c.title, name, c.expected)
+		}
+	}
+}
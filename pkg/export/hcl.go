@@ -0,0 +1,220 @@
+package export
+
+import (
+	"fmt"
+	"reflect"
+	"regexp"
+	"sort"
+	"strings"
+
+	"github.com/hashicorp/hcl/v2"
+	"github.com/hashicorp/hcl/v2/hclsyntax"
+	"github.com/hashicorp/hcl/v2/hclwrite"
+	"github.com/hashicorp/terraform-plugin-framework/attr"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/types/basetypes"
+	"github.com/zclconf/go-cty/cty"
+)
+
+// Attributes that are written first, in this order. Everything else follows alphabetically.
+var leadingAttributes = []string{"pipeline_id", "title", "description", "component_kind", "component_id", "inputs"}
+
+// Turns a model struct (as returned by `ConvertToTerraformModel`) into its attribute values,
+// keyed by their `tfsdk` tag.
+func modelAttributes(model reflect.Value) (map[string]attr.Value, error) {
+	if model.Kind() == reflect.Pointer {
+		model = model.Elem()
+	}
+	if model.Kind() != reflect.Struct {
+		return nil, fmt.Errorf("expected a model struct, got %s", model.Kind())
+	}
+	result := make(map[string]attr.Value, model.NumField())
+	modelType := model.Type()
+	for i := 0; i < modelType.NumField(); i++ {
+		name, ok := modelType.Field(i).Tag.Lookup("tfsdk")
+		if !ok || name == "-" {
+			continue
+		}
+		value, ok := model.Field(i).Interface().(attr.Value)
+		if !ok {
+			return nil, fmt.Errorf("field %s of %s is not an attr.Value", modelType.Field(i).Name, modelType.Name())
+		}
+		result[name] = value
+	}
+	return result, nil
+}
+
+// Attributes that cannot be written in configuration
+func isComputedOnly(attribute schema.Attribute) bool {
+	return attribute.IsComputed() && !attribute.IsOptional() && !attribute.IsRequired()
+}
+
+// The schema attributes of the objects contained in a nested attribute, if any
+func nestedAttributes(attribute schema.Attribute) map[string]schema.Attribute {
+	switch a := attribute.(type) {
+	case schema.SingleNestedAttribute:
+		return a.Attributes
+	case schema.ListNestedAttribute:
+		return a.NestedObject.Attributes
+	case schema.SetNestedAttribute:
+		return a.NestedObject.Attributes
+	case schema.MapNestedAttribute:
+		return a.NestedObject.Attributes
+	default:
+		return nil
+	}
+}
+
+func orderedNames[V any](values map[string]V) []string {
+	names := make([]string, 0, len(values))
+	for name := range values {
+		names = append(names, name)
+	}
+	sort.Slice(names, func(i, j int) bool {
+		a, b := leadingIndex(names[i]), leadingIndex(names[j])
+		if a != b {
+			return a < b
+		}
+		return names[i] < names[j]
+	})
+	return names
+}
+
+func leadingIndex(name string) int {
+	for i, leading := range leadingAttributes {
+		if name == leading {
+			return i
+		}
+	}
+	return len(leadingAttributes)
+}
+
+// Renders a value as an HCL expression. When `nested` is given, it describes the attributes
+// of object values so that computed-only attributes are left out.
+func valueTokens(value attr.Value, nested map[string]schema.Attribute) (hclwrite.Tokens, error) {
+	switch v := value.(type) {
+	case basetypes.StringValue:
+		return hclwrite.TokensForValue(cty.StringVal(v.ValueString())), nil
+	case basetypes.BoolValue:
+		return hclwrite.TokensForValue(cty.BoolVal(v.ValueBool())), nil
+	case basetypes.Int64Value:
+		return hclwrite.TokensForValue(cty.NumberIntVal(v.ValueInt64())), nil
+	case basetypes.Float64Value:
+		return hclwrite.TokensForValue(cty.NumberFloatVal(v.ValueFloat64())), nil
+	case basetypes.NumberValue:
+		return hclwrite.TokensForValue(cty.NumberVal(v.ValueBigFloat())), nil
+	case basetypes.ListValue:
+		return tupleTokens(v.Elements(), nested)
+	case basetypes.SetValue:
+		return tupleTokens(v.Elements(), nested)
+	case basetypes.MapValue:
+		elements := v.Elements()
+		items := make([]hclwrite.ObjectAttrTokens, 0, len(elements))
+		for _, key := range orderedNames(elements) {
+			if elements[key].IsNull() || elements[key].IsUnknown() {
+				continue
+			}
+			tokens, err := valueTokens(elements[key], nested)
+			if err != nil {
+				return nil, err
+			}
+			items = append(items, hclwrite.ObjectAttrTokens{
+				Name:  hclwrite.TokensForValue(cty.StringVal(key)),
+				Value: tokens,
+			})
+		}
+		return hclwrite.TokensForObject(items), nil
+	case basetypes.ObjectValue:
+		attributes := v.Attributes()
+		items := make([]hclwrite.ObjectAttrTokens, 0, len(attributes))
+		for _, name := range orderedNames(attributes) {
+			child := attributes[name]
+			if child.IsNull() || child.IsUnknown() {
+				continue
+			}
+			var childNested map[string]schema.Attribute
+			if nested != nil {
+				attribute, ok := nested[name]
+				if ok && isComputedOnly(attribute) {
+					continue
+				}
+				if ok {
+					childNested = nestedAttributes(attribute)
+				}
+			}
+			tokens, err := valueTokens(child, childNested)
+			if err != nil {
+				return nil, err
+			}
+			items = append(items, hclwrite.ObjectAttrTokens{
+				Name:  objectKeyTokens(name),
+				Value: tokens,
+			})
+		}
+		return hclwrite.TokensForObject(items), nil
+	default:
+		return nil, fmt.Errorf("unsupported value type %T", value)
+	}
+}
+
+func tupleTokens(elements []attr.Value, nested map[string]schema.Attribute) (hclwrite.Tokens, error) {
+	items := make([]hclwrite.Tokens, 0, len(elements))
+	for _, element := range elements {
+		tokens, err := valueTokens(element, nested)
+		if err != nil {
+			return nil, err
+		}
+		items = append(items, tokens)
+	}
+	return hclwrite.TokensForTuple(items), nil
+}
+
+func objectKeyTokens(name string) hclwrite.Tokens {
+	if hclsyntax.ValidIdentifier(name) {
+		return hclwrite.TokensForIdentifier(name)
+	}
+	return hclwrite.TokensForValue(cty.StringVal(name))
+}
+
+// A reference to an attribute of another resource, e.g. `mezmo_pipeline.main.id` or
+// `mezmo_route_processor.router.conditionals[0].output_name`
+type reference struct {
+	resourceType string
+	name         string
+	path         []any // string attribute names and int list indexes
+}
+
+func (r reference) traversal() hcl.Traversal {
+	traversal := hcl.Traversal{
+		hcl.TraverseRoot{Name: r.resourceType},
+		hcl.TraverseAttr{Name: r.name},
+	}
+	for _, step := range r.path {
+		switch s := step.(type) {
+		case string:
+			traversal = append(traversal, hcl.TraverseAttr{Name: s})
+		case int:
+			traversal = append(traversal, hcl.TraverseIndex{Key: cty.NumberIntVal(int64(s))})
+		}
+	}
+	return traversal
+}
+
+var nonIdentifierChars = regexp.MustCompile(`[^a-z0-9_]+`)
+
+// Builds a valid, unique resource name from a title, falling back to the given name
+func resourceName(title string, fallback string, taken map[string]bool) string {
+	name := strings.Trim(nonIdentifierChars.ReplaceAllString(strings.ToLower(title), "_"), "_")
+	if name == "" {
+		name = fallback
+	}
+	if name[0] >= '0' && name[0] <= '9' {
+		name = "_" + name
+	}
+	unique := name
+	for i := 2; taken[unique]; i++ {
+		unique = fmt.Sprintf("%s_%d", name, i)
+	}
+	taken[unique] = true
+	return unique
+}
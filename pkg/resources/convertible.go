@@ -17,6 +17,7 @@ type DestinationApiModel = client.Destination
 type AlertApiModel = client.Alert
 type BaseApiModel = client.BaseNode
 type SharedSourceApiModel = client.SharedSource
+type NotificationChannelApiModel = client.NotificationChannel
 
 type ConvertibleResourceDef interface {
 	/// the terraform resource type. example: mezmo_http_source
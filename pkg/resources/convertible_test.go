@@ -68,6 +68,8 @@ func loadResources(t *testing.T) map[string]*reflect.Value {
 	resList["mezmo_pipeline"] = &pipeline
 	shared_source := reflect.ValueOf(*loadJsonFile[SharedSourceApiModel](t, testdataPath, "shared_source.json"))
 	resList["mezmo_shared_source"] = &shared_source
+	notification_channel := reflect.ValueOf(*loadJsonFile[NotificationChannelApiModel](t, testdataPath, "notification_channel.json"))
+	resList["mezmo_notification_channel"] = &notification_channel
 
 	addToMap(t, resList, loadDirFiles[ProcessorApiModel](t, processorsPath, func(filename string) string {
 		return fmt.Sprintf("mezmo_%s_processor", filename)